@@ -3,14 +3,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
 	"github.com/Done-0/fuck-u-code/pkg/analyzer"
+	"github.com/Done-0/fuck-u-code/pkg/common"
+	"github.com/Done-0/fuck-u-code/pkg/config"
+	"github.com/Done-0/fuck-u-code/pkg/coverage"
 	"github.com/Done-0/fuck-u-code/pkg/i18n"
+	"github.com/Done-0/fuck-u-code/pkg/lsp"
+	"github.com/Done-0/fuck-u-code/pkg/metrics"
 	"github.com/Done-0/fuck-u-code/pkg/report"
 )
 
@@ -21,10 +30,25 @@ var (
 	maxIssues      int             // 每个文件最多列出的问题数
 	summaryOnly    bool            // 是否只显示结论，不看过程
 	markdownOutput bool            // 是否输出Markdown格式
+	outputFormat   string          // 输出格式
+	failThreshold  float64         // CI报告失败阈值
 	language       string          // 输出语言
 	translator     i18n.Translator // 翻译器
 	exclude        []string        // 排除的文件/目录模式
 	skipIndex      bool            // 是否跳过所有index.js/index.ts文件
+	stdinFilename  string          // 从标准输入读取时使用的文件名
+	enableMetrics  []string        // 额外启用的指标键
+	disableMetrics []string        // 禁用的指标键
+	configPath     string          // 项目配置文件路径
+	noIgnore       bool            // 是否不读取.gitignore和.fuckucodeignore
+	includeGen     bool            // 是否分析生成代码
+	workspaceMode  bool            // 是否按子项目分别评分
+	aggregation    string          // 文件得分的汇总方式
+	topFunctions   int             // 最差函数数量
+	rankFunctions  string          // 最差函数的排序依据
+	coverageFiles  []string        // 测试覆盖率文件
+	testReport     bool            // 是否单独评估测试代码
+	blame          bool            // 是否读取git blame获取技术债标记的引入时间
 )
 
 // 默认排除的模式
@@ -43,25 +67,8 @@ var defaultExcludes = []string{
 	"**/logs/**", "**/tmp/**", "**/temp/**", "**/dist/**", "**/test-results/**",
 	"**/testdata/**",
 
-	// 测试文件排除
-	// Go语言测试文件
-	"**/*_test.go", "**/testdata/**/*.go",
-
-	// Python测试文件
-	"**/test_*.py", "**/*_test.py", "**/tests/**/*.py", "**/testing/**/*.py", "**/pytest/**/*.py",
-
-	// JavaScript/TypeScript测试文件
-	"**/*.spec.js", "**/*.test.js", "**/__tests__/**/*.js", "**/test/**/*.js", "**/tests/**/*.js",
-	"**/*.spec.ts", "**/*.test.ts", "**/__tests__/**/*.ts", "**/test/**/*.ts", "**/tests/**/*.ts",
-	"**/*.spec.jsx", "**/*.test.jsx", "**/*.spec.tsx", "**/*.test.tsx",
-	"**/jest.config.js", "**/jest.setup.js", "**/jest.config.ts", "**/cypress/**",
-
-	// Java测试文件
-	"**/src/test/**/*.java", "**/*Test.java", "**/*Tests.java", "**/*IT.java", "**/JUnit/**/*.java",
-
-	// C/C++测试文件
-	"**/*_test.c", "**/*_test.cpp", "**/*_tests.c", "**/*_tests.cpp",
-	"**/test/**/**.c", "**/test/**/**.cpp", "**/tests/**/**.c", "**/tests/**/**.cpp",
+	// 测试框架、配置和第三方测试库，测试文件本身不排除，分析时单独归类
+	"**/testdata/**/*.go", "**/jest.config.js", "**/jest.setup.js", "**/jest.config.ts",
 	"**/gtest/**", "**/googletest/**", "**/catch/**", "**/boost/test/**",
 }
 
@@ -129,7 +136,31 @@ func createRootCommand() *cobra.Command {
 			}
 
 			// 运行分析
-			runAnalysis(path, lang, verbose, topFiles, maxIssues, summaryOnly, markdownOutput, exclude, skipIndex)
+			runAnalysis(analysisOptions{
+				Path:            path,
+				Language:        lang,
+				Verbose:         verbose,
+				TopFiles:        topFiles,
+				MaxIssues:       maxIssues,
+				SummaryOnly:     summaryOnly,
+				Format:          resolveFormat(outputFormat, markdownOutput),
+				FailThreshold:   failThreshold,
+				ExcludePatterns: exclude,
+				SkipIndex:       skipIndex,
+				StdinFilename:   stdinFilename,
+				EnableMetrics:   enableMetrics,
+				DisableMetrics:  disableMetrics,
+				ConfigPath:      configPath,
+				NoIgnore:        noIgnore,
+				IncludeGen:      includeGen,
+				Workspace:       workspaceMode,
+				Aggregation:     aggregation,
+				TopFunctions:    topFunctions,
+				RankFunctions:   rankFunctions,
+				CoverageFiles:   coverageFiles,
+				TestReport:      testReport,
+				Blame:           blame,
+			})
 			return nil
 		},
 	}
@@ -151,6 +182,15 @@ func addSubCommands(rootCmd *cobra.Command) {
 	// 创建completion命令
 	completionCmd := createCompletionCommand()
 
+	// 创建lsp命令
+	lspCmd := createLSPCommand()
+
+	// 创建metrics命令
+	metricsCmd := createMetricsCommand()
+
+	// 创建debt命令
+	debtCmd := createDebtCommand()
+
 	// 创建help命令
 	helpCmd := createHelpCommand(rootCmd)
 
@@ -158,7 +198,7 @@ func addSubCommands(rootCmd *cobra.Command) {
 	rootCmd.ResetCommands()
 
 	// 添加自定义命令到根命令
-	rootCmd.AddCommand(analyzeCmd, completionCmd, helpCmd)
+	rootCmd.AddCommand(analyzeCmd, metricsCmd, debtCmd, lspCmd, completionCmd, helpCmd)
 
 	// 设置help命令
 	rootCmd.SetHelpCommand(helpCmd)
@@ -189,6 +229,8 @@ func createAnalyzeCommand() *cobra.Command {
 			issuesFlag, _ := cmd.Flags().GetInt("issues")
 			summaryFlag, _ := cmd.Flags().GetBool("summary")
 			markdownFlag, _ := cmd.Flags().GetBool("markdown")
+			formatFlag, _ := cmd.Flags().GetString("format")
+			thresholdFlag, _ := cmd.Flags().GetFloat64("threshold")
 			excludePatterns, _ := cmd.Flags().GetStringArray("exclude")
 
 			// 设置语言
@@ -202,9 +244,46 @@ func createAnalyzeCommand() *cobra.Command {
 
 			// 获取skipindex选项
 			skipIndexFlag, _ := cmd.Flags().GetBool("skipindex")
+			stdinFilenameFlag, _ := cmd.Flags().GetString("stdin-filename")
+			enableMetricsFlag, _ := cmd.Flags().GetStringArray("enable-metric")
+			disableMetricsFlag, _ := cmd.Flags().GetStringArray("disable-metric")
+			configFlag, _ := cmd.Flags().GetString("config")
+			noIgnoreFlag, _ := cmd.Flags().GetBool("no-ignore")
+			includeGenFlag, _ := cmd.Flags().GetBool("include-generated")
+			workspaceFlag, _ := cmd.Flags().GetBool("workspace")
+			aggregationFlag, _ := cmd.Flags().GetString("aggregation")
+			topFunctionsFlag, _ := cmd.Flags().GetInt("top-functions")
+			rankFunctionsFlag, _ := cmd.Flags().GetString("rank-functions")
+			coverageFlag, _ := cmd.Flags().GetStringArray("coverage")
+			testReportFlag, _ := cmd.Flags().GetBool("test-report")
+			blameFlag, _ := cmd.Flags().GetBool("blame")
 
 			// 运行分析
-			runAnalysis(path, lang, verboseFlag, topFlag, issuesFlag, summaryFlag, markdownFlag, excludePatterns, skipIndexFlag)
+			runAnalysis(analysisOptions{
+				Path:            path,
+				Language:        lang,
+				Verbose:         verboseFlag,
+				TopFiles:        topFlag,
+				MaxIssues:       issuesFlag,
+				SummaryOnly:     summaryFlag,
+				Format:          resolveFormat(formatFlag, markdownFlag),
+				FailThreshold:   thresholdFlag,
+				ExcludePatterns: excludePatterns,
+				SkipIndex:       skipIndexFlag,
+				StdinFilename:   stdinFilenameFlag,
+				EnableMetrics:   enableMetricsFlag,
+				DisableMetrics:  disableMetricsFlag,
+				ConfigPath:      configFlag,
+				NoIgnore:        noIgnoreFlag,
+				IncludeGen:      includeGenFlag,
+				Workspace:       workspaceFlag,
+				Aggregation:     aggregationFlag,
+				TopFunctions:    topFunctionsFlag,
+				RankFunctions:   rankFunctionsFlag,
+				CoverageFiles:   coverageFlag,
+				TestReport:      testReportFlag,
+				Blame:           blameFlag,
+			})
 		},
 	}
 
@@ -215,12 +294,197 @@ func createAnalyzeCommand() *cobra.Command {
 	analyzeCmd.Flags().IntP("issues", "i", 5, translator.Translate("cmd.issues"))
 	analyzeCmd.Flags().BoolP("summary", "s", false, translator.Translate("cmd.summary"))
 	analyzeCmd.Flags().BoolP("markdown", "m", false, translator.Translate("cmd.markdown"))
+	analyzeCmd.Flags().StringP("format", "f", "console", translator.Translate("cmd.format"))
+	analyzeCmd.Flags().Float64("threshold", 60, translator.Translate("cmd.threshold"))
 	analyzeCmd.Flags().StringArrayP("exclude", "e", nil, translator.Translate("cmd.exclude"))
 	analyzeCmd.Flags().BoolP("skipindex", "x", false, translator.Translate("cmd.skipindex"))
+	analyzeCmd.Flags().String("stdin-filename", "", translator.Translate("cmd.stdin_filename"))
+	analyzeCmd.Flags().StringArray("enable-metric", nil, translator.Translate("cmd.enable_metric"))
+	analyzeCmd.Flags().StringArray("disable-metric", nil, translator.Translate("cmd.disable_metric"))
+	analyzeCmd.Flags().String("config", "", translator.Translate("cmd.config"))
+	analyzeCmd.Flags().Bool("no-ignore", false, translator.Translate("cmd.no_ignore"))
+	analyzeCmd.Flags().Bool("include-generated", false, translator.Translate("cmd.include_generated"))
+	analyzeCmd.Flags().Bool("workspace", false, translator.Translate("cmd.workspace"))
+	analyzeCmd.Flags().String("aggregation", string(analyzer.DefaultAggregation), translator.Translate("cmd.aggregation"))
+	analyzeCmd.Flags().Int("top-functions", 10, translator.Translate("cmd.top_functions"))
+	analyzeCmd.Flags().String("rank-functions", string(analyzer.DefaultFunctionRanking), translator.Translate("cmd.rank_functions"))
+	analyzeCmd.Flags().StringArray("coverage", nil, translator.Translate("cmd.coverage"))
+	analyzeCmd.Flags().Bool("test-report", false, translator.Translate("cmd.test_report"))
+	analyzeCmd.Flags().Bool("blame", false, translator.Translate("cmd.blame"))
 
 	return analyzeCmd
 }
 
+// createLSPCommand 创建lsp命令
+func createLSPCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "lsp",
+		Short: translator.Translate("cmd.lsp"),
+		Long:  translator.Translate("cmd.lsp.long"),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			// 标准输出用于协议通信，错误只能写到标准错误
+			server := lsp.NewServer(os.Stdin, os.Stdout, translator)
+			if err := server.Run(); err != nil {
+				fmt.Fprintln(os.Stderr, translator.Translate("cmd.lsp_failed", err))
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+// createMetricsCommand 创建metrics命令
+func createMetricsCommand() *cobra.Command {
+	metricsCmd := &cobra.Command{
+		Use:   "metrics",
+		Short: translator.Translate("cmd.metrics"),
+		Long:  translator.Translate("cmd.metrics.long"),
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: translator.Translate("cmd.metrics.list"),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			listMetrics(os.Stdout)
+		},
+	}
+
+	metricsCmd.AddCommand(listCmd)
+
+	return metricsCmd
+}
+
+// listMetrics 以表格形式输出所有已注册的指标
+func listMetrics(out io.Writer) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+		translator.Translate("metrics.list.key"),
+		translator.Translate("metrics.list.name"),
+		translator.Translate("metrics.list.weight"),
+		translator.Translate("metrics.list.languages"),
+		translator.Translate("metrics.list.status"))
+
+	for _, info := range metrics.Registered() {
+		languages := translator.Translate("metrics.list.all_languages")
+		if len(info.Languages) > 0 {
+			names := make([]string, 0, len(info.Languages))
+			for _, lang := range info.Languages {
+				names = append(names, string(lang))
+			}
+			languages = strings.Join(names, ", ")
+		}
+
+		status := translator.Translate("metrics.list.enabled")
+		if info.DisabledByDefault {
+			status = translator.Translate("metrics.list.disabled")
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%.2f\t%s\t%s\n", info.Key, translator.Translate(info.NameKey), info.Weight, languages, status)
+	}
+
+	w.Flush()
+}
+
+// createDebtCommand 创建debt命令
+func createDebtCommand() *cobra.Command {
+	debtCmd := &cobra.Command{
+		Use:   "debt [path]",
+		Short: translator.Translate("cmd.debt"),
+		Long:  translator.Translate("cmd.debt.long"),
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			path := "."
+			if len(args) > 0 {
+				path = args[0]
+			}
+
+			formatFlag, _ := cmd.Flags().GetString("format")
+			blameFlag, _ := cmd.Flags().GetBool("blame")
+			excludePatterns, _ := cmd.Flags().GetStringArray("exclude")
+			configFlag, _ := cmd.Flags().GetString("config")
+			noIgnoreFlag, _ := cmd.Flags().GetBool("no-ignore")
+			includeGenFlag, _ := cmd.Flags().GetBool("include-generated")
+			ticketPatternFlag, _ := cmd.Flags().GetString("ticket-pattern")
+
+			runDebt(debtOptions{
+				Path:            path,
+				Format:          strings.ToLower(formatFlag),
+				Blame:           blameFlag,
+				ExcludePatterns: excludePatterns,
+				ConfigPath:      configFlag,
+				NoIgnore:        noIgnoreFlag,
+				IncludeGen:      includeGenFlag,
+				TicketPattern:   ticketPatternFlag,
+			})
+		},
+	}
+
+	debtCmd.Flags().StringP("format", "f", "text", translator.Translate("cmd.debt.format"))
+	debtCmd.Flags().Bool("blame", false, translator.Translate("cmd.blame"))
+	debtCmd.Flags().StringArrayP("exclude", "e", nil, translator.Translate("cmd.exclude"))
+	debtCmd.Flags().String("config", "", translator.Translate("cmd.config"))
+	debtCmd.Flags().Bool("no-ignore", false, translator.Translate("cmd.no_ignore"))
+	debtCmd.Flags().Bool("include-generated", false, translator.Translate("cmd.include_generated"))
+	debtCmd.Flags().String("ticket-pattern", "", translator.Translate("cmd.ticket_pattern"))
+
+	return debtCmd
+}
+
+// debtOptions debt命令的选项
+type debtOptions struct {
+	Path            string   // 扫描路径
+	Format          string   // 输出格式
+	Blame           bool     // 是否读取git blame获取引入时间
+	ExcludePatterns []string // 排除模式
+	ConfigPath      string   // 项目配置文件路径，为空时自动查找
+	NoIgnore        bool     // 是否不读取.gitignore和.fuckucodeignore
+	IncludeGen      bool     // 是否扫描生成代码
+	TicketPattern   string   // 工单号正则表达式，为空时使用项目配置
+}
+
+// runDebt 列出技术债标记
+func runDebt(opts debtOptions) {
+	switch opts.Format {
+	case "text", "json", "csv":
+	default:
+		fmt.Fprintf(os.Stderr, translator.Translate("cmd.unknown_format")+"\n", opts.Format)
+		os.Exit(1)
+	}
+
+	cfg, err := loadConfig(analysisOptions{Path: opts.Path, ConfigPath: opts.ConfigPath})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, translator.Translate("cmd.config_failed")+"\n", err)
+		os.Exit(1)
+	}
+	ticketPattern := opts.TicketPattern
+	if ticketPattern == "" && cfg != nil {
+		ticketPattern = cfg.Debt.TicketPattern
+	}
+
+	engine, err := analyzer.NewEngine(
+		analyzer.WithTranslator(translator),
+		analyzer.WithExcludes(append(opts.ExcludePatterns, defaultExcludes...)...),
+		analyzer.WithNoIgnore(opts.NoIgnore),
+		analyzer.WithIncludeGenerated(opts.IncludeGen),
+		analyzer.WithTicketPattern(ticketPattern),
+		analyzer.WithBlame(opts.Blame),
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, translator.Translate("cmd.debt_failed")+"\n", err)
+		os.Exit(1)
+	}
+
+	markers, err := engine.FindDebtMarkers(context.Background(), opts.Path)
+	if err == nil {
+		err = report.WriteDebtMarkers(os.Stdout, markers, opts.Format, translator)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, translator.Translate("cmd.debt_failed")+"\n", err)
+		os.Exit(1)
+	}
+}
+
 // createCompletionCommand 创建completion命令
 func createCompletionCommand() *cobra.Command {
 	completionCmd := &cobra.Command{
@@ -348,8 +612,23 @@ func addFlags(cmd *cobra.Command) {
 	cmd.Flags().IntVarP(&maxIssues, "issues", "i", 5, translator.Translate("cmd.issues"))
 	cmd.Flags().BoolVarP(&summaryOnly, "summary", "s", false, translator.Translate("cmd.summary"))
 	cmd.Flags().BoolVarP(&markdownOutput, "markdown", "m", false, translator.Translate("cmd.markdown"))
+	cmd.Flags().StringVarP(&outputFormat, "format", "f", "console", translator.Translate("cmd.format"))
+	cmd.Flags().Float64Var(&failThreshold, "threshold", 60, translator.Translate("cmd.threshold"))
 	cmd.Flags().StringArrayVarP(&exclude, "exclude", "e", nil, translator.Translate("cmd.exclude"))
 	cmd.Flags().BoolVarP(&skipIndex, "skipindex", "x", false, translator.Translate("cmd.skipindex"))
+	cmd.Flags().StringVar(&stdinFilename, "stdin-filename", "", translator.Translate("cmd.stdin_filename"))
+	cmd.Flags().StringArrayVar(&enableMetrics, "enable-metric", nil, translator.Translate("cmd.enable_metric"))
+	cmd.Flags().StringArrayVar(&disableMetrics, "disable-metric", nil, translator.Translate("cmd.disable_metric"))
+	cmd.Flags().StringVar(&configPath, "config", "", translator.Translate("cmd.config"))
+	cmd.Flags().BoolVar(&noIgnore, "no-ignore", false, translator.Translate("cmd.no_ignore"))
+	cmd.Flags().BoolVar(&includeGen, "include-generated", false, translator.Translate("cmd.include_generated"))
+	cmd.Flags().BoolVar(&workspaceMode, "workspace", false, translator.Translate("cmd.workspace"))
+	cmd.Flags().StringVar(&aggregation, "aggregation", string(analyzer.DefaultAggregation), translator.Translate("cmd.aggregation"))
+	cmd.Flags().IntVar(&topFunctions, "top-functions", 10, translator.Translate("cmd.top_functions"))
+	cmd.Flags().StringVar(&rankFunctions, "rank-functions", string(analyzer.DefaultFunctionRanking), translator.Translate("cmd.rank_functions"))
+	cmd.Flags().StringArrayVar(&coverageFiles, "coverage", nil, translator.Translate("cmd.coverage"))
+	cmd.Flags().BoolVar(&testReport, "test-report", false, translator.Translate("cmd.test_report"))
+	cmd.Flags().BoolVar(&blame, "blame", false, translator.Translate("cmd.blame"))
 }
 
 // setLanguage 设置语言
@@ -375,6 +654,17 @@ func updateCommandDescriptions(cmd *cobra.Command) {
 		if c.Use == "analyze [path]" {
 			c.Short = translator.Translate("cmd.analyze")
 			c.Long = translator.Translate("cmd.analyze.long")
+		} else if c.Name() == "metrics" {
+			c.Short = translator.Translate("cmd.metrics")
+			c.Long = translator.Translate("cmd.metrics.long")
+		} else if c.Name() == "list" && c.Parent() != nil && c.Parent().Name() == "metrics" {
+			c.Short = translator.Translate("cmd.metrics.list")
+		} else if c.Name() == "debt" {
+			c.Short = translator.Translate("cmd.debt")
+			c.Long = translator.Translate("cmd.debt.long")
+		} else if c.Name() == "lsp" {
+			c.Short = translator.Translate("cmd.lsp")
+			c.Long = translator.Translate("cmd.lsp.long")
 		} else if c.Name() == "completion" {
 			updateCompletionCommand(c)
 		} else if c.Name() == "help" {
@@ -393,16 +683,32 @@ func updateCommandDescriptions(cmd *cobra.Command) {
 // updateFlagDescriptions 更新标志描述
 func updateFlagDescriptions(cmd *cobra.Command) {
 	flagDescriptions := map[string]string{
-		"lang":            "cmd.lang",
-		"verbose":         "cmd.verbose",
-		"top":             "cmd.top",
-		"issues":          "cmd.issues",
-		"summary":         "cmd.summary",
-		"markdown":        "cmd.markdown",
-		"exclude":         "cmd.exclude",
-		"skipindex":       "cmd.skipindex",
-		"help":            "cmd.help_flag",
-		"no-descriptions": "cmd.no_descriptions",
+		"lang":              "cmd.lang",
+		"verbose":           "cmd.verbose",
+		"top":               "cmd.top",
+		"issues":            "cmd.issues",
+		"summary":           "cmd.summary",
+		"markdown":          "cmd.markdown",
+		"format":            "cmd.format",
+		"threshold":         "cmd.threshold",
+		"exclude":           "cmd.exclude",
+		"skipindex":         "cmd.skipindex",
+		"stdin-filename":    "cmd.stdin_filename",
+		"enable-metric":     "cmd.enable_metric",
+		"disable-metric":    "cmd.disable_metric",
+		"config":            "cmd.config",
+		"no-ignore":         "cmd.no_ignore",
+		"include-generated": "cmd.include_generated",
+		"workspace":         "cmd.workspace",
+		"aggregation":       "cmd.aggregation",
+		"top-functions":     "cmd.top_functions",
+		"rank-functions":    "cmd.rank_functions",
+		"coverage":          "cmd.coverage",
+		"test-report":       "cmd.test_report",
+		"blame":             "cmd.blame",
+		"ticket-pattern":    "cmd.ticket_pattern",
+		"help":              "cmd.help_flag",
+		"no-descriptions":   "cmd.no_descriptions",
 	}
 
 	// 更新持久标志
@@ -418,6 +724,11 @@ func updateFlagDescriptions(cmd *cobra.Command) {
 			flag.Usage = translator.Translate(key)
 		}
 	}
+
+	// debt命令的输出格式与analyze不同
+	if flag := cmd.Flags().Lookup("format"); flag != nil && cmd.Name() == "debt" {
+		flag.Usage = translator.Translate("cmd.debt.format")
+	}
 }
 
 // updateCompletionCommand 更新completion命令的描述
@@ -470,20 +781,122 @@ func updateCompletionCommand(cmd *cobra.Command) {
 	}
 }
 
+// resolveFormat 根据--format和--markdown选项确定输出格式
+func resolveFormat(format string, markdown bool) string {
+	if markdown {
+		return "markdown"
+	}
+	return strings.ToLower(format)
+}
+
+// analysisOptions 一次分析运行的选项
+type analysisOptions struct {
+	Path            string        // 分析路径，- 表示标准输入
+	Language        i18n.Language // 输出语言
+	Verbose         bool          // 是否输出详细报告
+	TopFiles        int           // 问题最多的文件数量
+	MaxIssues       int           // 每个文件最多列出的问题数
+	SummaryOnly     bool          // 是否只显示结论
+	Format          string        // 输出格式
+	FailThreshold   float64       // CI报告失败阈值
+	ExcludePatterns []string      // 排除的文件/目录模式
+	SkipIndex       bool          // 是否跳过所有index.js/index.ts文件
+	StdinFilename   string        // 从标准输入读取时使用的文件名
+	EnableMetrics   []string      // 额外启用的指标键
+	DisableMetrics  []string      // 禁用的指标键
+	ConfigPath      string        // 项目配置文件路径，为空时自动查找
+	NoIgnore        bool          // 是否不读取.gitignore和.fuckucodeignore
+	IncludeGen      bool          // 是否分析生成代码
+	Workspace       bool          // 是否按子项目分别评分
+	Aggregation     string        // 文件得分的汇总方式
+	TopFunctions    int           // 最差函数数量
+	RankFunctions   string        // 最差函数的排序依据
+	CoverageFiles   []string      // 测试覆盖率文件
+	TestReport      bool          // 是否单独评估测试代码
+	Blame           bool          // 是否读取git blame获取技术债标记的引入时间
+}
+
+// loadConfig 加载项目配置，未指定配置文件时从分析路径向上查找，找不到时返回nil
+func loadConfig(opts analysisOptions) (*config.Config, error) {
+	path := opts.ConfigPath
+	if path == "" {
+		startDir := opts.Path
+		if opts.Path == "-" {
+			startDir = filepath.Dir(opts.StdinFilename)
+		} else if info, err := os.Stat(opts.Path); err == nil && !info.IsDir() {
+			startDir = filepath.Dir(opts.Path)
+		}
+
+		if path = config.Find(startDir); path == "" {
+			return nil, nil
+		}
+	}
+
+	return config.Load(path)
+}
+
+// configOptions 将项目配置中的自定义规则、命名风格和工单号格式转换为引擎选项
+func configOptions(cfg *config.Config) ([]analyzer.Option, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	ruleSet, err := cfg.RuleSet()
+	if err != nil {
+		return nil, err
+	}
+	namingStyles, err := cfg.NamingStyles()
+	if err != nil {
+		return nil, err
+	}
+	return []analyzer.Option{
+		analyzer.WithRules(ruleSet),
+		analyzer.WithNamingStyles(namingStyles),
+		analyzer.WithTicketPattern(cfg.Debt.TicketPattern),
+	}, nil
+}
+
 // runAnalysis 运行代码分析
-func runAnalysis(path string, lang i18n.Language, verbose bool, topFiles int, maxIssues int, summaryOnly bool, markdownOutput bool, excludePatterns []string, skipIndex bool) {
+func runAnalysis(opts analysisOptions) {
 	// 设置翻译器
-	translator := i18n.NewTranslator(lang)
+	translator := i18n.NewTranslator(opts.Language)
+
+	// 检查输出格式
+	switch opts.Format {
+	case "console", "markdown", "html", "json", "junit", "checkstyle":
+	default:
+		fmt.Fprintf(os.Stderr, translator.Translate("cmd.unknown_format")+"\n", opts.Format)
+		os.Exit(1)
+	}
+
+	// 检查最差函数的排序依据
+	ranking, ok := analyzer.ParseFunctionRanking(strings.ToLower(opts.RankFunctions))
+	if !ok {
+		fmt.Fprintf(os.Stderr, translator.Translate("cmd.unknown_function_ranking")+"\n", opts.RankFunctions)
+		os.Exit(1)
+	}
+
+	// 路径为 - 时从标准输入读取代码，由文件名识别语言
+	fromStdin := opts.Path == "-"
+	if fromStdin && !common.NewLanguageDetector().IsSupportedFile(opts.StdinFilename) {
+		fmt.Fprintf(os.Stderr, translator.Translate("cmd.stdin_unsupported")+"\n", opts.StdinFilename)
+		os.Exit(1)
+	}
 
-	// 只在非markdown模式下输出分析过程信息
-	if !markdownOutput {
+	// 只在控制台模式下输出分析过程信息
+	consoleOutput := opts.Format == "console"
+	if consoleOutput {
 		// 输出开始分析信息
-		fmt.Printf("🔍 %s\n", translator.Translate("cmd.start_analyzing", path))
+		displayPath := opts.Path
+		if fromStdin {
+			displayPath = opts.StdinFilename
+		}
+		fmt.Printf("🔍 %s\n", translator.Translate("cmd.start_analyzing", displayPath))
 
 		// 如果有排除模式，输出排除模式
-		if len(excludePatterns) > 0 {
+		if len(opts.ExcludePatterns) > 0 {
 			fmt.Printf("📂 %s\n", translator.Translate("cmd.exclude_patterns"))
-			for _, pattern := range excludePatterns {
+			for _, pattern := range opts.ExcludePatterns {
 				fmt.Printf("  - %s\n", pattern)
 			}
 			fmt.Println()
@@ -491,38 +904,136 @@ func runAnalysis(path string, lang i18n.Language, verbose bool, topFiles int, ma
 	}
 
 	// 添加默认排除模式
-	excludePatterns = append(excludePatterns, defaultExcludes...)
+	excludePatterns := append(opts.ExcludePatterns, defaultExcludes...)
 
 	// 如果启用了skipindex选项，添加index文件排除模式
-	if skipIndex {
+	if opts.SkipIndex {
 		excludePatterns = append(excludePatterns, "**/index.js", "**/index.ts", "**/index.jsx", "**/index.tsx")
 	}
 
-	// 创建分析器
-	analyzer := analyzer.NewAnalyzer()
-	analyzer.SetLanguage(lang)
-	analyzer.SetSilent(markdownOutput) // 在markdown模式下使用静默模式
+	// 创建分析引擎，只在控制台模式下显示进度
+	engineOptions := []analyzer.Option{
+		analyzer.WithTranslator(translator),
+		analyzer.WithExcludes(excludePatterns...),
+		analyzer.WithNoIgnore(opts.NoIgnore),
+		analyzer.WithIncludeGenerated(opts.IncludeGen),
+		analyzer.WithAggregation(analyzer.Aggregation(strings.ToLower(opts.Aggregation))),
+		analyzer.WithEnabledMetrics(opts.EnableMetrics...),
+		analyzer.WithDisabledMetrics(opts.DisableMetrics...),
+		analyzer.WithTestReport(opts.TestReport),
+		analyzer.WithBlame(opts.Blame),
+	}
+	if consoleOutput {
+		engineOptions = append(engineOptions, analyzer.WithProgress(analyzer.NewConsoleProgress(os.Stdout, os.Stderr, translator)))
+	}
+
+	// 加载项目配置中的自定义规则和命名风格
+	cfg, err := loadConfig(opts)
+	if err == nil {
+		var cfgOptions []analyzer.Option
+		cfgOptions, err = configOptions(cfg)
+		engineOptions = append(engineOptions, cfgOptions...)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, translator.Translate("cmd.config_failed")+"\n", err)
+		os.Exit(1)
+	}
+
+	// 加载测试覆盖率
+	if len(opts.CoverageFiles) > 0 {
+		profile, err := coverage.Load(opts.CoverageFiles...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, translator.Translate("cmd.coverage_failed")+"\n", err)
+			os.Exit(1)
+		}
+		engineOptions = append(engineOptions, analyzer.WithCoverage(profile))
+	}
+
+	engine, err := analyzer.NewEngine(engineOptions...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, translator.Translate("cmd.analysis_failed"), err)
+		os.Exit(1)
+	}
 
-	// 分析代码
-	result, err := analyzer.AnalyzeWithExcludes(path, nil, excludePatterns)
+	// 分析代码，工作区模式下各子项目单独评分
+	var result *analyzer.AnalysisResult
+	var workspaceResult *analyzer.WorkspaceResult
+	if opts.Workspace && !fromStdin {
+		workspaceResult, err = engine.AnalyzeWorkspace(context.Background(), opts.Path)
+		if err == nil {
+			result = workspaceResult.Overall
+		}
+	} else if fromStdin {
+		content, readErr := io.ReadAll(os.Stdin)
+		if readErr != nil {
+			fmt.Fprintf(os.Stderr, translator.Translate("cmd.stdin_read_failed")+"\n", readErr)
+			os.Exit(1)
+		}
+		result, err = engine.AnalyzeContent(context.Background(), opts.StdinFilename, content)
+	} else {
+		result, err = engine.Analyze(context.Background(), opts.Path)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, translator.Translate("cmd.analysis_failed"), err)
 		os.Exit(1)
 	}
 
+	// 控制台模式下失败的文件已由进度显示输出
+	if !consoleOutput {
+		for _, failed := range result.FailedFiles {
+			fmt.Fprintf(os.Stderr, translator.Translate("error.file_analysis_failed")+"\n", failed.FilePath, failed.Err)
+		}
+	}
+
 	// 创建报告
 	reportGen := report.NewReport(result)
 	reportGen.SetTranslator(translator)
 
 	// 设置报告选项
 	options := &report.ReportOptions{
-		Verbose:        verbose || topFiles > 10,
-		TopFiles:       topFiles,
-		MaxIssues:      maxIssues,
-		SummaryOnly:    summaryOnly,
-		MarkdownOutput: markdownOutput,
+		Verbose:         opts.Verbose || opts.TopFiles > 10,
+		TopFiles:        opts.TopFiles,
+		MaxIssues:       opts.MaxIssues,
+		SummaryOnly:     opts.SummaryOnly,
+		MarkdownOutput:  opts.Format == "markdown",
+		FailThreshold:   opts.FailThreshold,
+		TopFunctions:    opts.TopFunctions,
+		FunctionRanking: ranking,
+	}
+
+	// 工作区报告只支持控制台、Markdown和JSON，其余格式使用合并后的结果
+	if workspaceResult != nil {
+		workspaceReport := report.NewWorkspaceReport(workspaceResult)
+		workspaceReport.SetTranslator(translator)
+		switch opts.Format {
+		case "console", "markdown":
+			workspaceReport.GenerateConsoleReport(options)
+			return
+		case "json":
+			if err = workspaceReport.GenerateJSONReport(options); err != nil {
+				fmt.Fprintf(os.Stderr, translator.Translate("cmd.report_failed"), err)
+				os.Exit(1)
+			}
+			return
+		}
 	}
 
 	// 生成报告
-	reportGen.GenerateConsoleReport(options)
+	switch opts.Format {
+	case "html":
+		err = reportGen.GenerateHTMLReport(options)
+	case "json":
+		err = reportGen.GenerateJSONReport(options)
+	case "junit":
+		err = reportGen.GenerateJUnitReport(options)
+	case "checkstyle":
+		err = reportGen.GenerateCheckstyleReport(options)
+	default:
+		reportGen.GenerateConsoleReport(options)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, translator.Translate("cmd.report_failed"), err)
+		os.Exit(1)
+	}
 }
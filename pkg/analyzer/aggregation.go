@@ -0,0 +1,87 @@
+// Package analyzer 提供代码分析功能
+// 创建者：Done-0
+package analyzer
+
+import (
+	"math"
+	"sort"
+)
+
+// Aggregation 将多个文件的得分汇总为一个得分的策略
+type Aggregation string
+
+// 汇总策略
+const (
+	AggregateLines     Aggregation = "loc"       // 按代码行数加权平均
+	AggregateFunctions Aggregation = "functions" // 按函数数量加权平均
+	AggregateP90       Aggregation = "p90"       // 第90百分位，反映最差的一批文件
+	AggregateMax       Aggregation = "max"       // 最差文件的得分
+)
+
+// DefaultAggregation 默认汇总策略
+const DefaultAggregation = AggregateLines
+
+// Aggregations 返回所有汇总策略
+func Aggregations() []Aggregation {
+	return []Aggregation{AggregateLines, AggregateFunctions, AggregateP90, AggregateMax}
+}
+
+// ParseAggregation 解析汇总策略名称，为空时返回默认策略
+func ParseAggregation(name string) (Aggregation, bool) {
+	if name == "" {
+		return DefaultAggregation, true
+	}
+	for _, a := range Aggregations() {
+		if string(a) == name {
+			return a, true
+		}
+	}
+	return "", false
+}
+
+// DescriptionKey 返回策略说明的翻译键
+func (a Aggregation) DescriptionKey() string {
+	return "aggregation." + string(a)
+}
+
+// scoreSample 参与汇总的一个得分及其权重信息
+type scoreSample struct {
+	score     float64 // 得分(0-1)
+	lines     int     // 代码行数
+	functions int     // 函数数量
+}
+
+// aggregate 按策略汇总得分，没有样本时返回0
+func (a Aggregation) aggregate(samples []scoreSample) float64 {
+	if len(samples) == 0 {
+		return 0.0
+	}
+
+	switch a {
+	case AggregateP90, AggregateMax:
+		scores := make([]float64, len(samples))
+		for i, s := range samples {
+			scores[i] = s.score
+		}
+		sort.Float64s(scores)
+		if a == AggregateMax {
+			return scores[len(scores)-1]
+		}
+		// 最近秩法计算百分位
+		rank := int(math.Ceil(0.9*float64(len(scores)))) - 1
+		return scores[max(rank, 0)]
+	default:
+		totalScore := 0.0
+		totalWeight := 0
+		for _, s := range samples {
+			// 空文件或没有函数的文件也占一份权重，避免全部为空时无法计算得分
+			weight := max(s.lines, 1)
+			if a == AggregateFunctions {
+				weight = max(s.functions, 1)
+			}
+			totalScore += s.score * float64(weight)
+			totalWeight += weight
+		}
+		return totalScore / float64(totalWeight)
+	}
+}
@@ -3,20 +3,21 @@
 package analyzer
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"strings"
-	"sync"
-	"time"
 
 	"github.com/Done-0/fuck-u-code/pkg/common"
+	"github.com/Done-0/fuck-u-code/pkg/coverage"
+	"github.com/Done-0/fuck-u-code/pkg/debt"
 	"github.com/Done-0/fuck-u-code/pkg/i18n"
 	"github.com/Done-0/fuck-u-code/pkg/metrics"
 	"github.com/Done-0/fuck-u-code/pkg/parser"
-	"github.com/fatih/color"
+	"github.com/Done-0/fuck-u-code/pkg/rules"
 )
 
 // Analyzer 代码分析器接口
+// 嵌入到其他程序中时建议使用Engine，它支持context和函数式选项，且不会写入标准输出
 type Analyzer interface {
 	// Analyze 分析指定路径的代码
 	Analyze(path string) (*AnalysisResult, error)
@@ -24,6 +25,9 @@ type Analyzer interface {
 	// AnalyzeFile 分析单个文件
 	AnalyzeFile(filePath string) (*AnalysisResult, error)
 
+	// AnalyzeContent 分析内存中的代码内容，path用于识别语言
+	AnalyzeContent(path string, content []byte) (*AnalysisResult, error)
+
 	// AnalyzeWithExcludes 使用指定的包含/排除模式分析目录
 	AnalyzeWithExcludes(path string, includePatterns []string, excludePatterns []string) (*AnalysisResult, error)
 
@@ -41,6 +45,28 @@ type AnalysisResult struct {
 	FilesAnalyzed    []FileAnalysisResult    // 分析的文件结果
 	TotalFiles       int                     // 总文件数
 	TotalLines       int                     // 总代码行数
+	FailedFiles      []FileError             // 分析失败的文件
+	Suppressed       []SuppressedIssue       // 被抑制注释忽略的问题
+	SkippedFiles     []SkippedFile           // 识别为生成代码而跳过的文件
+	Directories      *DirectoryResult        // 按目录层级汇总的结果，只在分析目录时生成
+	Aggregation      Aggregation             // 汇总文件得分使用的策略，只在分析目录时设置
+	Coupling         []PackageCoupling       // 包级耦合度，按与主序列的距离从大到小排序，只在分析目录时生成
+	Tests            *TestSummary            // 测试文件与生产文件的匹配情况，只在分析目录时生成
+	TestQuality      *AnalysisResult         // 测试代码自身的质量，只在启用测试报告时生成
+	Debt             *DebtSummary            // 技术债标记汇总，只在分析目录时生成
+}
+
+// SkippedFile 跳过分析的文件
+type SkippedFile struct {
+	FilePath string // 文件路径
+	Reason   string // 跳过原因
+}
+
+// SuppressedIssue 被抑制注释忽略的问题，不计入评分
+type SuppressedIssue struct {
+	FilePath string // 文件路径
+	Metric   string // 指标名称
+	Issue    string // 问题描述
 }
 
 // MetricResult 指标结果
@@ -53,31 +79,46 @@ type MetricResult struct {
 
 // FileAnalysisResult 文件分析结果
 type FileAnalysisResult struct {
-	FilePath  string   // 文件路径
-	FileScore float64  // 文件得分
-	Issues    []string // 问题列表
+	FilePath      string           // 文件路径
+	FileScore     float64          // 文件得分
+	TotalLines    int              // 文件行数
+	FunctionCount int              // 函数数量
+	Functions     []FunctionResult // 函数分析结果
+	Issues        []string         // 问题列表
+
+	MaintainabilityIndex float64                 // 文件的可维护性指数，0-100，越高越好
+	Classes              []metrics.ClassCohesion // 文件中各类的内聚度和规模
+
+	HasCoverage bool    // 是否有测试覆盖率数据
+	Coverage    float64 // 行覆盖率，0-100
+
+	Source []byte // 分析的源码内容，只在分析标准输入等内存中的内容时保存，为空时从FilePath读取
+}
+
+// ReadSource 读取文件分析时的源码，优先使用分析时保存的内容
+func (f FileAnalysisResult) ReadSource() ([]byte, error) {
+	if f.Source != nil {
+		return f.Source, nil
+	}
+	return os.ReadFile(f.FilePath)
 }
 
 // DefaultAnalyzer 默认分析器实现
 type DefaultAnalyzer struct {
-	codeAnalyzer *CodeAnalyzer
-	translator   i18n.Translator
-	silent       bool // 静默模式，不输出进度信息
+	translator i18n.Translator
+	silent     bool // 静默模式，不输出进度信息
 }
 
 // NewAnalyzer 创建新的代码分析器
 func NewAnalyzer() Analyzer {
-	translator := i18n.NewTranslator(i18n.ZhCN)
 	return &DefaultAnalyzer{
-		codeAnalyzer: NewCodeAnalyzer(translator),
-		translator:   translator,
+		translator: i18n.NewTranslator(i18n.ZhCN),
 	}
 }
 
 // SetLanguage 设置分析器使用的语言
 func (a *DefaultAnalyzer) SetLanguage(lang i18n.Language) {
 	a.translator = i18n.NewTranslator(lang)
-	a.codeAnalyzer.SetTranslator(a.translator)
 }
 
 // SetSilent 设置静默模式
@@ -87,27 +128,50 @@ func (a *DefaultAnalyzer) SetSilent(silent bool) {
 
 // Analyze 分析指定路径的代码
 func (a *DefaultAnalyzer) Analyze(path string) (*AnalysisResult, error) {
-	info, err := os.Stat(path)
+	return a.AnalyzeWithExcludes(path, nil, []string{"*/vendor/*", "*/node_modules/*", "*/.git/*"})
+}
+
+// AnalyzeFile 分析单个文件
+func (a *DefaultAnalyzer) AnalyzeFile(filePath string) (*AnalysisResult, error) {
+	return a.newEngine().AnalyzeFile(context.Background(), filePath)
+}
+
+// AnalyzeContent 分析内存中的代码内容，path用于识别语言
+func (a *DefaultAnalyzer) AnalyzeContent(path string, content []byte) (*AnalysisResult, error) {
+	return a.newEngine().AnalyzeContent(context.Background(), path, content)
+}
+
+// AnalyzeWithExcludes 使用指定的包含/排除模式分析目录
+func (a *DefaultAnalyzer) AnalyzeWithExcludes(path string, includePatterns []string, excludePatterns []string) (*AnalysisResult, error) {
+	result, err := a.newEngine(WithIncludes(includePatterns...), WithExcludes(excludePatterns...)).Analyze(context.Background(), path)
 	if err != nil {
-		return nil, fmt.Errorf(a.translator.Translate("error.path_not_accessible"), err)
+		return nil, err
 	}
 
-	if info.IsDir() {
-		return a.AnalyzeWithExcludes(path, nil, []string{"*/vendor/*", "*/node_modules/*", "*/.git/*"})
+	// 静默模式下没有进度显示，失败的文件仍然输出到标准错误
+	if a.silent {
+		for _, failed := range result.FailedFiles {
+			fmt.Fprintf(os.Stderr, a.translator.Translate("error.file_analysis_failed")+"\n", failed.FilePath, failed.Err)
+		}
 	}
 
-	return a.AnalyzeFile(path)
+	return result, nil
 }
 
-// AnalyzeFile 分析单个文件
-func (a *DefaultAnalyzer) AnalyzeFile(filePath string) (*AnalysisResult, error) {
-	// 使用内部的CodeAnalyzer分析文件
-	fileResult, err := a.codeAnalyzer.AnalyzeFile(filePath)
-	if err != nil {
-		return nil, err
+// newEngine 根据当前语言和静默设置创建分析引擎
+func (a *DefaultAnalyzer) newEngine(opts ...Option) *Engine {
+	opts = append([]Option{WithTranslator(a.translator)}, opts...)
+	if !a.silent {
+		opts = append(opts, WithProgress(NewConsoleProgress(os.Stdout, os.Stderr, a.translator)))
 	}
 
-	// 转换为AnalysisResult
+	// 未指定指标时不会出错
+	engine, _ := NewEngine(opts...)
+	return engine
+}
+
+// newSingleFileResult 将单个文件的分析结果转换为AnalysisResult
+func newSingleFileResult(fileResult *metrics.AnalysisResult) *AnalysisResult {
 	result := &AnalysisResult{
 		CodeQualityScore: fileResult.GetOverallScore(),
 		Metrics:          make(map[string]MetricResult),
@@ -128,231 +192,54 @@ func (a *DefaultAnalyzer) AnalyzeFile(filePath string) (*AnalysisResult, error)
 
 	// 添加文件分析结果
 	result.FilesAnalyzed = append(result.FilesAnalyzed, FileAnalysisResult{
-		FilePath:  filePath,
-		FileScore: fileResult.GetOverallScore(),
-		Issues:    fileResult.GetIssues(),
-	})
-
-	return result, nil
+		FilePath:      fileResult.FilePath,
+		FileScore:     fileResult.GetOverallScore(),
+		TotalLines:    fileResult.TotalLines,
+		FunctionCount: len(fileResult.Functions),
+		Functions:     newFunctionResults(fileResult),
+		Issues:        fileResult.GetIssues(),
+
+		MaintainabilityIndex: fileMaintainabilityIndex(fileResult),
+		Classes:              fileClasses(fileResult),
+	}.withCoverage(fileResult))
+	result.Suppressed = appendSuppressed(result.Suppressed, fileResult)
+
+	return result
 }
 
-// AnalyzeWithExcludes 使用指定的包含/排除模式分析目录
-func (a *DefaultAnalyzer) AnalyzeWithExcludes(path string, includePatterns []string, excludePatterns []string) (*AnalysisResult, error) {
-	info, err := os.Stat(path)
-	if err != nil {
-		return nil, fmt.Errorf(a.translator.Translate("error.path_not_accessible"), err)
-	}
-
-	if !info.IsDir() {
-		return a.AnalyzeFile(path)
-	}
-
-	var lastFoundCount int
-
-	// 定义进度回调函数
-	progressCallback := func(found int) {
-		if !a.silent && found > lastFoundCount {
-			lastFoundCount = found
-			// 更新搜索进度
-			fmt.Printf("\r🔍 %s %d", a.translator.Translate("analyzer.searching_files"), found)
-		}
-	}
-
-	if !a.silent {
-		fmt.Printf("🔍 %s...\n", a.translator.Translate("analyzer.searching_files"))
-	} else {
-		// 静默模式下的空回调
-		progressCallback = func(int) {}
-	}
-
-	// 查找匹配的源码文件
-	files, err := common.FindSourceFiles(path, includePatterns, excludePatterns, progressCallback)
-	if err != nil {
-		return nil, fmt.Errorf(a.translator.Translate("error.source_files_not_found"), err)
-	}
-
-	// 只在非静默模式下清除进度显示并显示文件总数
-	if !a.silent && lastFoundCount > 0 {
-		fmt.Printf("\r%s\r", strings.Repeat(" ", 80))
-		fmt.Printf("📂 %s: %d\n", a.translator.Translate("analyzer.files_found"), len(files))
-	}
-
-	// 如果没有找到文件，直接返回空结果
-	if len(files) == 0 {
-		return &AnalysisResult{
-			Metrics:       make(map[string]MetricResult),
-			FilesAnalyzed: []FileAnalysisResult{},
-			TotalFiles:    0,
-			TotalLines:    0,
-		}, nil
-	}
-
-	// 保存分析结果
-	fileResults := make([]*metrics.AnalysisResult, 0, len(files))
-
-	// 在分析文件前显示进度条
-	if !a.silent {
-		// 不要添加多余的空行
-		progressStyle := color.New(color.FgHiCyan)
-		fileInfoStyle := color.New(color.FgHiBlack) // 淡色字体
-
-		// 根据语言选择进度文本
-		var progressText string
-		switch a.translator.GetLanguage() {
-		case i18n.EnUS:
-			progressText = "Analyzing files"
-		default:
-			progressText = "正在分析文件"
-		}
-
-		// 保存文件名历史
-		var currentFile string
-		fileCount := len(files)
-
-		// 分析每个文件并显示进度
-		for i, filePath := range files {
-			// 更新文件名
-			currentFile = shortenPath(filePath)
-
-			// 计算和显示进度条
-			percent := float64(i+1) / float64(fileCount)
-			barWidth := 30
-			barCompleted := int(float64(barWidth) * percent)
-			barRemaining := barWidth - barCompleted
-
-			// 显示进度条
-			fmt.Printf("\r\033[K  ")
-			progressStyle.Printf("%s: ", progressText)
-			fmt.Printf("%d/%d ", i+1, fileCount)
-			progressStyle.Printf("[%s%s]",
-				strings.Repeat("█", barCompleted),
-				strings.Repeat("░", barRemaining))
-
-			// 显示当前处理的文件
-			fmt.Printf("\n\033[K  正在处理: ")
-			fileInfoStyle.Printf("%s", currentFile)
-
-			// 回到进度条行
-			fmt.Printf("\033[A\r")
-
-			// 分析文件
-			result, err := a.codeAnalyzer.AnalyzeFile(filePath)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, a.translator.Translate("error.file_analysis_failed"), filePath, err)
-				continue
-			}
-			fileResults = append(fileResults, result)
-
-			// 大量文件时不要延迟
-			if len(files) < 30 {
-				time.Sleep(20 * time.Millisecond)
-			}
-		}
-
-		// 清理进度条行
-		fmt.Print("\r\033[K\n")
-	} else {
-		// 静默模式下直接分析文件
-		for _, filePath := range files {
-			result, err := a.codeAnalyzer.AnalyzeFile(filePath)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, a.translator.Translate("error.file_analysis_failed"), filePath, err)
-				continue
-			}
-			fileResults = append(fileResults, result)
-		}
-	}
-
-	// 创建结果对象
-	result := &AnalysisResult{
-		Metrics:       make(map[string]MetricResult),
-		FilesAnalyzed: make([]FileAnalysisResult, 0, len(fileResults)),
-		TotalFiles:    len(fileResults),
-	}
-
-	// 收集所有指标结果
-	allMetrics := make(map[string][]metrics.MetricResult)
-	totalLines := 0
-
-	// 处理每个文件的结果
-	for _, fileResult := range fileResults {
-		totalLines += fileResult.TotalLines
-
-		// 添加文件分析结果
-		result.FilesAnalyzed = append(result.FilesAnalyzed, FileAnalysisResult{
-			FilePath:  fileResult.FilePath,
-			FileScore: fileResult.GetOverallScore(),
-			Issues:    fileResult.GetIssues(),
+// appendSuppressed 收集单个文件中被抑制的问题
+func appendSuppressed(suppressed []SuppressedIssue, fileResult *metrics.AnalysisResult) []SuppressedIssue {
+	for _, item := range fileResult.Suppressed {
+		suppressed = append(suppressed, SuppressedIssue{
+			FilePath: fileResult.FilePath,
+			Metric:   item.Metric,
+			Issue:    item.Issue,
 		})
-
-		// 收集各指标结果
-		for name, metricResult := range fileResult.MetricResults {
-			if _, ok := allMetrics[name]; !ok {
-				allMetrics[name] = make([]metrics.MetricResult, 0, len(fileResults))
-			}
-			allMetrics[name] = append(allMetrics[name], metricResult)
-		}
-	}
-
-	// 计算各指标的平均分数
-	for name, metricResults := range allMetrics {
-		if len(metricResults) == 0 {
-			continue
-		}
-
-		// 计算平均分
-		totalScore := 0.0
-		totalWeight := 0.0
-		description := ""
-
-		for _, m := range metricResults {
-			totalScore += m.Score
-			totalWeight = m.Weight
-			description = m.Description
-		}
-
-		avgScore := totalScore / float64(len(metricResults))
-
-		// 添加到结果中
-		result.Metrics[name] = MetricResult{
-			Name:        name,
-			Score:       avgScore,
-			Description: description,
-			Weight:      totalWeight,
-		}
-	}
-
-	// 设置总行数
-	result.TotalLines = totalLines
-
-	// 计算总体评分
-	result.CodeQualityScore = a.codeAnalyzer.CalculateOverallScore(fileResults)
-
-	return result, nil
-}
-
-// shortenPath 缩短文件路径，只显示最后几个部分
-func shortenPath(path string) string {
-	parts := strings.Split(path, "/")
-	if len(parts) <= 4 {
-		return path
 	}
-
-	return "./" + strings.Join(parts[len(parts)-3:], "/")
+	return suppressed
 }
 
 // CodeAnalyzer 代码分析器
 type CodeAnalyzer struct {
 	metricFactory *metrics.MetricFactory
 	translator    i18n.Translator
+	metricKeys    []string             // 启用的指标键，为nil时启用所有默认指标
+	weights       map[string]float64   // 按指标键覆盖的权重
+	ruleSet       *rules.Set           // 项目自定义规则
+	aggregation   Aggregation          // 汇总文件得分的策略，为空时使用默认策略
+	namingStyles  metrics.NamingStyles // 项目配置的命名风格
+	coverage      *coverage.Profile    // 测试覆盖率
+	debtScanner   *debt.Scanner        // 技术债标记扫描器
 }
 
 // NewCodeAnalyzer 创建新的代码分析器
 func NewCodeAnalyzer(translator i18n.Translator) *CodeAnalyzer {
 	metricFactory := metrics.NewMetricFactory(translator)
+	debtScanner, _ := debt.NewScanner("")
 	return &CodeAnalyzer{
 		metricFactory: metricFactory,
 		translator:    translator,
+		debtScanner:   debtScanner,
 	}
 }
 
@@ -362,9 +249,23 @@ func (a *CodeAnalyzer) SetTranslator(translator i18n.Translator) {
 	a.metricFactory.SetTranslator(translator)
 }
 
-// GetMetrics 获取所有指标
+// GetMetrics 获取启用的指标
 func (a *CodeAnalyzer) GetMetrics() []metrics.Metric {
-	return a.metricFactory.CreateAllMetrics()
+	result := make([]metrics.Metric, 0, len(a.enabledMetricKeys()))
+	for _, key := range a.enabledMetricKeys() {
+		if metric, ok := a.metricFactory.CreateMetric(key); ok {
+			result = append(result, metric)
+		}
+	}
+	return result
+}
+
+// enabledMetricKeys 返回启用的指标键
+func (a *CodeAnalyzer) enabledMetricKeys() []string {
+	if a.metricKeys == nil {
+		return metrics.DefaultKeys()
+	}
+	return a.metricKeys
 }
 
 // AnalyzeFile 分析单个文件
@@ -374,6 +275,16 @@ func (a *CodeAnalyzer) AnalyzeFile(filePath string) (*metrics.AnalysisResult, er
 		return nil, fmt.Errorf(a.translator.Translate("error.file_read_failed"), filePath, err)
 	}
 
+	return a.AnalyzeContent(filePath, content)
+}
+
+// AnalyzeContent 分析内存中的文件内容，filePath仅用于识别语言和展示
+func (a *CodeAnalyzer) AnalyzeContent(filePath string, content []byte) (*metrics.AnalysisResult, error) {
+	return a.analyzeContent(filePath, content, nil)
+}
+
+// analyzeContent 分析文件内容，tests为该文件对应的测试，只在分析目录时提供
+func (a *CodeAnalyzer) analyzeContent(filePath string, content []byte, tests *metrics.FileTests) (*metrics.AnalysisResult, error) {
 	// 创建适合该文件的解析器
 	codeParser := parser.CreateParserForFile(filePath)
 
@@ -385,20 +296,92 @@ func (a *CodeAnalyzer) AnalyzeFile(filePath string) (*metrics.AnalysisResult, er
 
 	// 创建分析结果对象
 	result := metrics.NewAnalysisResult(filePath, parseResult)
+	result.Coverage = a.fileCoverage(filePath)
+	if provider, ok := parseResult.(interface{ GetComments() []parser.Comment }); ok {
+		result.Markers = a.debtScanner.Scan(filePath, provider.GetComments())
+	}
+	suppressions := parseSuppressions(content, parseResult.GetFunctions())
 
 	// 应用每个指标进行分析
-	for _, metric := range a.GetMetrics() {
+	for _, key := range a.enabledMetricKeys() {
+		info, ok := metrics.Lookup(key)
+		if !ok || !info.SupportsLanguage(parseResult.GetLanguage()) {
+			continue
+		}
+
+		metric := info.New(a.translator)
 		if !a.isLanguageSupported(metric, parseResult.GetLanguage()) {
 			continue
 		}
 
+		// 依赖自定义规则的指标在没有规则时不参与评分
+		if aware, ok := metric.(metrics.RuleAware); ok {
+			if a.ruleSet.Len() == 0 {
+				continue
+			}
+			aware.SetRules(a.ruleSet)
+		}
+		if aware, ok := metric.(metrics.NamingAware); ok {
+			aware.SetNamingStyles(a.namingStyles)
+		}
+		// 依赖覆盖率的指标在没有该语言的覆盖率时不参与评分
+		if aware, ok := metric.(metrics.CoverageAware); ok {
+			if result.Coverage == nil {
+				continue
+			}
+			aware.SetCoverage(result.Coverage)
+		}
+		if aware, ok := metric.(metrics.DebtAware); ok {
+			aware.SetMarkers(result.Markers)
+		}
+		// 依赖测试匹配结果的指标只在分析目录时参与评分
+		if aware, ok := metric.(metrics.TestAware); ok {
+			if tests == nil {
+				continue
+			}
+			aware.SetTests(tests)
+		}
+
+		// 权重以注册信息为准，注册时未设置则使用指标自身的权重，可被配置覆盖
 		metricResult := metric.Analyze(parseResult)
+		metricResult.Weight = info.Weight
+		if info.Weight == 0 {
+			metricResult.Weight = metric.Weight()
+		}
+		if weight, ok := a.weights[key]; ok {
+			metricResult.Weight = weight
+		}
+
+		// 被抑制的问题单独记录，不计入评分
+		if len(suppressions) > 0 {
+			kept, suppressed, keep := applySuppressions(key, metric, parseResult, metricResult, suppressions, content)
+			result.AddSuppressedIssues(metric.Name(), suppressed)
+			if !keep {
+				continue
+			}
+			metricResult = kept
+		}
 		result.AddMetricResult(metric.Name(), metricResult)
 	}
 
 	return result, nil
 }
 
+// fileCoverage 查找文件的测试覆盖率
+// 覆盖率中有同语言的文件却找不到该文件时，视为该文件完全未被测试
+func (a *CodeAnalyzer) fileCoverage(filePath string) *coverage.File {
+	if a.coverage.Len() == 0 {
+		return nil
+	}
+	if file, ok := a.coverage.Lookup(filePath); ok {
+		return file
+	}
+	if a.coverage.Covers(filePath) {
+		return &coverage.File{Path: filePath}
+	}
+	return nil
+}
+
 // isLanguageSupported 检查指标是否支持指定语言
 func (a *CodeAnalyzer) isLanguageSupported(metric metrics.Metric, language common.LanguageType) bool {
 	supportedLanguages := metric.SupportedLanguages()
@@ -415,7 +398,8 @@ func (a *CodeAnalyzer) isLanguageSupported(metric metrics.Metric, language commo
 	return false
 }
 
-// AnalyzeDirectory 分析目录
+// AnalyzeDirectory 分析目录，识别为生成代码的文件不返回结果
+// 单个文件分析失败时输出警告并跳过，不影响其他文件的结果；需要获取失败文件列表时使用Engine
 func (a *CodeAnalyzer) AnalyzeDirectory(dirPath string, includePatterns []string, excludePatterns []string, progressCallback func(found int)) ([]*metrics.AnalysisResult, error) {
 	// 查找所有符合条件的文件
 	files, err := common.FindSourceFiles(dirPath, includePatterns, excludePatterns, progressCallback)
@@ -423,67 +407,44 @@ func (a *CodeAnalyzer) AnalyzeDirectory(dirPath string, includePatterns []string
 		return nil, fmt.Errorf(a.translator.Translate("error.source_files_not_found"), err)
 	}
 
-	// 使用并发加速分析
-	results := make([]*metrics.AnalysisResult, 0, len(files))
-	resultsChan := make(chan *metrics.AnalysisResult, len(files))
-	errChan := make(chan error, len(files))
-
-	// 并发控制
-	concurrencyLimit := min(8, len(files)) // 最大并发数
-	semaphore := make(chan struct{}, concurrencyLimit)
-	var wg sync.WaitGroup
-
-	for _, file := range files {
-		wg.Add(1)
-		go func(filePath string) {
-			defer wg.Done()
-
-			// 获取信号量
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
-
-			result, err := a.AnalyzeFile(filePath)
-			if err != nil {
-				errChan <- fmt.Errorf(a.translator.Translate("error.file_analysis_failed"), filePath, err)
-				return
-			}
-			resultsChan <- result
-		}(file)
-	}
-
-	// 等待所有分析完成
-	wg.Wait()
-	close(resultsChan)
-	close(errChan)
+	config := defaultConfig()
+	config.Concurrency = min(8, len(files)) // 最大并发数
+	config.Translator = a.translator
+	engine := &Engine{config: config, codeAnalyzer: a}
 
-	// 收集结果
-	for result := range resultsChan {
-		results = append(results, result)
+	results, failedFiles, _, err := engine.analyzeFiles(context.Background(), files, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	// 检查错误
-	for err := range errChan {
+	for _, failed := range failedFiles {
+		err := fmt.Errorf(a.translator.Translate("error.file_analysis_failed"), failed.FilePath, failed.Err)
 		fmt.Fprintf(os.Stderr, a.translator.Translate("warning.format"), err)
 	}
 
 	return results, nil
 }
 
-// CalculateOverallScore 计算总体评分
+// CalculateOverallScore 按汇总策略计算总体评分，默认按文件代码行数加权
 func (a *CodeAnalyzer) CalculateOverallScore(results []*metrics.AnalysisResult) float64 {
-	if len(results) == 0 {
-		return 0.0
+	samples := make([]scoreSample, 0, len(results))
+	for _, result := range results {
+		samples = append(samples, scoreSample{
+			score:     result.GetOverallScore(),
+			lines:     result.TotalLines,
+			functions: len(result.Functions),
+		})
 	}
 
-	totalScore := 0.0
-	fileCount := 0
+	return a.resolvedAggregation().aggregate(samples)
+}
 
-	for _, result := range results {
-		totalScore += result.GetOverallScore()
-		fileCount++
+// resolvedAggregation 返回实际使用的汇总策略
+func (a *CodeAnalyzer) resolvedAggregation() Aggregation {
+	if a.aggregation == "" {
+		return DefaultAggregation
 	}
-
-	return totalScore / float64(fileCount)
+	return a.aggregation
 }
 
 // min 返回两个整数中较小的一个
@@ -493,3 +454,28 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+// fileMaintainabilityIndex 计算文件的可维护性指数
+func fileMaintainabilityIndex(fileResult *metrics.AnalysisResult) float64 {
+	if fileResult.ParseResult == nil {
+		return 0
+	}
+	return metrics.FileMaintainabilityIndex(fileResult.ParseResult)
+}
+
+// withCoverage 填写文件的行覆盖率
+func (f FileAnalysisResult) withCoverage(fileResult *metrics.AnalysisResult) FileAnalysisResult {
+	if ratio, ok := fileResult.Coverage.Total(); ok {
+		f.HasCoverage = true
+		f.Coverage = ratio * 100
+	}
+	return f
+}
+
+// fileClasses 计算文件中各类的内聚度和规模
+func fileClasses(fileResult *metrics.AnalysisResult) []metrics.ClassCohesion {
+	if fileResult.ParseResult == nil {
+		return nil
+	}
+	return metrics.AnalyzeClassCohesion(fileResult.ParseResult)
+}
@@ -0,0 +1,187 @@
+// Package analyzer 提供代码分析功能
+// 创建者：Done-0
+package analyzer
+
+import (
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+	"github.com/Done-0/fuck-u-code/pkg/metrics"
+	"github.com/Done-0/fuck-u-code/pkg/parser"
+)
+
+// 痛苦区判定条件：离主序列足够远、偏向稳定且具体的一侧，并且确实被其他包依赖
+const (
+	zoneOfPainDistance = 0.7
+	zoneOfPainAfferent = 2
+)
+
+// PackageCoupling 包级耦合度，只统计分析范围内各包之间的依赖
+type PackageCoupling struct {
+	Name         string              // 包名，Go为相对分析路径的目录，Java为包名，C#为命名空间
+	Language     common.LanguageType // 语言
+	Files        []string            // 包中的文件，按路径排序
+	Afferent     int                 // 传入耦合Ca，依赖该包的其他包数
+	Efferent     int                 // 传出耦合Ce，该包依赖的其他包数
+	FanIn        int                 // 扇入，其他包引用该包中的符号数
+	FanOut       int                 // 扇出，该包引用其他包中的符号数
+	Instability  float64             // 不稳定性 I = Ce / (Ca + Ce)
+	Abstractness float64             // 抽象度 A = 接口和抽象类数 / 类型数
+	Distance     float64             // 与主序列的距离 D = |A + I - 1|
+	ZoneOfPain   bool                // 是否处于痛苦区：稳定、具体且被依赖，修改代价大
+}
+
+// couplingPackage 计算耦合度时的包信息
+type couplingPackage struct {
+	coupling      *PackageCoupling
+	types         int
+	abstractTypes int
+	dependencies  map[string]bool // 依赖的包
+	references    map[string]bool // 引用的其他包中的符号
+}
+
+// BuildCoupling 根据各文件的导入和符号引用计算包级耦合度，支持Go、Java和C#
+// 结果按与主序列的距离从大到小排序
+func BuildCoupling(root string, files []*metrics.AnalysisResult) []PackageCoupling {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		absRoot = root
+	}
+
+	// 按语言和包名归组文件
+	packages := make(map[string]*couplingPackage)
+	fileDeps := make(map[string]*parser.Dependencies)
+	fileKeys := make(map[string]string)
+	for _, f := range files {
+		provider, ok := f.ParseResult.(interface{ GetDependencies() *parser.Dependencies })
+		if !ok || provider.GetDependencies() == nil {
+			continue
+		}
+		deps := provider.GetDependencies()
+
+		name := deps.Package
+		if f.Language == common.Go {
+			// Go的外部测试包会导入被测包，不计入
+			if strings.HasSuffix(f.FilePath, "_test.go") {
+				continue
+			}
+			name = goPackageDir(absRoot, f.FilePath)
+		}
+		if name == "" {
+			continue
+		}
+
+		key := string(f.Language) + ":" + name
+		pkg, ok := packages[key]
+		if !ok {
+			pkg = &couplingPackage{
+				coupling:     &PackageCoupling{Name: name, Language: f.Language},
+				dependencies: make(map[string]bool),
+				references:   make(map[string]bool),
+			}
+			packages[key] = pkg
+		}
+		pkg.coupling.Files = append(pkg.coupling.Files, f.FilePath)
+		pkg.types += deps.Types
+		pkg.abstractTypes += deps.AbstractTypes
+		fileDeps[f.FilePath] = deps
+		fileKeys[f.FilePath] = key
+	}
+
+	// 将导入和引用解析到分析范围内的包
+	for path, deps := range fileDeps {
+		key := fileKeys[path]
+		pkg := packages[key]
+		language := pkg.coupling.Language
+
+		for _, imp := range deps.Imports {
+			if target := resolvePackage(packages, language, imp); target != "" && target != key {
+				pkg.dependencies[target] = true
+			}
+		}
+		for _, ref := range deps.References {
+			idx := strings.LastIndex(ref, "#")
+			if target := resolvePackage(packages, language, ref[:max(idx, 0)]); target != "" && target != key {
+				pkg.references[target+ref[idx:]] = true
+			}
+		}
+	}
+
+	// 计算各项指标
+	for _, pkg := range packages {
+		pkg.coupling.Efferent = len(pkg.dependencies)
+		pkg.coupling.FanOut = len(pkg.references)
+		for target := range pkg.dependencies {
+			packages[target].coupling.Afferent++
+		}
+		for ref := range pkg.references {
+			packages[ref[:strings.LastIndex(ref, "#")]].coupling.FanIn++
+		}
+	}
+
+	result := make([]PackageCoupling, 0, len(packages))
+	for _, pkg := range packages {
+		c := pkg.coupling
+		if c.Afferent+c.Efferent > 0 {
+			c.Instability = float64(c.Efferent) / float64(c.Afferent+c.Efferent)
+		}
+		if pkg.types > 0 {
+			c.Abstractness = float64(pkg.abstractTypes) / float64(pkg.types)
+		}
+		c.Distance = math.Abs(c.Abstractness + c.Instability - 1)
+		c.ZoneOfPain = c.Abstractness+c.Instability < 1 && c.Distance >= zoneOfPainDistance && c.Afferent >= zoneOfPainAfferent
+		sort.Strings(c.Files)
+		result = append(result, *c)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Distance != result[j].Distance {
+			return result[i].Distance > result[j].Distance
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// goPackageDir 返回Go文件所在目录相对分析路径的路径，作为包名
+func goPackageDir(absRoot, filePath string) string {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		absPath = filePath
+	}
+	rel, err := filepath.Rel(absRoot, filepath.Dir(absPath))
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+// resolvePackage 将导入路径解析为分析范围内的包，返回包的键，找不到时返回空字符串
+// Go按导入路径的后缀匹配目录，Java按最长前缀匹配包名，C#按命名空间完全匹配
+func resolvePackage(packages map[string]*couplingPackage, language common.LanguageType, imp string) string {
+	imp = strings.TrimSuffix(imp, ".*")
+	best := ""
+	for key, pkg := range packages {
+		if pkg.coupling.Language != language {
+			continue
+		}
+
+		name := pkg.coupling.Name
+		var matched bool
+		switch language {
+		case common.Go:
+			matched = name != "." && (imp == name || strings.HasSuffix(imp, "/"+name))
+		case common.Java:
+			matched = imp == name || strings.HasPrefix(imp, name+".")
+		default:
+			matched = imp == name
+		}
+		if matched && len(key) > len(best) {
+			best = key
+		}
+	}
+	return best
+}
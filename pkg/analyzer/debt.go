@@ -0,0 +1,115 @@
+// Package analyzer 提供代码分析功能
+// 创建者：Done-0
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+	"github.com/Done-0/fuck-u-code/pkg/debt"
+	"github.com/Done-0/fuck-u-code/pkg/metrics"
+	"github.com/Done-0/fuck-u-code/pkg/parser"
+)
+
+// debtOldestMarkers 汇总中保留的最老标记数量
+const debtOldestMarkers = 10
+
+// DebtSummary 技术债标记汇总
+type DebtSummary struct {
+	Total     int               // 标记总数
+	Untracked int               // 没有关联工单的标记数
+	ByKind    map[debt.Kind]int // 各类标记的数量
+	Files     []FileDebt        // 各文件的标记数，多的在前
+	Oldest    []debt.Marker     // 最老的标记，只在读取git blame后才有
+	Markers   []debt.Marker     // 所有标记，按文件和行号排列
+}
+
+// FileDebt 文件中的技术债标记数
+type FileDebt struct {
+	FilePath  string // 文件路径
+	Markers   int    // 标记数
+	Untracked int    // 没有关联工单的标记数
+}
+
+// buildDebtSummary 汇总文件中的技术债标记，没有标记时返回nil
+func buildDebtSummary(fileResults []*metrics.AnalysisResult) *DebtSummary {
+	summary := &DebtSummary{ByKind: make(map[debt.Kind]int)}
+	for _, r := range fileResults {
+		if len(r.Markers) == 0 {
+			continue
+		}
+		file := FileDebt{FilePath: r.FilePath, Markers: len(r.Markers)}
+		for _, marker := range r.Markers {
+			summary.ByKind[marker.Kind]++
+			if !marker.Tracked() {
+				file.Untracked++
+			}
+		}
+		summary.Total += file.Markers
+		summary.Untracked += file.Untracked
+		summary.Files = append(summary.Files, file)
+		summary.Markers = append(summary.Markers, r.Markers...)
+	}
+	if summary.Total == 0 {
+		return nil
+	}
+
+	sort.SliceStable(summary.Files, func(i, j int) bool {
+		a, b := summary.Files[i], summary.Files[j]
+		if a.Markers != b.Markers {
+			return a.Markers > b.Markers
+		}
+		return a.Untracked > b.Untracked
+	})
+	summary.Oldest = debt.Oldest(summary.Markers, debtOldestMarkers)
+	return summary
+}
+
+// blameMarkers 读取git blame为文件中的标记设置引入时间，文件不在git仓库中时保持为空
+func (e *Engine) blameMarkers(ctx context.Context, result *metrics.AnalysisResult) {
+	if !e.config.Blame || result == nil || len(result.Markers) == 0 {
+		return
+	}
+	_ = debt.ApplyBlame(ctx, result.Markers)
+}
+
+// FindDebtMarkers 查找路径下所有文件（包括测试文件）中的技术债标记，顺序与文件顺序一致
+// 只提取注释而不计算指标，启用Blame时读取git blame获取每个标记的引入时间
+func (e *Engine) FindDebtMarkers(ctx context.Context, path string) ([]debt.Marker, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf(e.config.Translator.Translate("error.path_not_accessible"), err)
+	}
+	files := []string{path}
+	if info.IsDir() {
+		if files, err = e.searchFiles(ctx, path); err != nil {
+			return nil, err
+		}
+	}
+
+	detector := common.NewLanguageDetector()
+	var markers []debt.Marker
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		if !e.config.IncludeGenerated && common.DetectGenerated(file, content) != common.GeneratedNone {
+			continue
+		}
+
+		comments := parser.ExtractComments(content, detector.DetectLanguage(file))
+		fileMarkers := e.codeAnalyzer.debtScanner.Scan(file, comments)
+		if e.config.Blame && len(fileMarkers) > 0 {
+			_ = debt.ApplyBlame(ctx, fileMarkers)
+		}
+		markers = append(markers, fileMarkers...)
+	}
+	return markers, ctx.Err()
+}
@@ -0,0 +1,415 @@
+// Package analyzer 提供代码分析功能
+// 创建者：Done-0
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+	"github.com/Done-0/fuck-u-code/pkg/debt"
+	"github.com/Done-0/fuck-u-code/pkg/metrics"
+)
+
+// Engine 可嵌入的代码分析引擎
+// 引擎本身不会向标准输出或标准错误写入任何内容，进度通过ProgressReporter报告
+type Engine struct {
+	config       Config
+	codeAnalyzer *CodeAnalyzer
+}
+
+// FileError 单个文件分析失败的原因
+type FileError struct {
+	FilePath string // 文件路径
+	Err      error  // 失败原因
+}
+
+// Error 实现error接口
+func (e FileError) Error() string {
+	return fmt.Sprintf("%s: %v", e.FilePath, e.Err)
+}
+
+// Unwrap 返回原始错误
+func (e FileError) Unwrap() error {
+	return e.Err
+}
+
+// NewEngine 使用给定选项创建分析引擎，指标键无效时返回错误
+func NewEngine(opts ...Option) (*Engine, error) {
+	config := defaultConfig()
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	// 校验指标键
+	keys := make([]string, 0, len(config.Metrics)+len(config.EnabledMetrics)+len(config.DisabledMetrics)+len(config.Weights))
+	keys = append(keys, config.Metrics...)
+	keys = append(keys, config.EnabledMetrics...)
+	keys = append(keys, config.DisabledMetrics...)
+	for key := range config.Weights {
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		if _, ok := metrics.Lookup(key); !ok {
+			return nil, fmt.Errorf(config.Translator.Translate("error.unknown_metric"), key)
+		}
+	}
+
+	// 校验汇总策略
+	if _, ok := ParseAggregation(string(config.Aggregation)); !ok {
+		return nil, fmt.Errorf(config.Translator.Translate("error.unknown_aggregation"), config.Aggregation)
+	}
+
+	// 编译工单号格式
+	debtScanner, err := debt.NewScanner(config.TicketPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	codeAnalyzer := NewCodeAnalyzer(config.Translator)
+	codeAnalyzer.metricKeys = resolveMetricKeys(config)
+	codeAnalyzer.weights = config.Weights
+	codeAnalyzer.ruleSet = config.Rules
+	codeAnalyzer.aggregation = config.Aggregation
+	codeAnalyzer.namingStyles = config.NamingStyles
+	codeAnalyzer.coverage = config.Coverage
+	codeAnalyzer.debtScanner = debtScanner
+
+	return &Engine{
+		config:       config,
+		codeAnalyzer: codeAnalyzer,
+	}, nil
+}
+
+// resolveMetricKeys 根据配置计算最终启用的指标键
+func resolveMetricKeys(config Config) []string {
+	base := config.Metrics
+	if len(base) == 0 {
+		base = metrics.DefaultKeys()
+	}
+
+	disabled := make(map[string]bool, len(config.DisabledMetrics))
+	for _, key := range config.DisabledMetrics {
+		disabled[key] = true
+	}
+
+	seen := make(map[string]bool)
+	keys := make([]string, 0, len(base)+len(config.EnabledMetrics))
+	for _, key := range append(append([]string{}, base...), config.EnabledMetrics...) {
+		if disabled[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Analyze 分析指定路径，路径可以是文件或目录
+func (e *Engine) Analyze(ctx context.Context, path string) (*AnalysisResult, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf(e.config.Translator.Translate("error.path_not_accessible"), err)
+	}
+
+	if !info.IsDir() {
+		return e.AnalyzeFile(ctx, path)
+	}
+
+	files, tests, err := e.findFiles(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// 如果没有找到文件，直接返回空结果
+	if len(files) == 0 {
+		return &AnalysisResult{
+			Metrics:       make(map[string]MetricResult),
+			FilesAnalyzed: []FileAnalysisResult{},
+		}, nil
+	}
+
+	fileResults, failedFiles, skippedFiles, err := e.analyzeFiles(ctx, files, tests)
+	if err != nil {
+		return nil, err
+	}
+	e.config.Progress.OnAnalysisDone()
+
+	return e.buildDirectoryResult(path, fileResults, failedFiles, skippedFiles, tests), nil
+}
+
+// findFiles 查找目录中需要分析的文件，并建立测试文件索引
+// 测试文件只用于匹配生产文件，启用测试报告时才一起分析
+func (e *Engine) findFiles(ctx context.Context, dir string) ([]string, *testIndex, error) {
+	e.config.Progress.OnSearchStart()
+	files, err := e.searchFiles(ctx, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sources, testFiles := splitTestFiles(dir, files)
+	tests := e.buildTestIndex(dir, sources, testFiles)
+	if e.config.TestReport {
+		sources = append(sources, testFiles...)
+	}
+	e.config.Progress.OnSearchDone(len(sources))
+
+	return sources, tests, nil
+}
+
+// searchFiles 按包含、排除和忽略规则查找目录中的源码文件，包括测试文件
+func (e *Engine) searchFiles(ctx context.Context, dir string) ([]string, error) {
+	files, err := common.FindSourceFilesWithOptions(ctx, dir, common.FindOptions{
+		IncludePatterns: e.config.IncludePatterns,
+		ExcludePatterns: e.config.ExcludePatterns,
+		NoIgnore:        e.config.NoIgnore,
+		Progress:        e.config.Progress.OnSearchProgress,
+	})
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf(e.config.Translator.Translate("error.source_files_not_found"), err)
+	}
+	return files, nil
+}
+
+// buildDirectoryResult 汇总目录的分析结果，包括失败、跳过的文件、目录树和测试匹配情况
+// 测试文件不计入生产代码的评分，启用测试报告时单独汇总为TestQuality
+func (e *Engine) buildDirectoryResult(root string, fileResults []*metrics.AnalysisResult, failedFiles []FileError, skippedFiles []SkippedFile, tests *testIndex) *AnalysisResult {
+	var testResults []*metrics.AnalysisResult
+	var testFailed []FileError
+	var testSkipped []SkippedFile
+	if tests != nil {
+		fileResults, testResults = partition(fileResults, func(r *metrics.AnalysisResult) bool { return tests.isTest(r.FilePath) })
+		failedFiles, testFailed = partition(failedFiles, func(f FileError) bool { return tests.isTest(f.FilePath) })
+		skippedFiles, testSkipped = partition(skippedFiles, func(s SkippedFile) bool { return tests.isTest(s.FilePath) })
+	}
+
+	result := e.buildResult(fileResults)
+	result.FailedFiles = failedFiles
+	result.SkippedFiles = skippedFiles
+	result.Directories = BuildDirectoryTree(root, result.FilesAnalyzed, result.Aggregation)
+	result.Coupling = BuildCoupling(root, fileResults)
+	e.addCouplingIssues(result)
+	result.Tests = tests.summarize(root, fileResults)
+	result.Debt = buildDebtSummary(fileResults)
+	if e.config.TestReport && len(testResults) > 0 {
+		result.TestQuality = e.buildDirectoryResult(root, testResults, testFailed, testSkipped, nil)
+	}
+	return result
+}
+
+// partition 按条件将切片拆分为不满足和满足条件的两部分
+func partition[T any](items []T, match func(T) bool) (rest, matched []T) {
+	for _, item := range items {
+		if match(item) {
+			matched = append(matched, item)
+		} else {
+			rest = append(rest, item)
+		}
+	}
+	return rest, matched
+}
+
+// addCouplingIssues 将处于痛苦区的包作为结构问题记录到包中的第一个文件
+func (e *Engine) addCouplingIssues(result *AnalysisResult) {
+	fileIndex := make(map[string]int, len(result.FilesAnalyzed))
+	for i, file := range result.FilesAnalyzed {
+		fileIndex[file.FilePath] = i
+	}
+
+	for _, pkg := range result.Coupling {
+		if !pkg.ZoneOfPain {
+			continue
+		}
+		i, ok := fileIndex[pkg.Files[0]]
+		if !ok {
+			continue
+		}
+		issue := fmt.Sprintf(e.config.Translator.Translate("issue.zone_of_pain"),
+			pkg.Name, pkg.Afferent, pkg.Instability, pkg.Abstractness, pkg.Distance)
+		result.FilesAnalyzed[i].Issues = append(result.FilesAnalyzed[i].Issues, issue)
+	}
+}
+
+// AnalyzeFile 分析单个文件
+func (e *Engine) AnalyzeFile(ctx context.Context, filePath string) (*AnalysisResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	fileResult, err := e.codeAnalyzer.AnalyzeFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSingleFileResult(fileResult), nil
+}
+
+// AnalyzeContent 分析内存中的代码内容，path用于识别语言
+func (e *Engine) AnalyzeContent(ctx context.Context, path string, content []byte) (*AnalysisResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	fileResult, err := e.codeAnalyzer.AnalyzeContent(path, content)
+	if err != nil {
+		return nil, err
+	}
+
+	// 内容可能与磁盘上的同名文件不同，保存下来供报告定位问题和截取片段
+	result := newSingleFileResult(fileResult)
+	result.FilesAnalyzed[0].Source = content
+	return result, nil
+}
+
+// analyzeFiles 并发分析文件，结果顺序与files一致
+// 单个文件失败不影响整体，只有ctx取消时才返回错误；生成代码不参与分析，记录为跳过
+func (e *Engine) analyzeFiles(ctx context.Context, files []string, tests *testIndex) ([]*metrics.AnalysisResult, []FileError, []SkippedFile, error) {
+	results := make([]*metrics.AnalysisResult, len(files))
+	errs := make([]error, len(files))
+	skipped := make([]common.GeneratedKind, len(files))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var progressMu sync.Mutex
+	done := 0
+
+	for w := 0; w < min(e.config.Concurrency, len(files)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], skipped[i], errs[i] = e.analyzeSourceFile(files[i], tests.forFile(files[i]))
+				e.blameMarkers(ctx, results[i])
+
+				// 串行调用进度回调
+				progressMu.Lock()
+				done++
+				e.config.Progress.OnFileAnalyzed(files[i], done, len(files), errs[i])
+				progressMu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for i := range files {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	fileResults := make([]*metrics.AnalysisResult, 0, len(files))
+	var failedFiles []FileError
+	var skippedFiles []SkippedFile
+	for i, result := range results {
+		if errs[i] != nil {
+			failedFiles = append(failedFiles, FileError{FilePath: files[i], Err: errs[i]})
+			continue
+		}
+		if skipped[i] != common.GeneratedNone {
+			skippedFiles = append(skippedFiles, SkippedFile{
+				FilePath: files[i],
+				Reason:   e.config.Translator.Translate("skip.generated." + string(skipped[i])),
+			})
+			continue
+		}
+		fileResults = append(fileResults, result)
+	}
+
+	return fileResults, failedFiles, skippedFiles, nil
+}
+
+// analyzeSourceFile 读取并分析目录中的一个文件，识别为生成代码时返回其类型而不分析
+// tests为该文件对应的测试，为nil时依赖测试匹配结果的指标不参与评分
+func (e *Engine) analyzeSourceFile(filePath string, tests *metrics.FileTests) (*metrics.AnalysisResult, common.GeneratedKind, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, common.GeneratedNone, fmt.Errorf(e.config.Translator.Translate("error.file_read_failed"), filePath, err)
+	}
+
+	if !e.config.IncludeGenerated {
+		if kind := common.DetectGenerated(filePath, content); kind != common.GeneratedNone {
+			return nil, kind, nil
+		}
+	}
+
+	result, err := e.codeAnalyzer.analyzeContent(filePath, content, tests)
+	return result, common.GeneratedNone, err
+}
+
+// buildResult 汇总各文件的分析结果，总分和各指标得分都按汇总策略计算
+func (e *Engine) buildResult(fileResults []*metrics.AnalysisResult) *AnalysisResult {
+	aggregation := e.codeAnalyzer.resolvedAggregation()
+	result := &AnalysisResult{
+		Metrics:       make(map[string]MetricResult),
+		FilesAnalyzed: make([]FileAnalysisResult, 0, len(fileResults)),
+		TotalFiles:    len(fileResults),
+		Aggregation:   aggregation,
+	}
+
+	// 收集所有指标结果
+	metricSamples := make(map[string][]scoreSample)
+	metricWeights := make(map[string]float64)
+	metricDescriptions := make(map[string]string)
+	totalLines := 0
+
+	// 处理每个文件的结果
+	for _, fileResult := range fileResults {
+		totalLines += fileResult.TotalLines
+
+		// 添加文件分析结果
+		result.FilesAnalyzed = append(result.FilesAnalyzed, FileAnalysisResult{
+			FilePath:      fileResult.FilePath,
+			FileScore:     fileResult.GetOverallScore(),
+			TotalLines:    fileResult.TotalLines,
+			FunctionCount: len(fileResult.Functions),
+			Functions:     newFunctionResults(fileResult),
+			Issues:        fileResult.GetIssues(),
+
+			MaintainabilityIndex: fileMaintainabilityIndex(fileResult),
+			Classes:              fileClasses(fileResult),
+		}.withCoverage(fileResult))
+		result.Suppressed = appendSuppressed(result.Suppressed, fileResult)
+
+		// 收集各指标结果
+		for name, metricResult := range fileResult.MetricResults {
+			metricSamples[name] = append(metricSamples[name], scoreSample{
+				score:     metricResult.Score,
+				lines:     fileResult.TotalLines,
+				functions: len(fileResult.Functions),
+			})
+			metricWeights[name] += metricResult.Weight
+			metricDescriptions[name] = metricResult.Description
+		}
+	}
+
+	// 按汇总策略计算各指标得分，权重取各文件的平均值
+	for name, samples := range metricSamples {
+		result.Metrics[name] = MetricResult{
+			Name:        name,
+			Score:       aggregation.aggregate(samples),
+			Description: metricDescriptions[name],
+			Weight:      metricWeights[name] / float64(len(samples)),
+		}
+	}
+
+	// 设置总行数
+	result.TotalLines = totalLines
+
+	// 计算总体评分
+	result.CodeQualityScore = e.codeAnalyzer.CalculateOverallScore(fileResults)
+
+	return result
+}
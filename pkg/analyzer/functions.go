@@ -0,0 +1,149 @@
+// Package analyzer 提供代码分析功能
+// 创建者：Done-0
+package analyzer
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Done-0/fuck-u-code/pkg/metrics"
+)
+
+// FunctionResult 函数分析结果
+type FunctionResult struct {
+	Name       string // 函数名
+	Owner      string // 所属的类或接收者类型，普通函数为空
+	FilePath   string // 所在文件路径
+	StartLine  int    // 开始行
+	EndLine    int    // 结束行
+	Lines      int    // 函数行数
+	Complexity int    // 圈复杂度
+	Parameters int    // 参数数量
+
+	HalsteadVolume       float64 // Halstead体积
+	HalsteadDifficulty   float64 // Halstead难度
+	HalsteadEffort       float64 // Halstead工作量
+	HalsteadBugs         float64 // Halstead估计缺陷数
+	MaintainabilityIndex float64 // 可维护性指数，0-100，越高越好
+
+	HasCoverage bool    // 是否有测试覆盖率数据
+	Coverage    float64 // 行覆盖率，0-100
+	CRAP        float64 // CRAP风险分，复杂度高且覆盖率低时很大
+}
+
+// QualifiedName 返回带所属类型的函数名，如 Shop.add，普通函数只返回函数名
+func (f FunctionResult) QualifiedName() string {
+	if f.Owner == "" {
+		return f.Name
+	}
+	return f.Owner + "." + f.Name
+}
+
+// FunctionRanking 最差函数的排序依据
+type FunctionRanking string
+
+// 函数排序依据
+const (
+	RankByComplexity      FunctionRanking = "complexity" // 按圈复杂度排序
+	RankByLength          FunctionRanking = "length"     // 按函数行数排序
+	RankByParameters      FunctionRanking = "params"     // 按参数数量排序
+	RankByMaintainability FunctionRanking = "mi"         // 按可维护性指数从低到高排序
+	RankByCRAP            FunctionRanking = "crap"       // 按CRAP风险分排序，需要覆盖率数据
+)
+
+// DefaultFunctionRanking 默认函数排序依据
+const DefaultFunctionRanking = RankByComplexity
+
+// FunctionRankings 返回所有函数排序依据
+func FunctionRankings() []FunctionRanking {
+	return []FunctionRanking{RankByComplexity, RankByLength, RankByParameters, RankByMaintainability, RankByCRAP}
+}
+
+// ParseFunctionRanking 解析函数排序依据，为空时返回默认值
+func ParseFunctionRanking(name string) (FunctionRanking, bool) {
+	if name == "" {
+		return DefaultFunctionRanking, true
+	}
+	for _, r := range FunctionRankings() {
+		if string(r) == name {
+			return r, true
+		}
+	}
+	return "", false
+}
+
+// keys 返回排序时依次比较的值，主排序字段在前
+func (r FunctionRanking) keys(f FunctionResult) [3]int {
+	switch r {
+	case RankByLength:
+		return [3]int{f.Lines, f.Complexity, f.Parameters}
+	case RankByParameters:
+		return [3]int{f.Parameters, f.Complexity, f.Lines}
+	case RankByMaintainability:
+		return [3]int{int(math.Round((100 - f.MaintainabilityIndex) * 100)), f.Complexity, f.Lines}
+	case RankByCRAP:
+		return [3]int{int(math.Round(f.CRAP * 100)), f.Complexity, f.Lines}
+	default:
+		return [3]int{f.Complexity, f.Lines, f.Parameters}
+	}
+}
+
+// WorstFunctions 返回所有文件中最差的函数，limit小于等于0时返回全部
+func WorstFunctions(files []FileAnalysisResult, ranking FunctionRanking, limit int) []FunctionResult {
+	var functions []FunctionResult
+	for _, f := range files {
+		functions = append(functions, f.Functions...)
+	}
+
+	sort.SliceStable(functions, func(i, j int) bool {
+		a, b := ranking.keys(functions[i]), ranking.keys(functions[j])
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] > b[k]
+			}
+		}
+		return false
+	})
+
+	if limit > 0 && len(functions) > limit {
+		functions = functions[:limit]
+	}
+	return functions
+}
+
+// newFunctionResults 将解析出的函数转换为函数分析结果
+func newFunctionResults(fileResult *metrics.AnalysisResult) []FunctionResult {
+	var maintainability []metrics.FunctionMaintainability
+	if fileResult.ParseResult != nil {
+		maintainability = metrics.AnalyzeFunctionMaintainability(fileResult.ParseResult)
+	}
+
+	functions := make([]FunctionResult, 0, len(fileResult.Functions))
+	for i, fn := range fileResult.Functions {
+		result := FunctionResult{
+			Name:       fn.Name,
+			Owner:      fn.Owner,
+			FilePath:   fileResult.FilePath,
+			StartLine:  fn.StartLine,
+			EndLine:    fn.EndLine,
+			Lines:      max(fn.EndLine-fn.StartLine+1, 0),
+			Complexity: fn.Complexity,
+			Parameters: fn.Parameters,
+		}
+		if i < len(maintainability) {
+			m := maintainability[i]
+			result.HalsteadVolume = m.Volume()
+			result.HalsteadDifficulty = m.Difficulty()
+			result.HalsteadEffort = m.Effort()
+			result.HalsteadBugs = m.Bugs()
+			result.MaintainabilityIndex = m.MaintainabilityIndex
+		}
+		if ratio, ok := fileResult.Coverage.Ratio(fn.StartLine, fn.EndLine); ok {
+			result.HasCoverage = true
+			result.Coverage = ratio * 100
+			result.CRAP = metrics.CRAP(fn.Complexity, ratio)
+		}
+		functions = append(functions, result)
+	}
+	return functions
+}
@@ -0,0 +1,75 @@
+// Package analyzer 提供代码分析功能
+// 创建者：Done-0
+package analyzer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 问题定位使用的正则表达式
+var (
+	// 显式行号，如 "行 12"、"line 12"、"(位于 main.go:12)"
+	issueLinePattern = regexp.MustCompile(`(?:行|[Ll]ine)\s*(\d+)|:(\d+)\)`)
+
+	// 问题中引用的标识符，如 "函数 'foo'"、"Function foo has"
+	issueIdentPattern = regexp.MustCompile(`'([^'\s]+)'|(?:函数|函数名|类型|类型名|变量名|常量名|全局变量|方法|类|Function|function|Type|type|Method|method|Class|class)\s+([A-Za-z_$][\w$.]*)`)
+)
+
+// LocateIssue 根据问题描述在源码中推断问题所在行
+// 返回1开始的行号，无法定位时返回0
+func LocateIssue(issue string, content []byte) int {
+	lines := strings.Split(string(content), "\n")
+
+	// 优先使用问题中的显式行号
+	if match := issueLinePattern.FindStringSubmatch(issue); match != nil {
+		for _, group := range match[1:] {
+			if line, err := strconv.Atoi(group); err == nil && line > 0 && line <= len(lines) {
+				return line
+			}
+		}
+	}
+
+	// 其次查找问题中提到的标识符的声明位置
+	for _, match := range issueIdentPattern.FindAllStringSubmatch(issue, -1) {
+		name := match[1]
+		if name == "" {
+			name = match[2]
+		}
+		if idx := strings.LastIndex(name, "."); idx != -1 {
+			name = name[idx+1:]
+		}
+		if line := findDeclarationLine(lines, name); line > 0 {
+			return line
+		}
+	}
+
+	return 0
+}
+
+// findDeclarationLine 查找标识符的声明行
+func findDeclarationLine(lines []string, name string) int {
+	if name == "" {
+		return 0
+	}
+
+	quoted := regexp.QuoteMeta(name)
+
+	// 按可信度从高到低依次尝试：声明关键字、函数定义形式、任意出现位置
+	patterns := []*regexp.Regexp{
+		regexp.MustCompile(`\b(?:func|def|function|class|interface|struct|type|var|const|let)\b.*\b` + quoted + `\b`),
+		regexp.MustCompile(`^\s*(?:[\w<>\[\],*&?]+\s+)+` + quoted + `\s*\([^;]*$|\b` + quoted + `\s*[:=]\s*(?:async\s+)?(?:function|\()`),
+		regexp.MustCompile(`\b` + quoted + `\b`),
+	}
+
+	for _, pattern := range patterns {
+		for i, line := range lines {
+			if pattern.MatchString(line) {
+				return i + 1
+			}
+		}
+	}
+
+	return 0
+}
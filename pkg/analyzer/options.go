@@ -0,0 +1,192 @@
+// Package analyzer 提供代码分析功能
+// 创建者：Done-0
+package analyzer
+
+import (
+	"runtime"
+
+	"github.com/Done-0/fuck-u-code/pkg/coverage"
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+	"github.com/Done-0/fuck-u-code/pkg/metrics"
+	"github.com/Done-0/fuck-u-code/pkg/rules"
+)
+
+// Config 分析引擎配置
+type Config struct {
+	Translator       i18n.Translator      // 翻译器，决定指标名称和问题描述的语言
+	Metrics          []string             // 启用的指标键，为空时启用所有默认指标
+	EnabledMetrics   []string             // 在默认指标之外额外启用的指标键
+	DisabledMetrics  []string             // 禁用的指标键
+	Weights          map[string]float64   // 按指标键覆盖默认权重
+	IncludePatterns  []string             // 包含模式
+	ExcludePatterns  []string             // 排除模式
+	NoIgnore         bool                 // 不读取.gitignore和.fuckucodeignore
+	IncludeGenerated bool                 // 分析目录时不跳过生成代码、压缩代码和数据文件
+	Concurrency      int                  // 并发分析的文件数
+	Progress         ProgressReporter     // 进度回调，为空时不报告进度
+	Rules            *rules.Set           // 项目自定义规则
+	Aggregation      Aggregation          // 汇总文件得分的策略
+	NamingStyles     metrics.NamingStyles // 项目配置的命名风格
+	Coverage         *coverage.Profile    // 测试覆盖率，为空时不计算未测试复杂度
+	TestReport       bool                 // 分析目录时是否单独评估测试代码的质量
+	TicketPattern    string               // 识别技术债标记中工单号的正则表达式，为空时使用默认格式
+	Blame            bool                 // 是否读取git blame获取技术债标记的引入时间
+}
+
+// Option 分析引擎配置选项
+type Option func(*Config)
+
+// defaultConfig 返回默认配置
+func defaultConfig() Config {
+	return Config{
+		Translator:  i18n.NewTranslator(i18n.ZhCN),
+		Weights:     make(map[string]float64),
+		Concurrency: runtime.NumCPU(),
+		Progress:    NopProgress{},
+		Aggregation: DefaultAggregation,
+	}
+}
+
+// WithTranslator 设置翻译器
+func WithTranslator(translator i18n.Translator) Option {
+	return func(c *Config) {
+		if translator != nil {
+			c.Translator = translator
+		}
+	}
+}
+
+// WithMetrics 只启用指定键的指标，如 "cyclomatic_complexity"
+func WithMetrics(keys ...string) Option {
+	return func(c *Config) {
+		c.Metrics = append(c.Metrics, keys...)
+	}
+}
+
+// WithEnabledMetrics 额外启用指定键的指标，用于默认禁用的指标
+func WithEnabledMetrics(keys ...string) Option {
+	return func(c *Config) {
+		c.EnabledMetrics = append(c.EnabledMetrics, keys...)
+	}
+}
+
+// WithDisabledMetrics 禁用指定键的指标
+func WithDisabledMetrics(keys ...string) Option {
+	return func(c *Config) {
+		c.DisabledMetrics = append(c.DisabledMetrics, keys...)
+	}
+}
+
+// WithWeight 覆盖指定指标的权重
+func WithWeight(key string, weight float64) Option {
+	return func(c *Config) {
+		c.Weights[key] = weight
+	}
+}
+
+// WithWeights 批量覆盖指标权重
+func WithWeights(weights map[string]float64) Option {
+	return func(c *Config) {
+		for key, weight := range weights {
+			c.Weights[key] = weight
+		}
+	}
+}
+
+// WithIncludes 添加包含模式，分析目录时只分析匹配的文件
+func WithIncludes(patterns ...string) Option {
+	return func(c *Config) {
+		c.IncludePatterns = append(c.IncludePatterns, patterns...)
+	}
+}
+
+// WithExcludes 添加排除模式
+func WithExcludes(patterns ...string) Option {
+	return func(c *Config) {
+		c.ExcludePatterns = append(c.ExcludePatterns, patterns...)
+	}
+}
+
+// WithNoIgnore 设置是否忽略.gitignore和.fuckucodeignore，为true时只使用包含/排除模式
+func WithNoIgnore(noIgnore bool) Option {
+	return func(c *Config) {
+		c.NoIgnore = noIgnore
+	}
+}
+
+// WithIncludeGenerated 设置分析目录时是否包含生成代码，默认按内容识别并跳过
+func WithIncludeGenerated(include bool) Option {
+	return func(c *Config) {
+		c.IncludeGenerated = include
+	}
+}
+
+// WithConcurrency 设置并发分析的文件数，小于1时使用1
+func WithConcurrency(n int) Option {
+	return func(c *Config) {
+		if n < 1 {
+			n = 1
+		}
+		c.Concurrency = n
+	}
+}
+
+// WithProgress 设置进度回调
+func WithProgress(progress ProgressReporter) Option {
+	return func(c *Config) {
+		if progress == nil {
+			progress = NopProgress{}
+		}
+		c.Progress = progress
+	}
+}
+
+// WithRules 设置项目自定义规则，命中的规则计入"自定义规则"指标
+func WithRules(ruleSet *rules.Set) Option {
+	return func(c *Config) {
+		c.Rules = ruleSet
+	}
+}
+
+// WithNamingStyles 设置项目配置的命名风格，覆盖各语言的默认命名规范
+func WithNamingStyles(styles metrics.NamingStyles) Option {
+	return func(c *Config) {
+		c.NamingStyles = styles
+	}
+}
+
+// WithCoverage 设置测试覆盖率，用于计算函数的覆盖率、CRAP风险分和"未测试复杂度"指标
+func WithCoverage(profile *coverage.Profile) Option {
+	return func(c *Config) {
+		c.Coverage = profile
+	}
+}
+
+// WithTestReport 设置分析目录时是否单独评估测试代码的质量，结果在AnalysisResult.TestQuality中
+// 无论是否启用，测试文件都不计入生产代码的评分，只用于匹配生产文件
+func WithTestReport(enabled bool) Option {
+	return func(c *Config) {
+		c.TestReport = enabled
+	}
+}
+
+// WithTicketPattern 设置识别技术债标记中工单号的正则表达式，没有关联工单的标记扣分加倍
+func WithTicketPattern(pattern string) Option {
+	return func(c *Config) {
+		c.TicketPattern = pattern
+	}
+}
+
+// WithBlame 设置是否读取git blame获取技术债标记的引入时间，用于找出最老的标记
+func WithBlame(enabled bool) Option {
+	return func(c *Config) {
+		c.Blame = enabled
+	}
+}
+
+// WithAggregation 设置汇总文件得分的策略，如 AggregateLines、AggregateP90
+func WithAggregation(aggregation Aggregation) Option {
+	return func(c *Config) {
+		c.Aggregation = aggregation
+	}
+}
@@ -0,0 +1,148 @@
+// Package analyzer 提供代码分析功能
+// 创建者：Done-0
+package analyzer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+)
+
+// ProgressReporter 分析进度回调接口
+// 分析引擎保证回调串行调用，实现无需自行加锁
+type ProgressReporter interface {
+	// OnSearchStart 开始搜索源码文件
+	OnSearchStart()
+
+	// OnSearchProgress 搜索过程中已找到found个文件
+	OnSearchProgress(found int)
+
+	// OnSearchDone 搜索完成，共找到total个文件
+	OnSearchDone(total int)
+
+	// OnFileAnalyzed 一个文件分析结束，err不为空表示该文件分析失败
+	OnFileAnalyzed(path string, done int, total int, err error)
+
+	// OnAnalysisDone 所有文件分析结束
+	OnAnalysisDone()
+}
+
+// NopProgress 不做任何事情的进度回调，可嵌入到只关心部分事件的实现中
+type NopProgress struct{}
+
+// OnSearchStart 开始搜索源码文件
+func (NopProgress) OnSearchStart() {}
+
+// OnSearchProgress 搜索过程中已找到found个文件
+func (NopProgress) OnSearchProgress(int) {}
+
+// OnSearchDone 搜索完成
+func (NopProgress) OnSearchDone(int) {}
+
+// OnFileAnalyzed 一个文件分析结束
+func (NopProgress) OnFileAnalyzed(string, int, int, error) {}
+
+// OnAnalysisDone 所有文件分析结束
+func (NopProgress) OnAnalysisDone() {}
+
+// ConsoleProgress 在终端中显示搜索进度和进度条
+type ConsoleProgress struct {
+	out        io.Writer
+	errOut     io.Writer
+	translator i18n.Translator
+	lastFound  int
+}
+
+// NewConsoleProgress 创建终端进度显示，进度写入out，文件错误写入errOut
+func NewConsoleProgress(out io.Writer, errOut io.Writer, translator i18n.Translator) *ConsoleProgress {
+	return &ConsoleProgress{
+		out:        out,
+		errOut:     errOut,
+		translator: translator,
+	}
+}
+
+// OnSearchStart 开始搜索源码文件
+func (p *ConsoleProgress) OnSearchStart() {
+	fmt.Fprintf(p.out, "🔍 %s...\n", p.translator.Translate("analyzer.searching_files"))
+}
+
+// OnSearchProgress 更新搜索进度
+func (p *ConsoleProgress) OnSearchProgress(found int) {
+	if found > p.lastFound {
+		p.lastFound = found
+		fmt.Fprintf(p.out, "\r🔍 %s %d", p.translator.Translate("analyzer.searching_files"), found)
+	}
+}
+
+// OnSearchDone 清除搜索进度并显示文件总数
+func (p *ConsoleProgress) OnSearchDone(total int) {
+	if p.lastFound > 0 {
+		fmt.Fprintf(p.out, "\r%s\r", strings.Repeat(" ", 80))
+		fmt.Fprintf(p.out, "📂 %s: %d\n", p.translator.Translate("analyzer.files_found"), total)
+	}
+}
+
+// OnFileAnalyzed 显示进度条和当前处理的文件
+func (p *ConsoleProgress) OnFileAnalyzed(path string, done int, total int, err error) {
+	if err != nil {
+		fmt.Fprintf(p.errOut, p.translator.Translate("error.file_analysis_failed")+"\n", path, err)
+	}
+
+	progressStyle := color.New(color.FgHiCyan)
+	fileInfoStyle := color.New(color.FgHiBlack) // 淡色字体
+
+	// 根据语言选择进度文本
+	var progressText string
+	switch p.translator.GetLanguage() {
+	case i18n.EnUS:
+		progressText = "Analyzing files"
+	default:
+		progressText = "正在分析文件"
+	}
+
+	// 计算和显示进度条
+	percent := float64(done) / float64(total)
+	barWidth := 30
+	barCompleted := int(float64(barWidth) * percent)
+	barRemaining := barWidth - barCompleted
+
+	fmt.Fprintf(p.out, "\r\033[K  ")
+	progressStyle.Fprintf(p.out, "%s: ", progressText)
+	fmt.Fprintf(p.out, "%d/%d ", done, total)
+	progressStyle.Fprintf(p.out, "[%s%s]",
+		strings.Repeat("█", barCompleted),
+		strings.Repeat("░", barRemaining))
+
+	// 显示当前处理的文件
+	fmt.Fprintf(p.out, "\n\033[K  正在处理: ")
+	fileInfoStyle.Fprintf(p.out, "%s", shortenPath(path))
+
+	// 回到进度条行
+	fmt.Fprintf(p.out, "\033[A\r")
+
+	// 文件较少时稍作停顿，让进度条可见
+	if total < 30 {
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// OnAnalysisDone 清理进度条行
+func (p *ConsoleProgress) OnAnalysisDone() {
+	fmt.Fprint(p.out, "\r\033[K\n")
+}
+
+// shortenPath 缩短文件路径，只显示最后几个部分
+func shortenPath(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) <= 4 {
+		return path
+	}
+
+	return "./" + strings.Join(parts[len(parts)-3:], "/")
+}
@@ -0,0 +1,228 @@
+// Package analyzer 提供代码分析功能
+// 创建者：Done-0
+package analyzer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/metrics"
+	"github.com/Done-0/fuck-u-code/pkg/parser"
+)
+
+// suppressionDirective 抑制注释，必须写在注释中
+// 如 "// fuck-u-code:ignore complexity"、"# fuck-u-code:ignore-file"、"/* fuck-u-code:ignore naming */"
+var suppressionDirective = regexp.MustCompile(`(?://|#|/\*|<!--)\s*fuck-u-code:(ignore-file|ignore)\b([\w ,\t-]*)`)
+
+// suppressionAliases 抑制注释中可以使用的指标简称
+var suppressionAliases = map[string]string{
+	"complexity":  "cyclomatic_complexity",
+	"cognitive":   "cognitive_complexity",
+	"mi":          "maintainability_index",
+	"length":      "function_length",
+	"state":       "state_management",
+	"cohesion":    "class_cohesion",
+	"untested":    "untested_complexity",
+	"crap":        "untested_complexity",
+	"tests":       "test_presence",
+	"debt":        "debt_markers",
+	"todo":        "debt_markers",
+	"comment":     "comment_ratio",
+	"comments":    "comment_ratio",
+	"error":       "error_handling",
+	"errors":      "error_handling",
+	"naming":      "naming_convention",
+	"duplication": "code_duplication",
+	"structure":   "structure_analysis",
+	"custom":      "custom_rules",
+	"rules":       "custom_rules",
+}
+
+// suppression 一条抑制注释及其作用范围
+type suppression struct {
+	metrics   map[string]bool // 被抑制的指标键，为nil表示所有指标
+	wholeFile bool            // 是否作用于整个文件
+	startLine int             // 作用范围起始行
+	endLine   int             // 作用范围结束行
+}
+
+// covers 判断是否抑制指定指标
+func (s suppression) covers(key string) bool {
+	return s.metrics == nil || s.metrics[key]
+}
+
+// coversLine 判断是否抑制指定指标在某行的问题
+func (s suppression) coversLine(key string, line int) bool {
+	return s.covers(key) && (s.wholeFile || (line >= s.startLine && line <= s.endLine))
+}
+
+// parseSuppressions 解析源码中的抑制注释
+// 独占一行的注释作用于下一行代码，如果下一行是函数定义则作用于整个函数；行尾注释作用于所在行
+func parseSuppressions(content []byte, functions []parser.Function) []suppression {
+	if !strings.Contains(string(content), "fuck-u-code:ignore") {
+		return nil
+	}
+
+	lines := strings.Split(string(content), "\n")
+	var result []suppression
+
+	for i, line := range lines {
+		match := suppressionDirective.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		keys, ok := parseSuppressionTargets(match[2])
+		if !ok {
+			continue
+		}
+
+		s := suppression{metrics: keys}
+		if match[1] == "ignore-file" {
+			s.wholeFile = true
+			result = append(result, s)
+			continue
+		}
+
+		target := i + 1
+		if isCommentOnlyLine(line) {
+			target = nextCodeLine(lines, i+1)
+		}
+
+		s.startLine, s.endLine = target, target
+		for _, fn := range functions {
+			if fn.StartLine == target && fn.EndLine > s.endLine {
+				s.endLine = fn.EndLine
+			}
+		}
+		result = append(result, s)
+	}
+
+	return result
+}
+
+// parseSuppressionTargets 解析抑制的指标，未指定时表示所有指标
+// 指定了指标但都无法识别时返回false，避免拼写错误导致抑制全部问题
+func parseSuppressionTargets(text string) (map[string]bool, bool) {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(fields) == 0 {
+		return nil, true
+	}
+
+	keys := make(map[string]bool)
+	for _, field := range fields {
+		field = strings.ToLower(field)
+		if field == "all" {
+			return nil, true
+		}
+		if key, ok := suppressionAliases[field]; ok {
+			keys[key] = true
+		} else if _, ok := metrics.Lookup(field); ok {
+			keys[field] = true
+		}
+	}
+
+	return keys, len(keys) > 0
+}
+
+// isCommentOnlyLine 判断一行是否只有注释
+func isCommentOnlyLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	for _, prefix := range []string{"//", "#", "/*", "*", "<!--"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextCodeLine 返回from(从0开始)之后第一行代码的行号(从1开始)，跳过空行、注释和装饰器
+func nextCodeLine(lines []string, from int) int {
+	for i := from; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || isCommentOnlyLine(trimmed) || strings.HasPrefix(trimmed, "@") {
+			continue
+		}
+		return i + 1
+	}
+	return from
+}
+
+// applySuppressions 从指标结果中移除被抑制的问题
+// 被抑制的函数不参与该指标的评分；整个文件被抑制时返回keep为false
+func applySuppressions(key string, metric metrics.Metric, parseResult parser.ParseResult, result metrics.MetricResult, suppressions []suppression, content []byte) (kept metrics.MetricResult, suppressed []string, keep bool) {
+	for _, s := range suppressions {
+		if s.wholeFile && s.covers(key) {
+			return result, result.Issues, false
+		}
+	}
+
+	// 去掉被抑制的函数后重新计算得分
+	kept = result
+	functions := parseResult.GetFunctions()
+	remaining := make([]parser.Function, 0, len(functions))
+	for _, fn := range functions {
+		if !isFunctionSuppressed(key, fn, suppressions) {
+			remaining = append(remaining, fn)
+		}
+	}
+	if len(remaining) < len(functions) {
+		if len(remaining) == 0 {
+			return result, result.Issues, false
+		}
+		if base, ok := parseResult.(*parser.BaseParseResult); ok {
+			filtered := *base
+			filtered.Functions = remaining
+			kept = metric.Analyze(&filtered)
+			kept.Weight = result.Weight
+		}
+	}
+
+	// 重新计算后消失的问题也算被抑制
+	remainingIssues := make(map[string]int)
+	for _, issue := range kept.Issues {
+		remainingIssues[issue]++
+	}
+	for _, issue := range result.Issues {
+		if remainingIssues[issue] > 0 {
+			remainingIssues[issue]--
+			continue
+		}
+		suppressed = append(suppressed, issue)
+	}
+
+	// 按行抑制剩余问题
+	issues := make([]string, 0, len(kept.Issues))
+	for _, issue := range kept.Issues {
+		if line := LocateIssue(issue, content); line > 0 && isLineSuppressed(key, line, suppressions) {
+			suppressed = append(suppressed, issue)
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	kept.Issues = issues
+
+	return kept, suppressed, true
+}
+
+// isFunctionSuppressed 判断函数是否被针对该指标的抑制注释覆盖
+func isFunctionSuppressed(key string, fn parser.Function, suppressions []suppression) bool {
+	for _, s := range suppressions {
+		if !s.wholeFile && s.covers(key) && s.startLine == fn.StartLine {
+			return true
+		}
+	}
+	return false
+}
+
+// isLineSuppressed 判断某行的问题是否被抑制
+func isLineSuppressed(key string, line int, suppressions []suppression) bool {
+	for _, s := range suppressions {
+		if s.coversLine(key, line) {
+			return true
+		}
+	}
+	return false
+}
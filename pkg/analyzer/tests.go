@@ -0,0 +1,306 @@
+// Package analyzer 提供代码分析功能
+// 创建者：Done-0
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+	"github.com/Done-0/fuck-u-code/pkg/metrics"
+)
+
+// TestSummary 测试文件与生产文件的匹配情况
+type TestSummary struct {
+	TestFiles       int            // 测试文件数
+	TestLines       int            // 测试代码行数
+	ProductionFiles int            // 生产文件数
+	ProductionLines int            // 生产代码行数
+	Untested        int            // 没有对应测试的生产文件数
+	Packages        []PackageTests // 各包的测试情况，未测试文件多的在前
+	NoAssertions    []string       // 没有任何断言的测试文件
+}
+
+// PackageTests 包的测试情况，包为生产文件所在目录，测试目录（如 src/test、tests、__tests__）与对应的生产目录视为同一个包
+type PackageTests struct {
+	Name            string   // 包名，为相对于分析根目录的目录
+	ProductionFiles int      // 生产文件数
+	TestFiles       int      // 测试文件数
+	ProductionLines int      // 生产代码行数
+	TestLines       int      // 测试代码行数
+	Untested        []string // 没有对应测试的生产文件
+}
+
+// Ratio 返回测试代码行数与生产代码行数之比
+func (p PackageTests) Ratio() float64 {
+	if p.ProductionLines == 0 {
+		return 0
+	}
+	return float64(p.TestLines) / float64(p.ProductionLines)
+}
+
+// Ratio 返回测试代码行数与生产代码行数之比
+func (s *TestSummary) Ratio() float64 {
+	if s.ProductionLines == 0 {
+		return 0
+	}
+	return float64(s.TestLines) / float64(s.ProductionLines)
+}
+
+// testDirNames 只用于存放测试或区分源码和测试的目录名，计算包时忽略
+var testDirNames = map[string]bool{
+	"test": true, "tests": true, "__tests__": true, "spec": true, "testing": true, "main": true,
+}
+
+// assertionPatterns 各语言中表示断言的写法
+var assertionPatterns = map[common.LanguageType]*regexp.Regexp{
+	common.Go:         regexp.MustCompile(`\b[tbf]\.(Error|Errorf|Fatal|Fatalf|Fail|FailNow)\(|\b(assert|require)\.\w+\(|(?m)^\s*// (Unordered )?[Oo]utput:`),
+	common.Python:     regexp.MustCompile(`\bassert\b|\bself\.(assert\w*|fail)\(|\bpytest\.(raises|fail)\b`),
+	common.JavaScript: regexp.MustCompile(`\b(expect|assert)\b|\.should\b|\bt\.(is|not|true|false|truthy|falsy|deepEqual|throws)\(`),
+	common.Java:       regexp.MustCompile(`\bassert\w*\s*\(|\bassert\s|\b(verify|fail)\(|@Test\s*\(\s*expected`),
+	common.CSharp:     regexp.MustCompile(`\bAssert\.|\.Should\(|\.Verify\(`),
+	common.C:          regexp.MustCompile(`\b(ASSERT|EXPECT|CHECK|REQUIRE)_?\w*\s*\(|\bassert\s*\(`),
+}
+
+// testFile 测试文件
+type testFile struct {
+	path       string
+	pkg        string // 所属包的键
+	lines      int    // 代码行数
+	assertions bool   // 是否包含断言
+}
+
+// testIndex 测试文件索引，分析前建立，分析过程中只读
+type testIndex struct {
+	root          string                 // 分析根目录，包键相对于它计算
+	paths         map[string]bool        // 所有测试文件，包括读取失败和生成的
+	tests         map[string]*testFile   // 测试文件路径到测试文件
+	matched       map[string][]*testFile // 生产文件路径到按命名匹配的测试文件
+	packageTested map[string]bool        // 有包级测试的包
+}
+
+// languageFamily 返回匹配测试时使用的语言族，JavaScript和TypeScript、C和C++互相匹配
+func languageFamily(lang common.LanguageType) common.LanguageType {
+	switch lang {
+	case common.TypeScript:
+		return common.JavaScript
+	case common.CPlusPlus:
+		return common.C
+	default:
+		return lang
+	}
+}
+
+// testPackageKey 返回文件所属包的键：相对目录去掉测试相关的目录名
+// 如 src/main/java/com/a 和 src/test/java/com/a 都为 src/java/com/a
+func testPackageKey(root, path string) string {
+	rel, err := relativeTo(root, filepath.Dir(path))
+	if err != nil {
+		rel = filepath.Dir(path)
+	}
+	var segments []string
+	for _, segment := range strings.Split(filepath.ToSlash(rel), "/") {
+		if segment != "." && !testDirNames[segment] {
+			segments = append(segments, segment)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// relativeTo 返回path相对于root的路径
+// 两者都先转换为绝对路径，根目录为 "." 时查找到的文件路径是绝对路径
+func relativeTo(root, path string) (string, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(absRoot, absPath)
+}
+
+// commonPrefix 返回两个包键开头相同的目录数
+func commonPrefix(a, b string) int {
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+	n := 0
+	for n < len(as) && n < len(bs) && as[n] == bs[n] {
+		n++
+	}
+	return n
+}
+
+// splitTestFiles 将文件分为生产文件和测试文件
+// 测试文件模式相对于root匹配，root先转换为绝对路径，避免根目录之外的 test、spec 目录参与匹配
+func splitTestFiles(root string, files []string) (sources, tests []string) {
+	if absRoot, err := filepath.Abs(root); err == nil {
+		root = absRoot
+	}
+	for _, file := range files {
+		if common.IsTestFile(file, root) {
+			tests = append(tests, file)
+		} else {
+			sources = append(sources, file)
+		}
+	}
+	return sources, tests
+}
+
+// buildTestIndex 读取测试文件并按命名和包与生产文件匹配
+// 测试文件名对应的生产文件有多个时，取目录最接近的；找不到对应文件的测试视为所在包的包级测试
+func (e *Engine) buildTestIndex(root string, sources, tests []string) *testIndex {
+	index := &testIndex{
+		root:          root,
+		paths:         make(map[string]bool, len(tests)),
+		tests:         make(map[string]*testFile, len(tests)),
+		matched:       make(map[string][]*testFile),
+		packageTested: make(map[string]bool),
+	}
+
+	type subjectKey struct {
+		family common.LanguageType
+		name   string
+	}
+	detector := common.NewLanguageDetector()
+	bySubject := make(map[subjectKey][]string)
+	for _, source := range sources {
+		base := filepath.Base(source)
+		key := subjectKey{languageFamily(detector.DetectLanguage(source)), strings.TrimSuffix(base, filepath.Ext(base))}
+		bySubject[key] = append(bySubject[key], source)
+	}
+
+	for _, path := range tests {
+		index.paths[path] = true
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if !e.config.IncludeGenerated && common.DetectGenerated(path, content) != common.GeneratedNone {
+			continue
+		}
+
+		family := languageFamily(detector.DetectLanguage(path))
+		test := &testFile{
+			path:       path,
+			pkg:        testPackageKey(root, path),
+			lines:      strings.Count(string(content), "\n") + 1,
+			assertions: assertionPatterns[family] != nil && assertionPatterns[family].Match(content),
+		}
+		index.tests[path] = test
+
+		// 同名的生产文件中取包最接近的，距离相同时都算匹配
+		var subjects []string
+		best := -1
+		if subject := common.TestSubject(path); subject != "" {
+			for _, source := range bySubject[subjectKey{family, subject}] {
+				switch score := commonPrefix(test.pkg, testPackageKey(root, source)); {
+				case score > best:
+					subjects, best = []string{source}, score
+				case score == best:
+					subjects = append(subjects, source)
+				}
+			}
+		}
+		if len(subjects) == 0 {
+			index.packageTested[test.pkg] = true
+		}
+		for _, source := range subjects {
+			index.matched[source] = append(index.matched[source], test)
+		}
+	}
+	return index
+}
+
+// isTest 判断文件是否为索引中的测试文件
+func (t *testIndex) isTest(path string) bool {
+	return t != nil && t.paths[path]
+}
+
+// forFile 返回生产文件对应的测试，索引为空或文件本身是测试时返回nil
+func (t *testIndex) forFile(path string) *metrics.FileTests {
+	if t == nil || t.isTest(path) {
+		return nil
+	}
+
+	tests := &metrics.FileTests{PackageTested: t.packageTested[testPackageKey(t.root, path)]}
+	for _, test := range t.matched[path] {
+		tests.TestFiles = append(tests.TestFiles, test.path)
+		if !test.assertions {
+			tests.NoAssertions = append(tests.NoAssertions, test.path)
+		}
+	}
+	return tests
+}
+
+// summarize 汇总root下生产文件和测试文件的匹配情况，root可以是索引根目录下的子项目
+func (t *testIndex) summarize(root string, fileResults []*metrics.AnalysisResult) *TestSummary {
+	if t == nil {
+		return nil
+	}
+
+	summary := &TestSummary{}
+	packages := make(map[string]*PackageTests)
+	pkgOf := func(key, path string) *PackageTests {
+		if pkg, ok := packages[key]; ok {
+			return pkg
+		}
+		name, err := relativeTo(root, filepath.Dir(path))
+		if err != nil {
+			name = filepath.Dir(path)
+		}
+		pkg := &PackageTests{Name: filepath.ToSlash(name)}
+		packages[key] = pkg
+		return pkg
+	}
+
+	for _, r := range fileResults {
+		pkg := pkgOf(testPackageKey(t.root, r.FilePath), r.FilePath)
+		pkg.ProductionFiles++
+		pkg.ProductionLines += r.TotalLines
+		summary.ProductionFiles++
+		summary.ProductionLines += r.TotalLines
+		if len(r.Functions) > 0 && !t.forFile(r.FilePath).Tested() {
+			pkg.Untested = append(pkg.Untested, r.FilePath)
+			summary.Untested++
+		}
+	}
+
+	paths := make([]string, 0, len(t.tests))
+	for path := range t.tests {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		test := t.tests[path]
+		if rel, err := relativeTo(root, test.path); err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		pkg := pkgOf(test.pkg, test.path)
+		pkg.TestFiles++
+		pkg.TestLines += test.lines
+		summary.TestFiles++
+		summary.TestLines += test.lines
+		if !test.assertions {
+			summary.NoAssertions = append(summary.NoAssertions, test.path)
+		}
+	}
+
+	for _, pkg := range packages {
+		summary.Packages = append(summary.Packages, *pkg)
+	}
+	sort.Slice(summary.Packages, func(i, j int) bool {
+		a, b := summary.Packages[i], summary.Packages[j]
+		if len(a.Untested) != len(b.Untested) {
+			return len(a.Untested) > len(b.Untested)
+		}
+		if a.Ratio() != b.Ratio() {
+			return a.Ratio() < b.Ratio()
+		}
+		return a.Name < b.Name
+	})
+	return summary
+}
@@ -0,0 +1,109 @@
+// Package analyzer 提供代码分析功能
+// 创建者：Done-0
+package analyzer
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// maxWorstFiles 每个目录记录的最差文件数
+const maxWorstFiles = 3
+
+// DirectoryResult 目录汇总结果，包含其所有子目录中的文件
+type DirectoryResult struct {
+	Path        string               // 目录路径，根节点为分析路径
+	Name        string               // 目录名
+	Score       float64              // 按汇总策略计算的得分(0-1，越高越差)
+	TotalFiles  int                  // 文件数
+	TotalLines  int                  // 代码行数
+	TotalIssues int                  // 问题数
+	WorstFiles  []FileAnalysisResult // 得分最差的文件，按得分从差到好排序
+	Children    []*DirectoryResult   // 子目录，按得分从差到好排序
+}
+
+// BuildDirectoryTree 按目录层级汇总文件结果，root为分析路径，aggregation为空时使用默认策略
+func BuildDirectoryTree(root string, files []FileAnalysisResult, aggregation Aggregation) *DirectoryResult {
+	if aggregation == "" {
+		aggregation = DefaultAggregation
+	}
+
+	tree := &DirectoryResult{Path: root, Name: filepath.Base(root)}
+	allFiles := make(map[*DirectoryResult][]FileAnalysisResult)
+
+	// 文件路径可能是绝对路径，统一转换后再计算相对目录
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		absRoot = root
+	}
+
+	for _, f := range files {
+		absPath, err := filepath.Abs(f.FilePath)
+		if err != nil {
+			absPath = f.FilePath
+		}
+		relDir, err := filepath.Rel(absRoot, filepath.Dir(absPath))
+		if err != nil || strings.HasPrefix(relDir, "..") {
+			relDir = "."
+		}
+
+		// 文件计入从根目录到所在目录路径上的每一个节点
+		node := tree
+		allFiles[node] = append(allFiles[node], f)
+		if relDir != "." {
+			for _, part := range strings.Split(filepath.ToSlash(relDir), "/") {
+				node = node.child(part)
+				allFiles[node] = append(allFiles[node], f)
+			}
+		}
+	}
+
+	for node, nodeFiles := range allFiles {
+		node.aggregate(nodeFiles, aggregation)
+	}
+	tree.sortChildren()
+
+	return tree
+}
+
+// child 返回指定名称的子目录，不存在时创建
+func (d *DirectoryResult) child(name string) *DirectoryResult {
+	for _, c := range d.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	c := &DirectoryResult{Path: filepath.Join(d.Path, name), Name: name}
+	d.Children = append(d.Children, c)
+	return c
+}
+
+// aggregate 汇总目录中所有文件的得分、行数、问题数和最差文件
+func (d *DirectoryResult) aggregate(files []FileAnalysisResult, aggregation Aggregation) {
+	samples := make([]scoreSample, 0, len(files))
+	for _, f := range files {
+		d.TotalFiles++
+		d.TotalLines += f.TotalLines
+		d.TotalIssues += len(f.Issues)
+		samples = append(samples, scoreSample{score: f.FileScore, lines: f.TotalLines, functions: f.FunctionCount})
+	}
+	d.Score = aggregation.aggregate(samples)
+
+	worst := make([]FileAnalysisResult, len(files))
+	copy(worst, files)
+	sort.SliceStable(worst, func(i, j int) bool {
+		return worst[i].FileScore > worst[j].FileScore
+	})
+	d.WorstFiles = worst[:min(maxWorstFiles, len(worst))]
+}
+
+// sortChildren 递归将子目录按得分从差到好排序
+func (d *DirectoryResult) sortChildren() {
+	sort.SliceStable(d.Children, func(i, j int) bool {
+		return d.Children[i].Score > d.Children[j].Score
+	})
+	for _, c := range d.Children {
+		c.sortChildren()
+	}
+}
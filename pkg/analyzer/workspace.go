@@ -0,0 +1,98 @@
+// Package analyzer 提供代码分析功能
+// 创建者：Done-0
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Done-0/fuck-u-code/pkg/metrics"
+	"github.com/Done-0/fuck-u-code/pkg/workspace"
+)
+
+// WorkspaceResult 工作区分析结果，每个子项目单独评分
+type WorkspaceResult struct {
+	Root     string          // 工作区根目录
+	Projects []ProjectResult // 各子项目的结果，按路径排序
+	Overall  *AnalysisResult // 所有文件合并后的结果
+}
+
+// ProjectResult 子项目分析结果
+type ProjectResult struct {
+	Name   string          // 项目名称
+	Path   string          // 项目目录
+	Kind   string          // 项目类型，如 go、node、python，不属于任何子项目的文件为空
+	Result *AnalysisResult // 项目的分析结果
+}
+
+// AnalyzeWorkspace 识别目录中的子项目（go.mod、package.json、pyproject.toml、pom.xml等）并分别评分
+// 每个文件归属于离它最近的子项目，所有文件只分析一次
+func (e *Engine) AnalyzeWorkspace(ctx context.Context, root string) (*WorkspaceResult, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf(e.config.Translator.Translate("error.path_not_accessible"), err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf(e.config.Translator.Translate("error.workspace_not_directory"), root)
+	}
+
+	files, tests, err := e.findFiles(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	fileResults, failedFiles, skippedFiles, err := e.analyzeFiles(ctx, files, tests)
+	if err != nil {
+		return nil, err
+	}
+	e.config.Progress.OnAnalysisDone()
+
+	result := &WorkspaceResult{
+		Root:    root,
+		Overall: e.buildDirectoryResult(root, fileResults, failedFiles, skippedFiles, tests),
+	}
+
+	// 按文件路径索引，便于拆分到各子项目
+	resultsByPath := make(map[string]*metrics.AnalysisResult, len(fileResults))
+	for _, r := range fileResults {
+		resultsByPath[r.FilePath] = r
+	}
+	failedByPath := make(map[string]FileError, len(failedFiles))
+	for _, f := range failedFiles {
+		failedByPath[f.FilePath] = f
+	}
+	skippedByPath := make(map[string]SkippedFile, len(skippedFiles))
+	for _, s := range skippedFiles {
+		skippedByPath[s.FilePath] = s
+	}
+
+	for _, project := range workspace.Group(root, files) {
+		var projectResults []*metrics.AnalysisResult
+		var projectFailed []FileError
+		var projectSkipped []SkippedFile
+		for _, file := range project.Files {
+			if r, ok := resultsByPath[file]; ok {
+				projectResults = append(projectResults, r)
+			} else if f, ok := failedByPath[file]; ok {
+				projectFailed = append(projectFailed, f)
+			} else if s, ok := skippedByPath[file]; ok {
+				projectSkipped = append(projectSkipped, s)
+			}
+		}
+
+		// 只有生成代码或全部分析失败的项目不参与评分
+		if len(projectResults) == 0 {
+			continue
+		}
+
+		result.Projects = append(result.Projects, ProjectResult{
+			Name:   project.Name,
+			Path:   project.Path,
+			Kind:   string(project.Kind),
+			Result: e.buildDirectoryResult(project.Path, projectResults, projectFailed, projectSkipped, tests),
+		})
+	}
+
+	return result, nil
+}
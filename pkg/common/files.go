@@ -4,6 +4,7 @@
 package common
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
@@ -25,6 +26,35 @@ func FindSourceFiles(
 	excludePatterns []string,
 	progressCallback func(found int),
 ) ([]string, error) {
+	return FindSourceFilesContext(context.Background(), rootDir, includePatterns, excludePatterns, progressCallback)
+}
+
+// FindSourceFilesContext 与FindSourceFiles相同，但在ctx取消时提前结束遍历并返回ctx的错误
+func FindSourceFilesContext(
+	ctx context.Context,
+	rootDir string,
+	includePatterns []string,
+	excludePatterns []string,
+	progressCallback func(found int),
+) ([]string, error) {
+	return FindSourceFilesWithOptions(ctx, rootDir, FindOptions{
+		IncludePatterns: includePatterns,
+		ExcludePatterns: excludePatterns,
+		Progress:        progressCallback,
+	})
+}
+
+// FindOptions 查找源码文件的选项
+type FindOptions struct {
+	IncludePatterns []string        // 包含模式，为空时包含所有支持的文件
+	ExcludePatterns []string        // 排除模式
+	NoIgnore        bool            // 不读取.gitignore和.fuckucodeignore
+	Progress        func(found int) // 进度回调，报告已找到的文件数量
+}
+
+// FindSourceFilesWithOptions 按选项在指定目录中查找源代码文件
+// 默认遵循各级目录中的.gitignore和.fuckucodeignore，模式语法与.gitignore相同
+func FindSourceFilesWithOptions(ctx context.Context, rootDir string, opts FindOptions) ([]string, error) {
 	var files []string
 	detector := NewLanguageDetector()
 
@@ -36,18 +66,32 @@ func FindSourceFiles(
 		}
 	}
 
+	var ignore *IgnoreMatcher
+	if !opts.NoIgnore {
+		ignore = NewIgnoreMatcher(rootDir)
+	}
+
 	// 遍历目录查找文件
 	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
 		// 跳过目录
 		if info.IsDir() {
+			if path == rootDir {
+				return nil
+			}
 			// 检查是否应该跳过此目录
-			if shouldSkipDir(path, rootDir, excludePatterns) {
+			if shouldSkipDir(path, rootDir, opts.ExcludePatterns) || (ignore != nil && ignore.IsIgnored(path, true)) {
 				return filepath.SkipDir
 			}
+			if ignore != nil {
+				ignore.LoadDir(path)
+			}
 			return nil
 		}
 
@@ -56,13 +100,13 @@ func FindSourceFiles(
 			return nil
 		}
 
-		// 检查是否符合包含/排除模式
-		if shouldIncludeFile(path, rootDir, includePatterns, excludePatterns) {
+		// 检查是否符合包含/排除模式和忽略文件
+		if shouldIncludeFile(path, rootDir, opts.IncludePatterns, opts.ExcludePatterns) && (ignore == nil || !ignore.IsIgnored(path, false)) {
 			files = append(files, path)
 
 			// 报告进度
-			if progressCallback != nil {
-				progressCallback(len(files))
+			if opts.Progress != nil {
+				opts.Progress(len(files))
 			}
 		}
 
@@ -80,7 +124,7 @@ func shouldSkipDir(path, rootDir string, excludePatterns []string) bool {
 	}
 
 	// 检查排除模式
-	return matchesAnyPattern(path, rootDir, excludePatterns)
+	return matchesAnyPatternAs(path, rootDir, excludePatterns, true)
 }
 
 // shouldIncludeFile 判断是否应该包含文件
@@ -96,14 +140,24 @@ func shouldIncludeFile(path, rootDir string, includePatterns, excludePatterns []
 	return matchesAnyPattern(path, rootDir, includePatterns)
 }
 
+// MatchesAnyPattern 检查路径相对于rootDir是否匹配任一模式，模式语法与.gitignore相同
+func MatchesAnyPattern(path, rootDir string, patterns []string) bool {
+	return matchesAnyPattern(path, rootDir, patterns)
+}
+
 // matchesAnyPattern 检查路径是否匹配任一模式
 func matchesAnyPattern(path, rootDir string, patterns []string) bool {
+	return matchesAnyPatternAs(path, rootDir, patterns, false)
+}
+
+// matchesAnyPatternAs 检查路径是否匹配任一模式，isDir表示路径是否为目录
+func matchesAnyPatternAs(path, rootDir string, patterns []string, isDir bool) bool {
 	if len(patterns) == 0 {
 		return false
 	}
 
-	// 获取相对路径，用于匹配
-	relPath, err := filepath.Rel(rootDir, path)
+	// 获取相对路径，用于匹配；两边先转换为绝对路径，根目录为 "." 时查找到的文件路径是绝对路径
+	relPath, err := relativePath(rootDir, path)
 	if err != nil {
 		relPath = path
 	}
@@ -112,39 +166,25 @@ func matchesAnyPattern(path, rootDir string, patterns []string) bool {
 	relPath = filepath.ToSlash(relPath)
 
 	for _, pattern := range patterns {
-		// 标准化模式分隔符
-		pattern = filepath.ToSlash(pattern)
-
-		// 尝试直接匹配
-		if matched, _ := filepath.Match(pattern, relPath); matched {
+		if p := cachedGlob(pattern); p != nil && !p.negate && p.match(relPath, isDir) {
 			return true
 		}
-
-		// 处理 **/ 模式
-		if strings.Contains(pattern, "**/") {
-			parts := strings.Split(pattern, "**/")
-			if len(parts) == 2 {
-				prefix := parts[0]
-				suffix := parts[1]
-
-				// 检查前缀匹配
-				if prefix == "" || strings.HasPrefix(relPath, prefix) {
-					// 检查后缀匹配
-					if suffix == "" || strings.HasSuffix(relPath, suffix) || matchesPattern(relPath, suffix) {
-						return true
-					}
-				}
-			}
-		}
 	}
 
 	return false
 }
 
-// matchesPattern 检查路径是否匹配模式
-func matchesPattern(path, pattern string) bool {
-	matched, _ := filepath.Match(pattern, filepath.Base(path))
-	return matched
+// relativePath 返回path相对于rootDir的路径，两者都先转换为绝对路径
+func relativePath(rootDir, path string) (string, error) {
+	absRoot, err := filepath.Abs(rootDir)
+	if err != nil {
+		return "", err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(absRoot, absPath)
 }
 
 // isHiddenDir 判断是否为隐藏目录
@@ -0,0 +1,158 @@
+// Package common 提供项目通用功能
+// 创建者：Done-0
+
+package common
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// GeneratedKind 生成代码的类型，为空表示不是生成代码
+type GeneratedKind string
+
+// 生成代码的类型
+const (
+	GeneratedNone     GeneratedKind = ""         // 不是生成代码
+	GeneratedHeader   GeneratedKind = "header"   // 文件头声明为生成代码，如 "// Code generated ... DO NOT EDIT."
+	GeneratedMarker   GeneratedKind = "marker"   // 包含 @generated 标记
+	GeneratedProtobuf GeneratedKind = "protobuf" // protobuf/gRPC 生成的代码
+	GeneratedMinified GeneratedKind = "minified" // 压缩后的代码
+	GeneratedData     GeneratedKind = "data"     // 数据或锁文件
+)
+
+// headerLines 检查生成标记时读取的文件头行数
+const headerLines = 30
+
+var (
+	// goGeneratedHeader Go官方约定的生成代码声明
+	goGeneratedHeader = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.\r?$`)
+
+	// autoGeneratedComment 文件头注释中的生成声明
+	autoGeneratedComment = regexp.MustCompile(`(?i)\bauto-?generated\b`)
+	generatedComment     = regexp.MustCompile(`(?i)\bgenerated\b`)
+	doNotEditComment     = regexp.MustCompile(`(?i)\bdo not (edit|modify)\b`)
+
+	// protobufComment protoc及其插件生成的声明
+	protobufComment = regexp.MustCompile(`Generated by the protocol buffer compiler|protoc-gen-[\w-]+`)
+
+	// literalLine 只包含字面量和标点的行，如 "name": "x",
+	literalLine = regexp.MustCompile(`^(?:"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|[-+]?\d[\w.]*|true|false|null|None|True|False|[\[\]{}(),:;=\s])+$`)
+)
+
+// protobufSuffixes protobuf/gRPC生成文件的后缀
+var protobufSuffixes = []string{
+	".pb.go", ".pb.gw.go", "_pb2.py", "_pb2_grpc.py", ".pb.cc", ".pb.h",
+	"_pb.js", "_grpc_pb.js", "_pb.d.ts", "_grpc_pb.d.ts", ".pb.ts",
+}
+
+// lockFileNames 常见的锁文件
+var lockFileNames = map[string]bool{
+	"package-lock.json": true, "yarn.lock": true, "pnpm-lock.yaml": true,
+	"go.sum": true, "Cargo.lock": true, "poetry.lock": true, "Pipfile.lock": true,
+	"composer.lock": true, "Gemfile.lock": true, "packages.lock.json": true,
+}
+
+// DetectGenerated 根据文件名和内容判断是否为生成代码、压缩代码或数据文件
+func DetectGenerated(path string, content []byte) GeneratedKind {
+	base := filepath.Base(path)
+	if lockFileNames[base] {
+		return GeneratedData
+	}
+	for _, suffix := range protobufSuffixes {
+		if strings.HasSuffix(base, suffix) {
+			return GeneratedProtobuf
+		}
+	}
+
+	header := headerComments(content)
+	if protobufComment.MatchString(header) {
+		return GeneratedProtobuf
+	}
+	if goGeneratedHeader.Match(content) {
+		return GeneratedHeader
+	}
+	if strings.Contains(header, "@generated") {
+		return GeneratedMarker
+	}
+	if autoGeneratedComment.MatchString(header) || (generatedComment.MatchString(header) && doNotEditComment.MatchString(header)) {
+		return GeneratedHeader
+	}
+
+	if isMinified(content) {
+		return GeneratedMinified
+	}
+	if isDataLike(content) {
+		return GeneratedData
+	}
+
+	return GeneratedNone
+}
+
+// headerComments 返回文件头部的注释行，避免代码中的字符串被误认为生成标记
+func headerComments(content []byte) string {
+	var sb strings.Builder
+	lines := bytes.SplitN(content, []byte("\n"), headerLines+1)
+	for i, line := range lines {
+		if i == headerLines {
+			break
+		}
+		trimmed := strings.TrimSpace(string(line))
+		for _, prefix := range []string{"//", "#", "/*", "*", "<!--", "\"\"\"", "'''"} {
+			if strings.HasPrefix(trimmed, prefix) {
+				sb.WriteString(trimmed)
+				sb.WriteByte('\n')
+				break
+			}
+		}
+	}
+	return sb.String()
+}
+
+// isMinified 判断是否为压缩后的代码：有超长行且空白很少，或平均行长过长
+func isMinified(content []byte) bool {
+	if len(content) < 2048 {
+		return false
+	}
+
+	lines := bytes.Count(content, []byte("\n")) + 1
+	maxLen, lineLen, spaces := 0, 0, 0
+	for _, b := range content {
+		switch b {
+		case '\n':
+			maxLen = max(maxLen, lineLen)
+			lineLen = 0
+			spaces++
+		case ' ', '\t', '\r':
+			lineLen++
+			spaces++
+		default:
+			lineLen++
+		}
+	}
+	maxLen = max(maxLen, lineLen)
+
+	whitespaceRatio := float64(spaces) / float64(len(content))
+	avgLen := len(content) / lines
+
+	return (maxLen >= 1000 && whitespaceRatio < 0.1) || avgLen >= 300
+}
+
+// isDataLike 判断是否为数据文件：绝大多数行只有字面量
+func isDataLike(content []byte) bool {
+	total, literal := 0, 0
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		total++
+		if literalLine.MatchString(trimmed) {
+			literal++
+		}
+	}
+
+	return total >= 200 && float64(literal)/float64(total) >= 0.9
+}
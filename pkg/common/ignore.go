@@ -0,0 +1,286 @@
+// Package common 提供项目通用功能
+// 创建者：Done-0
+
+package common
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// IgnoreFileName 工具专用的忽略文件名，语法与.gitignore相同，优先级高于同目录的.gitignore
+const IgnoreFileName = ".fuckucodeignore"
+
+// ignoreFileNames 按优先级从低到高排列的忽略文件
+var ignoreFileNames = []string{".gitignore", IgnoreFileName}
+
+// globPattern 编译后的gitignore风格模式
+type globPattern struct {
+	negate  bool           // 以!开头，重新包含被忽略的路径
+	dirOnly bool           // 以/结尾，只匹配目录
+	regex   *regexp.Regexp // 匹配相对路径的正则
+}
+
+// globCache 排除/包含模式的编译缓存
+var globCache sync.Map
+
+// compileGlob 按gitignore语法编译模式，空行和注释返回nil
+// 语法：
+//   - 不含/的模式匹配任意层级的文件名，含/的模式相对于基准目录
+//   - * 和 ? 不匹配/，** 匹配任意层级目录
+//   - 以/结尾只匹配目录，以!开头表示取反
+func compileGlob(line string) *globPattern {
+	line = strings.TrimRight(line, "\r")
+	// 去掉未转义的行尾空格
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = line[:len(line)-1]
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+
+	p := &globPattern{}
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+
+	line = filepath.ToSlash(line)
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return nil
+	}
+
+	// 含/的模式相对于基准目录，否则匹配任意层级
+	if strings.Contains(line, "/") {
+		line = strings.TrimPrefix(line, "/")
+	} else {
+		line = "**/" + line
+	}
+
+	regex, err := regexp.Compile("^" + globToRegex(line) + "$")
+	if err != nil {
+		return nil
+	}
+	p.regex = regex
+	return p
+}
+
+// globToRegex 将glob模式转换为正则
+func globToRegex(glob string) string {
+	var sb strings.Builder
+	runes := []rune(glob)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			atStart := i == 0 || runes[i-1] == '/'
+			switch {
+			case atStart && i+2 < len(runes) && runes[i+2] == '/':
+				// **/ 匹配零个或多个目录
+				sb.WriteString(`(?:.*/)?`)
+				i += 2
+			case atStart && i+2 == len(runes):
+				// 末尾的 /** 匹配目录下的所有内容
+				sb.WriteString(`.*`)
+				i++
+			default:
+				sb.WriteString(`.*`)
+				for i+1 < len(runes) && runes[i+1] == '*' {
+					i++
+				}
+			}
+		case r == '*':
+			sb.WriteString(`[^/]*`)
+		case r == '?':
+			sb.WriteString(`[^/]`)
+		case r == '[':
+			end := strings.IndexRune(string(runes[i+1:]), ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := string(runes[i+1 : i+1+end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case r == '\\' && i+1 < len(runes):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(runes[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	return sb.String()
+}
+
+// match 判断相对路径是否匹配模式
+// 目录还会以"目录/"的形式匹配，使 dir/** 之类的模式可以直接跳过整个目录
+func (p *globPattern) match(relPath string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	if p.regex.MatchString(relPath) {
+		return true
+	}
+	return isDir && p.regex.MatchString(relPath+"/")
+}
+
+// cachedGlob 获取缓存的编译结果
+func cachedGlob(pattern string) *globPattern {
+	if cached, ok := globCache.Load(pattern); ok {
+		return cached.(*globPattern)
+	}
+	compiled := compileGlob(pattern)
+	globCache.Store(pattern, compiled)
+	return compiled
+}
+
+// IgnoreMatcher 按目录加载.gitignore和.fuckucodeignore并判断路径是否被忽略
+// 子目录的规则优先于父目录，同一目录中后出现的规则优先，与git行为一致
+type IgnoreMatcher struct {
+	top      string                    // 最上层的规则目录，通常是仓库根目录
+	exclude  []*globPattern            // .git/info/exclude中的模式，相对于仓库根目录
+	patterns map[string][]*globPattern // 目录 -> 该目录忽略文件中的模式
+}
+
+// NewIgnoreMatcher 为rootDir创建忽略规则匹配器
+// rootDir位于git仓库中时，同时加载仓库根目录到rootDir之间各级目录的忽略文件和.git/info/exclude
+func NewIgnoreMatcher(rootDir string) *IgnoreMatcher {
+	root, err := filepath.Abs(rootDir)
+	if err != nil {
+		root = rootDir
+	}
+
+	m := &IgnoreMatcher{
+		top:      root,
+		patterns: make(map[string][]*globPattern),
+	}
+
+	repoRoot := findRepoRoot(root)
+	if repoRoot == "" {
+		m.LoadDir(root)
+		return m
+	}
+
+	m.top = repoRoot
+	m.exclude = readIgnoreFile(filepath.Join(repoRoot, ".git", "info", "exclude"))
+
+	// 从仓库根目录逐级加载到rootDir
+	var dirs []string
+	for dir := root; ; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == repoRoot || dir == filepath.Dir(dir) {
+			break
+		}
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		m.LoadDir(dirs[i])
+	}
+
+	return m
+}
+
+// findRepoRoot 向上查找包含.git的目录，找不到时返回空字符串
+func findRepoRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadDir 加载目录中的忽略文件，重复加载同一目录时不做任何事
+// 规则按绝对路径保存，与IsIgnored的查找方式一致，遍历相对路径的目录时同样生效
+func (m *IgnoreMatcher) LoadDir(dir string) {
+	if absDir, err := filepath.Abs(dir); err == nil {
+		dir = absDir
+	}
+	if _, loaded := m.patterns[dir]; loaded {
+		return
+	}
+
+	var patterns []*globPattern
+	for _, name := range ignoreFileNames {
+		patterns = append(patterns, readIgnoreFile(filepath.Join(dir, name))...)
+	}
+	m.patterns[dir] = patterns
+}
+
+// readIgnoreFile 读取忽略文件，文件不存在时返回nil
+func readIgnoreFile(path string) []*globPattern {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var patterns []*globPattern
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if p := compileGlob(scanner.Text()); p != nil {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// IsIgnored 判断路径是否被忽略，路径所在的各级目录需要已通过LoadDir加载
+func (m *IgnoreMatcher) IsIgnored(path string, isDir bool) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+
+	// 从上到下收集规则目录
+	var dirs []string
+	for dir := filepath.Dir(absPath); ; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == m.top || dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	ignored := false
+	for i := len(dirs) - 1; i >= 0; i-- {
+		patterns := m.patterns[dirs[i]]
+		if dirs[i] == m.top && len(m.exclude) > 0 {
+			patterns = append(append([]*globPattern{}, m.exclude...), patterns...)
+		}
+		if len(patterns) == 0 {
+			continue
+		}
+
+		relPath, err := filepath.Rel(dirs[i], absPath)
+		if err != nil {
+			continue
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		for _, p := range patterns {
+			if p.match(relPath, isDir) {
+				ignored = !p.negate
+			}
+		}
+	}
+
+	return ignored
+}
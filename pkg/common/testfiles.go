@@ -0,0 +1,107 @@
+// Package common 提供项目通用功能
+// 创建者：Done-0
+
+package common
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// testFilePatterns 测试文件的模式，语法与.gitignore相同
+var testFilePatterns = []string{
+	// Go语言测试文件
+	"**/*_test.go",
+
+	// Python测试文件
+	"**/test_*.py", "**/*_test.py", "**/tests/**/*.py", "**/testing/**/*.py", "**/pytest/**/*.py",
+
+	// JavaScript/TypeScript测试文件
+	"**/*.spec.js", "**/*.test.js", "**/__tests__/**/*.js", "**/test/**/*.js", "**/tests/**/*.js",
+	"**/*.spec.ts", "**/*.test.ts", "**/__tests__/**/*.ts", "**/test/**/*.ts", "**/tests/**/*.ts",
+	"**/*.spec.jsx", "**/*.test.jsx", "**/*.spec.tsx", "**/*.test.tsx", "**/cypress/**",
+
+	// Java测试文件
+	"**/src/test/**/*.java", "**/*Test.java", "**/*Tests.java", "**/*IT.java", "**/JUnit/**/*.java",
+
+	// C#测试文件
+	"**/*Test.cs", "**/*Tests.cs", "**/*.Tests/**/*.cs", "**/*.Test/**/*.cs",
+
+	// C/C++测试文件
+	"**/*_test.c", "**/*_test.cpp", "**/*_tests.c", "**/*_tests.cpp",
+	"**/test/**/**.c", "**/test/**/**.cpp", "**/tests/**/**.c", "**/tests/**/**.cpp",
+}
+
+// IsTestFile 判断文件是否为测试文件，路径相对于rootDir匹配
+func IsTestFile(path, rootDir string) bool {
+	return matchesAnyPattern(path, rootDir, testFilePatterns)
+}
+
+// TestSubject 根据测试文件的命名返回被测文件的文件名（不含扩展名）
+// 如 foo_test.go、test_foo.py、foo.spec.ts、FooTest.java 都返回 foo 或 Foo，无法从命名推断时返回空
+func TestSubject(path string) string {
+	base := filepath.Base(path)
+	name := strings.TrimSuffix(base, filepath.Ext(base))
+
+	switch NewLanguageDetector().DetectLanguage(path) {
+	case Go:
+		return cutSuffixes(name, "_test")
+	case Python:
+		if rest, ok := strings.CutPrefix(name, "test_"); ok {
+			return rest
+		}
+		return cutSuffixes(name, "_test")
+	case JavaScript, TypeScript:
+		if subject := cutSuffixes(name, ".test", ".spec"); subject != "" {
+			return subject
+		}
+		// Jest约定 __tests__ 目录中的文件与被测文件同名
+		if filepath.Base(filepath.Dir(path)) == "__tests__" {
+			return name
+		}
+	case Java, CSharp:
+		if subject := cutSuffixes(name, "Tests", "Test", "IT"); subject != "" {
+			return subject
+		}
+		if rest, ok := strings.CutPrefix(name, "Test"); ok && rest != "" && unicode.IsUpper(rune(rest[0])) {
+			return rest
+		}
+	case C, CPlusPlus:
+		if rest, ok := strings.CutPrefix(name, "test_"); ok {
+			return rest
+		}
+		return cutSuffixes(name, "_tests", "_test")
+	}
+	return ""
+}
+
+// SuggestTestFile 返回按各语言惯例为源文件命名的测试文件名，如 foo.go 返回 foo_test.go
+func SuggestTestFile(path string) string {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	name := strings.TrimSuffix(base, ext)
+
+	switch NewLanguageDetector().DetectLanguage(path) {
+	case Python:
+		return "test_" + base
+	case JavaScript, TypeScript:
+		return name + ".test" + ext
+	case Java:
+		return name + "Test" + ext
+	case CSharp:
+		return name + "Tests" + ext
+	default:
+		return name + "_test" + ext
+	}
+}
+
+// cutSuffixes 去掉第一个匹配的后缀，去掉后为空或都不匹配时返回空
+func cutSuffixes(name string, suffixes ...string) string {
+	for _, suffix := range suffixes {
+		if rest, ok := strings.CutSuffix(name, suffix); ok && rest != "" {
+			return rest
+		}
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+// Package config 提供项目配置文件的查找和加载
+// 创建者：Done-0
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Done-0/fuck-u-code/pkg/metrics"
+	"github.com/Done-0/fuck-u-code/pkg/rules"
+)
+
+// FileName 项目配置文件名
+const FileName = ".fuckucode.json"
+
+// Config 项目配置
+type Config struct {
+	Rules  []rules.Rule                 `json:"rules"`  // 自定义规则
+	Naming map[string]map[string]string `json:"naming"` // 按语言和标识符类别覆盖的命名风格
+	Debt   DebtConfig                   `json:"debt"`   // 技术债标记
+
+	dir string // 配置文件所在目录
+}
+
+// DebtConfig 技术债标记配置
+type DebtConfig struct {
+	TicketPattern string `json:"ticket_pattern"` // 识别工单号的正则表达式，为空时使用默认格式
+}
+
+// Load 加载指定路径的配置文件，未知字段视为错误以便发现拼写问题
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件 %s 失败: %w", path, err)
+	}
+
+	cfg := &Config{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+	}
+
+	cfg.dir = filepath.Dir(path)
+	return cfg, nil
+}
+
+// Find 从startDir开始逐级向上查找配置文件，找不到时返回空字符串
+func Find(startDir string) string {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		candidate := filepath.Join(dir, FileName)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Dir 返回配置文件所在目录，路径模式相对于该目录匹配
+func (c *Config) Dir() string {
+	return c.dir
+}
+
+// RuleSet 编译配置中的自定义规则
+func (c *Config) RuleSet() (*rules.Set, error) {
+	return rules.Compile(c.dir, c.Rules)
+}
+
+// NamingStyles 解析配置中的命名风格
+func (c *Config) NamingStyles() (metrics.NamingStyles, error) {
+	return metrics.ParseNamingStyles(c.Naming)
+}
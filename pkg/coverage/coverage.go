@@ -0,0 +1,255 @@
+// Package coverage 读取测试覆盖率文件，支持Go coverprofile、LCOV、Cobertura XML和JaCoCo XML
+// 创建者：Done-0
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// File 单个源文件的行覆盖率
+type File struct {
+	Path  string      // 覆盖率文件中记录的路径
+	Lines map[int]int // 可执行行号到执行次数
+}
+
+// Ratio 返回指定行范围内的行覆盖率，0-1
+// 文件没有任何可执行行时表示文件完全未被测试，返回0；范围内没有可执行行时返回false
+func (f *File) Ratio(start, end int) (float64, bool) {
+	if f == nil {
+		return 0, false
+	}
+	if len(f.Lines) == 0 {
+		return 0, true
+	}
+
+	covered, total := 0, 0
+	for line, hits := range f.Lines {
+		if line < start || line > end {
+			continue
+		}
+		total++
+		if hits > 0 {
+			covered++
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(covered) / float64(total), true
+}
+
+// Total 返回整个文件的行覆盖率
+func (f *File) Total() (float64, bool) {
+	return f.Ratio(0, math.MaxInt)
+}
+
+// Profile 合并后的覆盖率数据
+type Profile struct {
+	files  map[string]*File   // 按规范化路径索引
+	byBase map[string][]*File // 按文件名索引，用于路径后缀匹配
+	exts   map[string]bool    // 覆盖率中出现过的文件扩展名
+
+	mu      sync.Mutex
+	modules map[string]string // 目录到所属Go模块路径的缓存
+}
+
+// NewProfile 创建空的覆盖率数据
+func NewProfile() *Profile {
+	return &Profile{
+		files:   make(map[string]*File),
+		byBase:  make(map[string][]*File),
+		exts:    make(map[string]bool),
+		modules: make(map[string]string),
+	}
+}
+
+// Load 读取并合并多个覆盖率文件，格式按内容自动识别
+func Load(paths ...string) (*Profile, error) {
+	profile := NewProfile()
+	for _, p := range paths {
+		if err := profile.LoadFile(p); err != nil {
+			return nil, err
+		}
+	}
+	return profile, nil
+}
+
+// LoadFile 读取覆盖率文件并合并到当前数据中
+func (p *Profile) LoadFile(filePath string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("读取覆盖率文件 %s 失败: %w", filePath, err)
+	}
+	if err := p.Parse(content); err != nil {
+		return fmt.Errorf("解析覆盖率文件 %s 失败: %w", filePath, err)
+	}
+	return nil
+}
+
+// Parse 解析覆盖率内容并合并到当前数据中
+func (p *Profile) Parse(content []byte) error {
+	trimmed := bytes.TrimSpace(content)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("mode:")):
+		return p.parseGo(content)
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		return p.parseXML(content)
+	case isLCOV(content):
+		return p.parseLCOV(content)
+	default:
+		return fmt.Errorf("无法识别的覆盖率格式，支持Go coverprofile、LCOV、Cobertura XML和JaCoCo XML")
+	}
+}
+
+// Len 返回覆盖率中的文件数
+func (p *Profile) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.files)
+}
+
+// addLine 记录一行的执行次数，同一行多次出现时取最大值
+func (p *Profile) addLine(filePath string, line, hits int) {
+	if line <= 0 {
+		return
+	}
+	file := p.file(filePath)
+	if old, ok := file.Lines[line]; !ok || hits > old {
+		file.Lines[line] = hits
+	}
+}
+
+// file 返回路径对应的文件，不存在时创建
+func (p *Profile) file(filePath string) *File {
+	key := normalizePath(filePath)
+	if file, ok := p.files[key]; ok {
+		return file
+	}
+
+	file := &File{Path: key, Lines: make(map[int]int)}
+	p.files[key] = file
+	base := path.Base(key)
+	p.byBase[base] = append(p.byBase[base], file)
+	p.exts[strings.ToLower(path.Ext(key))] = true
+	return file
+}
+
+// Covers 判断覆盖率中是否有与该文件相同扩展名的文件
+// 只有这时才能把覆盖率中缺失的文件视为未测试，否则可能只是没有收集该语言的覆盖率
+func (p *Profile) Covers(filePath string) bool {
+	if p == nil {
+		return false
+	}
+	return p.exts[strings.ToLower(filepath.Ext(filePath))]
+}
+
+// Lookup 查找源文件的覆盖率
+// 依次按绝对路径、Go导入路径和路径后缀匹配，后缀至少要匹配到上一级目录，多个文件同样匹配时视为找不到
+func (p *Profile) Lookup(filePath string) (*File, bool) {
+	if p == nil {
+		return nil, false
+	}
+
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		absPath = filePath
+	}
+	key := normalizePath(absPath)
+	if file, ok := p.files[key]; ok {
+		return file, true
+	}
+	if strings.HasSuffix(key, ".go") {
+		if importPath, ok := p.goImportPath(absPath); ok {
+			if file, ok := p.files[importPath]; ok {
+				return file, true
+			}
+		}
+	}
+
+	segments := strings.Split(key, "/")
+	var best *File
+	bestScore, tie := 0, false
+	for _, file := range p.byBase[path.Base(key)] {
+		candidate := strings.Split(file.Path, "/")
+		score := commonSuffix(segments, candidate)
+		if score < 2 && score < len(candidate) {
+			continue
+		}
+		switch {
+		case score > bestScore:
+			best, bestScore, tie = file, score, false
+		case score == bestScore:
+			tie = true
+		}
+	}
+	if best == nil || tie {
+		return nil, false
+	}
+	return best, true
+}
+
+// commonSuffix 返回两个路径末尾相同的段数
+func commonSuffix(a, b []string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[len(a)-1-n] == b[len(b)-1-n] {
+		n++
+	}
+	return n
+}
+
+// goImportPath 根据最近的go.mod计算Go源文件的导入路径，如 github.com/a/b/pkg/x.go
+func (p *Profile) goImportPath(absPath string) (string, bool) {
+	dir := filepath.Dir(absPath)
+	for current := dir; ; {
+		if module, ok := p.moduleOf(current); ok {
+			rel, err := filepath.Rel(current, absPath)
+			if err != nil {
+				return "", false
+			}
+			return path.Join(module, filepath.ToSlash(rel)), true
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", false
+		}
+		current = parent
+	}
+}
+
+// moduleOf 读取目录下go.mod声明的模块路径，结果会被缓存
+func (p *Profile) moduleOf(dir string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if module, ok := p.modules[dir]; ok {
+		return module, module != ""
+	}
+
+	module := ""
+	if content, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for scanner.Scan() {
+			if rest, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module"); ok {
+				module = strings.Trim(strings.TrimSpace(rest), `"`)
+				break
+			}
+		}
+	}
+	p.modules[dir] = module
+	return module, module != ""
+}
+
+// normalizePath 统一路径分隔符并清理路径
+func normalizePath(filePath string) string {
+	filePath = strings.ReplaceAll(filePath, `\`, "/")
+	return strings.TrimPrefix(path.Clean(filePath), "./")
+}
@@ -0,0 +1,202 @@
+// Package coverage 读取测试覆盖率文件，支持Go coverprofile、LCOV、Cobertura XML和JaCoCo XML
+// 创建者：Done-0
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// parseGo 解析Go coverprofile
+// 每行格式为 "文件:起始行.列,结束行.列 语句数 执行次数"，块内的每一行都记为可执行行
+func (p *Profile) parseGo(content []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+
+		colon := strings.LastIndex(line, ":")
+		fields := strings.Fields(line[colon+1:])
+		if colon < 0 || len(fields) != 3 {
+			return fmt.Errorf("第 %d 行格式错误: %s", lineNo, line)
+		}
+		start, end, ok := parseGoBlock(fields[0])
+		statements, err1 := strconv.Atoi(fields[1])
+		count, err2 := strconv.Atoi(fields[2])
+		if !ok || err1 != nil || err2 != nil {
+			return fmt.Errorf("第 %d 行格式错误: %s", lineNo, line)
+		}
+		if statements == 0 {
+			continue
+		}
+
+		file := line[:colon]
+		for l := start; l <= end; l++ {
+			p.addLine(file, l, count)
+		}
+	}
+	return scanner.Err()
+}
+
+// parseGoBlock 解析 "起始行.列,结束行.列"，返回起止行号
+func parseGoBlock(block string) (int, int, bool) {
+	from, to, ok := strings.Cut(block, ",")
+	if !ok {
+		return 0, 0, false
+	}
+	fromLine, _, _ := strings.Cut(from, ".")
+	toLine, _, _ := strings.Cut(to, ".")
+	start, err1 := strconv.Atoi(fromLine)
+	end, err2 := strconv.Atoi(toLine)
+	if err1 != nil || err2 != nil {
+		return 0, 0, false
+	}
+	return start, end, true
+}
+
+// isLCOV 判断内容是否为LCOV格式
+func isLCOV(content []byte) bool {
+	for _, line := range bytes.Split(content, []byte("\n")) {
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte("SF:")) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseLCOV 解析LCOV格式，SF记录源文件，DA记录 "行号,执行次数"
+func (p *Profile) parseLCOV(content []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	current := ""
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			current = strings.TrimPrefix(line, "SF:")
+			p.file(current)
+		case strings.HasPrefix(line, "DA:") && current != "":
+			fields := strings.Split(strings.TrimPrefix(line, "DA:"), ",")
+			if len(fields) < 2 {
+				return fmt.Errorf("第 %d 行格式错误: %s", lineNo, line)
+			}
+			number, err1 := strconv.Atoi(fields[0])
+			hits, err2 := strconv.Atoi(fields[1])
+			if err1 != nil || err2 != nil {
+				return fmt.Errorf("第 %d 行格式错误: %s", lineNo, line)
+			}
+			p.addLine(current, number, hits)
+		case line == "end_of_record":
+			current = ""
+		}
+	}
+	return scanner.Err()
+}
+
+// coberturaReport Cobertura XML报告
+type coberturaReport struct {
+	Sources []string         `xml:"sources>source"`
+	Classes []coberturaClass `xml:"packages>package>classes>class"`
+}
+
+// coberturaClass Cobertura中的类，对应一个源文件
+type coberturaClass struct {
+	Filename string `xml:"filename,attr"`
+	Lines    []struct {
+		Number int `xml:"number,attr"`
+		Hits   int `xml:"hits,attr"`
+	} `xml:"lines>line"`
+}
+
+// jacocoGroup JaCoCo XML报告及其中的分组，分组可以嵌套
+type jacocoGroup struct {
+	Groups   []jacocoGroup `xml:"group"`
+	Packages []struct {
+		Name        string `xml:"name,attr"`
+		SourceFiles []struct {
+			Name  string `xml:"name,attr"`
+			Lines []struct {
+				Number        int `xml:"nr,attr"`
+				CoveredInstrs int `xml:"ci,attr"`
+			} `xml:"line"`
+		} `xml:"sourcefile"`
+	} `xml:"package"`
+}
+
+// parseXML 按根元素区分Cobertura（coverage）和JaCoCo（report）
+func (p *Profile) parseXML(content []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("解析XML失败: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "coverage":
+			var report coberturaReport
+			if err := decoder.DecodeElement(&report, &start); err != nil {
+				return fmt.Errorf("解析Cobertura报告失败: %w", err)
+			}
+			p.addCobertura(report)
+			return nil
+		case "report":
+			var report jacocoGroup
+			if err := decoder.DecodeElement(&report, &start); err != nil {
+				return fmt.Errorf("解析JaCoCo报告失败: %w", err)
+			}
+			p.addJaCoCo(report)
+			return nil
+		default:
+			return fmt.Errorf("不支持的XML覆盖率格式: <%s>", start.Name.Local)
+		}
+	}
+}
+
+// addCobertura 合并Cobertura报告，相对路径以第一个source目录为基准
+func (p *Profile) addCobertura(report coberturaReport) {
+	source := ""
+	if len(report.Sources) > 0 {
+		source = strings.TrimSpace(report.Sources[0])
+	}
+	for _, class := range report.Classes {
+		filePath := normalizePath(class.Filename)
+		if source != "" && !path.IsAbs(filePath) {
+			filePath = path.Join(normalizePath(source), filePath)
+		}
+		p.file(filePath)
+		for _, line := range class.Lines {
+			p.addLine(filePath, line.Number, line.Hits)
+		}
+	}
+}
+
+// addJaCoCo 合并JaCoCo报告，源文件路径由包名和文件名组成，如 com/example/App.java
+func (p *Profile) addJaCoCo(group jacocoGroup) {
+	for _, sub := range group.Groups {
+		p.addJaCoCo(sub)
+	}
+	for _, pkg := range group.Packages {
+		for _, source := range pkg.SourceFiles {
+			filePath := path.Join(pkg.Name, source.Name)
+			p.file(filePath)
+			for _, line := range source.Lines {
+				p.addLine(filePath, line.Number, line.CoveredInstrs)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Package debt 提取注释中的技术债标记，如 TODO、FIXME、HACK、XXX 和 @deprecated
+// 创建者：Done-0
+package debt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Blame 读取git blame，返回文件每一行最后修改的时间
+// 尚未提交的行没有时间，文件不在git仓库中时返回错误
+func Blame(ctx context.Context, path string) (map[int]time.Time, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", filepath.Dir(path), "blame", "--line-porcelain", "--", filepath.Base(path))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("读取 %s 的git blame失败: %w: %s", path, err, strings.TrimSpace(stderr.String()))
+	}
+	return parseBlame(output), nil
+}
+
+// parseBlame 解析 git blame --line-porcelain 的输出
+// 每行源码前有一段头信息，第一行为 "提交 原行号 现行号 [行数]"，其中 author-time 为提交时间
+func parseBlame(output []byte) map[int]time.Time {
+	dates := make(map[int]time.Time)
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	line, uncommitted := 0, false
+	for scanner.Scan() {
+		text := scanner.Text()
+		switch {
+		case strings.HasPrefix(text, "\t"):
+			// 源码行，头信息结束
+			line = 0
+		case line == 0:
+			fields := strings.Fields(text)
+			if len(fields) >= 3 {
+				line, _ = strconv.Atoi(fields[2])
+				uncommitted = strings.Trim(fields[0], "0") == ""
+			}
+		case strings.HasPrefix(text, "author-time ") && !uncommitted:
+			if seconds, err := strconv.ParseInt(strings.TrimPrefix(text, "author-time "), 10, 64); err == nil {
+				dates[line] = time.Unix(seconds, 0)
+			}
+		}
+	}
+	return dates
+}
+
+// ApplyBlame 按文件读取git blame，为标记设置最后修改时间
+// 读取失败的文件（如不在git仓库中）跳过，返回第一个错误
+func ApplyBlame(ctx context.Context, markers []Marker) error {
+	var firstErr error
+	byFile := make(map[string][]int)
+	for i, marker := range markers {
+		byFile[marker.File] = append(byFile[marker.File], i)
+	}
+
+	for file, indexes := range byFile {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		dates, err := Blame(ctx, file)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		for _, i := range indexes {
+			markers[i].Date = dates[markers[i].Line]
+		}
+	}
+	return firstErr
+}
+
+// Oldest 返回最后修改时间最早的n个标记，没有时间的标记不参与排序
+func Oldest(markers []Marker, n int) []Marker {
+	var dated []Marker
+	for _, marker := range markers {
+		if !marker.Date.IsZero() {
+			dated = append(dated, marker)
+		}
+	}
+	sort.SliceStable(dated, func(i, j int) bool {
+		return dated[i].Date.Before(dated[j].Date)
+	})
+	if len(dated) > n {
+		dated = dated[:n]
+	}
+	return dated
+}
@@ -0,0 +1,109 @@
+// Package debt 提取注释中的技术债标记，如 TODO、FIXME、HACK、XXX 和 @deprecated
+// 创建者：Done-0
+package debt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Done-0/fuck-u-code/pkg/parser"
+)
+
+// Kind 技术债标记类型
+type Kind string
+
+// 技术债标记类型
+const (
+	Todo       Kind = "TODO"
+	Fixme      Kind = "FIXME"
+	Hack       Kind = "HACK"
+	XXX        Kind = "XXX"
+	Deprecated Kind = "DEPRECATED"
+)
+
+// Kinds 所有标记类型，按严重程度从高到低排列
+var Kinds = []Kind{Fixme, Hack, XXX, Todo, Deprecated}
+
+// DefaultTicketPattern 默认的工单号格式：JIRA风格的 ABC-123、GitHub风格的 #123 和 issue/PR 链接
+const DefaultTicketPattern = `\b[A-Z][A-Z0-9]+-\d+\b|(?:^|\s|\()#\d+\b|https?://\S+/(?:issues|pull|merge_requests)/\d+`
+
+// Marker 注释中的一个技术债标记
+type Marker struct {
+	File   string    // 文件路径
+	Line   int       // 所在行
+	Kind   Kind      // 标记类型
+	Author string    // 标记中署名的负责人，如 TODO(alice)
+	Ticket string    // 关联的工单号，没有关联时为空
+	Text   string    // 标记后的说明
+	Date   time.Time // 所在行最后修改的时间，只在读取git blame后设置
+}
+
+// Tracked 判断标记是否关联了工单
+func (m Marker) Tracked() bool {
+	return m.Ticket != ""
+}
+
+// markerPattern 匹配注释行开头的标记，支持 TODO(作者)、TODO @作者 和 @deprecated、Deprecated: 写法
+var markerPattern = regexp.MustCompile(`^[-*/#!\s]*(?:(TODO|FIXME|HACK|XXX)\b(?:\(([^)]*)\))?|(@deprecated|Deprecated:))\s*(?:@([\w.-]+))?[\s:：,-]*(.*)$`)
+
+// Scanner 从注释中提取技术债标记
+type Scanner struct {
+	ticket *regexp.Regexp
+}
+
+// NewScanner 创建标记扫描器，ticketPattern为识别工单号的正则表达式，为空时使用DefaultTicketPattern
+func NewScanner(ticketPattern string) (*Scanner, error) {
+	if ticketPattern == "" {
+		ticketPattern = DefaultTicketPattern
+	}
+	ticket, err := regexp.Compile(ticketPattern)
+	if err != nil {
+		return nil, fmt.Errorf("工单号正则表达式 %q 无效: %w", ticketPattern, err)
+	}
+	return &Scanner{ticket: ticket}, nil
+}
+
+// Scan 提取注释中的标记，每行最多一个标记
+func (s *Scanner) Scan(file string, comments []parser.Comment) []Marker {
+	var markers []Marker
+	for _, comment := range comments {
+		for i, line := range comment.Lines() {
+			if marker, ok := s.parseLine(line); ok {
+				marker.File = file
+				marker.Line = comment.StartLine + i
+				markers = append(markers, marker)
+			}
+		}
+	}
+	return markers
+}
+
+// parseLine 解析一行注释，行首不是标记时返回false
+func (s *Scanner) parseLine(line string) (Marker, bool) {
+	match := markerPattern.FindStringSubmatch(line)
+	if match == nil {
+		return Marker{}, false
+	}
+
+	marker := Marker{Kind: Kind(match[1]), Text: strings.TrimSpace(match[5])}
+	if match[3] != "" {
+		marker.Kind = Deprecated
+	}
+
+	// 括号中可以是作者也可以是工单号
+	owner := strings.TrimPrefix(strings.TrimSpace(match[2]), "@")
+	if owner != "" && s.ticket.MatchString(owner) {
+		marker.Ticket = owner
+	} else {
+		marker.Author = owner
+	}
+	if match[4] != "" {
+		marker.Author = match[4]
+	}
+	if marker.Ticket == "" {
+		marker.Ticket = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s.ticket.FindString(marker.Text)), "("))
+	}
+	return marker, true
+}
@@ -76,6 +76,18 @@ func (t *DefaultTranslator) loadMessages() {
 	}
 }
 
+// RegisterMessages 为指定语言添加或覆盖翻译，供自定义指标等扩展使用，应在分析开始前调用
+func RegisterMessages(language Language, messages map[string]string) {
+	target := enUSMessages
+	if language == ZhCN {
+		target = zhCNMessages
+	}
+
+	for key, msg := range messages {
+		target[key] = msg
+	}
+}
+
 // FormatKey 格式化翻译键，将多个部分组合成一个键
 func FormatKey(parts ...string) string {
 	return strings.Join(parts, ".")
@@ -89,12 +101,20 @@ var zhCNMessages = map[string]string{
 
 	// 指标名称
 	"metric.cyclomatic_complexity": "循环复杂度",
-	"metric.function_length":       "状态管理",
+	"metric.cognitive_complexity":  "认知复杂度",
+	"metric.maintainability_index": "可维护性指数",
+	"metric.function_length":       "函数长度",
+	"metric.state_management":      "状态管理",
+	"metric.class_cohesion":        "类内聚度",
+	"metric.untested_complexity":   "未测试复杂度",
+	"metric.test_presence":         "测试完备度",
+	"metric.debt_markers":          "技术债标记",
 	"metric.comment_ratio":         "注释覆盖率",
 	"metric.error_handling":        "错误处理",
 	"metric.naming_convention":     "命名规范",
 	"metric.code_duplication":      "代码重复度",
 	"metric.structure_analysis":    "代码结构",
+	"metric.custom_rules":          "自定义规则",
 
 	// 分析器进度
 	"analyzer.searching_files":   "正在搜索源代码文件...",
@@ -102,8 +122,71 @@ var zhCNMessages = map[string]string{
 	"analyzer.analyzing_files":   "正在分析文件...",
 	"analyzer.analysis_complete": "分析完成",
 
+	// 最差函数
+	"report.functions":                 "最差函数",
+	"report.functions.rank.complexity": "按圈复杂度",
+	"report.functions.rank.length":     "按函数行数",
+	"report.functions.rank.params":     "按参数数量",
+	"report.functions.rank.mi":         "按可维护性指数",
+	"report.functions.rank.crap":       "按CRAP风险分",
+	"report.functions.name":            "函数",
+	"report.functions.location":        "位置",
+	"report.functions.complexity":      "复杂度",
+	"report.functions.lines":           "行数",
+	"report.functions.params":          "参数",
+	"report.functions.mi":              "可维护性",
+	"report.functions.coverage":        "覆盖率",
+	"report.functions.crap":            "CRAP",
+	"report.functions.none":            "未识别到函数",
+
+	// 包耦合度
+	"report.coupling":              "包耦合度",
+	"report.coupling.legend":       "Ca 传入耦合，Ce 传出耦合，I 不稳定性，A 抽象度，D 与主序列的距离；按 D 从大到小排列",
+	"report.coupling.package":      "包",
+	"report.coupling.fan_in":       "扇入",
+	"report.coupling.fan_out":      "扇出",
+	"report.coupling.zone_of_pain": "(痛苦区)",
+
+	// 测试情况
+	"report.tests":               "测试情况",
+	"report.tests.summary":       "测试文件 %d 个，共 %d 行；生产代码 %d 行，测试代码比 %.2f；没有对应测试的生产文件 %d 个",
+	"report.tests.package":       "包",
+	"report.tests.production":    "生产文件",
+	"report.tests.files":         "测试文件",
+	"report.tests.untested":      "未测试",
+	"report.tests.ratio":         "测试代码比",
+	"report.tests.no_assertions": "没有断言的测试文件",
+	"report.tests.quality":       "测试代码质量",
+
+	// 技术债
+	"report.debt":           "技术债",
+	"report.debt.summary":   "共 %d 个标记，其中 %d 个没有关联工单（%s）",
+	"report.debt.file":      "文件",
+	"report.debt.markers":   "标记",
+	"report.debt.untracked": "无工单",
+	"report.debt.oldest":    "最老的标记",
+
 	// 问题分类
 	"report.no_issues":           "恭喜！没有特别多问题的文件！",
+	"report.suppressed":          "已抑制的问题",
+	"report.suppressed.total":    "共 %d 个问题被抑制注释忽略，不计入评分",
+	"report.suppressed.count":    "抑制数量",
+	"report.directories":         "目录评分",
+	"report.directory.summary":   "%d 个文件, %d 行, %d 个问题",
+	"report.directory.worst":     "最差文件",
+	"report.aggregation":         "汇总方式",
+	"aggregation.loc":            "按代码行数加权平均",
+	"aggregation.functions":      "按函数数量加权平均",
+	"aggregation.p90":            "第90百分位",
+	"aggregation.max":            "最差文件",
+	"report.workspace.overview":  "子项目概览",
+	"report.workspace.count":     "共 %d 个子项目，按得分从差到好排列",
+	"report.workspace.project":   "项目",
+	"report.workspace.kind":      "类型",
+	"report.workspace.path":      "路径",
+	"report.workspace.files":     "文件",
+	"report.workspace.lines":     "行数",
+	"report.workspace.issues":    "问题",
 	"issue.category.complexity":  "复杂度问题",
 	"issue.category.comment":     "注释问题",
 	"issue.category.naming":      "命名问题",
@@ -111,6 +194,7 @@ var zhCNMessages = map[string]string{
 	"issue.category.duplication": "重复问题",
 	"issue.category.error":       "错误处理问题",
 	"issue.category.other":       "其他问题",
+	"issue.category.debt":        "技术债",
 
 	// 质量等级
 	"level.clean":             "清新可人",
@@ -126,10 +210,41 @@ var zhCNMessages = map[string]string{
 	"level.disaster.ultimate": "终极屎王",
 
 	// 命令行
-	"cmd.short":                      "💻 fuck-u-code",
-	"cmd.long":                       "🔍 屎山代码检测器 - 客观评估您的代码质量\n\n它可以分析代码质量、输出评分，帮助您发现代码中的💩。适用于：\n- 项目重构前的质量评估\n- 团队代码审查辅助工具\n- 学习编程最佳实践",
-	"cmd.analyze":                    "分析代码质量并输出评分",
-	"cmd.analyze.long":               "深入分析代码库，检测各种代码潜在问题，输出质量报告。不指定路径时分析当前目录。",
+	"cmd.short":             "💻 fuck-u-code",
+	"cmd.long":              "🔍 屎山代码检测器 - 客观评估您的代码质量\n\n它可以分析代码质量、输出评分，帮助您发现代码中的💩。适用于：\n- 项目重构前的质量评估\n- 团队代码审查辅助工具\n- 学习编程最佳实践",
+	"cmd.analyze":           "分析代码质量并输出评分",
+	"cmd.analyze.long":      "深入分析代码库，检测各种代码潜在问题，输出质量报告。不指定路径时分析当前目录。",
+	"cmd.lsp":               "以语言服务器(LSP)模式运行",
+	"cmd.metrics":           "管理代码质量指标",
+	"cmd.metrics.long":      "查看已注册的代码质量指标。分析时可通过 --enable-metric 和 --disable-metric 按指标键启用或禁用指标。",
+	"cmd.metrics.list":      "列出所有已注册的指标",
+	"cmd.enable_metric":     "按指标键启用指标 (可多次使用，见 metrics list)",
+	"cmd.disable_metric":    "按指标键禁用指标 (可多次使用，见 metrics list)",
+	"cmd.config":            "项目配置文件路径，默认从分析路径向上查找 .fuckucode.json",
+	"cmd.no_ignore":         "不读取 .gitignore 和 .fuckucodeignore，只使用默认排除和 --exclude",
+	"cmd.include_generated": "分析生成代码、压缩代码和数据文件（默认按内容识别并跳过）",
+	"cmd.coverage":          "测试覆盖率文件，支持Go coverprofile、LCOV、Cobertura XML和JaCoCo XML (可多次使用)",
+	"cmd.coverage_failed":   "加载覆盖率失败：%v",
+	"cmd.test_report":       "单独评估测试代码的质量（测试文件始终不计入生产代码评分）",
+
+	// 技术债命令
+	"cmd.blame":          "读取 git blame 获取技术债标记的引入时间，列出最老的标记",
+	"cmd.debt":           "列出代码中的技术债标记",
+	"cmd.debt.long":      "列出注释中的 TODO、FIXME、HACK、XXX 和 @deprecated 标记及其负责人、工单号和说明，可导出为 JSON 或 CSV。不指定路径时扫描当前目录，测试文件也会扫描。",
+	"cmd.debt.format":    "输出格式：text、json、csv",
+	"cmd.ticket_pattern": "识别工单号的正则表达式，覆盖项目配置中的 debt.ticket_pattern",
+	"cmd.debt_failed":    "查找技术债标记失败：%v",
+
+	// 跳过的文件
+	"skip.generated.header":          "文件头声明为生成代码",
+	"skip.generated.marker":          "包含 @generated 标记",
+	"skip.generated.protobuf":        "protobuf/gRPC 生成的代码",
+	"skip.generated.minified":        "压缩后的代码（行过长、空白过少）",
+	"skip.generated.data":            "数据或锁文件",
+	"verbose.skipped_files":          "跳过的文件",
+	"cmd.config_failed":              "加载配置失败：%v",
+	"cmd.lsp.long":                   "通过标准输入输出提供语言服务器协议，在编辑器中实时展示诊断信息、函数复杂度悬停提示和代码透镜。",
+	"cmd.lsp_failed":                 "语言服务器异常退出: %v",
 	"cmd.completion":                 "生成自动补全脚本",
 	"cmd.completion.long":            "为指定的shell生成自动补全脚本，支持bash、zsh、fish和PowerShell。",
 	"cmd.completion.long_prefix":     "为指定的shell生成fuck-u-code的自动补全脚本。",
@@ -148,14 +263,26 @@ var zhCNMessages = map[string]string{
 	"cmd.no_descriptions":            "禁用补全描述",
 	"cmd.path_not_found":             "路径不可访问 '%s': %v",
 	"cmd.analysis_failed":            "分析失败：%v",
+	"cmd.unknown_format":             "不支持的输出格式: %s",
+	"cmd.unknown_function_ranking":   "不支持的函数排序依据: %s（支持：complexity, length, params, mi, crap）",
+	"cmd.report_failed":              "生成报告失败：%v",
 	"cmd.lang":                       "指定输出语言（支持：zh-CN, en-US，默认：zh-CN）",
 	"cmd.verbose":                    "显示详细分析报告",
 	"cmd.top":                        "显示问题最多的文件数量（默认5个）",
+	"cmd.top_functions":              "显示最差函数的数量（默认10个，0表示不显示）",
+	"cmd.rank_functions":             "最差函数的排序依据（complexity：圈复杂度，length：行数，params：参数数量，mi：可维护性指数，crap：CRAP风险分，默认：complexity）",
 	"cmd.issues":                     "每个文件显示多少条问题（默认5个）",
 	"cmd.summary":                    "只看结论，过程略过",
 	"cmd.markdown":                   "输出Markdown格式的精简报告，便于AI工具处理",
+	"cmd.format":                     "输出格式（支持：console, markdown, html, json, junit, checkstyle，默认：console）",
+	"cmd.threshold":                  "JUnit报告中判定为失败的得分阈值（0-100，默认60）",
 	"cmd.exclude":                    "排除的文件/目录模式 (可多次使用，默认已排除常见依赖目录)",
 	"cmd.skipindex":                  "跳过所有 index.js/index.ts 文件",
+	"cmd.stdin_filename":             "从标准输入读取代码(路径为 - )时使用的文件名，用于识别语言",
+	"cmd.stdin_unsupported":          "无法从文件名 %q 识别语言，请使用 --stdin-filename 指定带扩展名的文件名",
+	"cmd.stdin_read_failed":          "读取标准输入失败：%v",
+	"cmd.aggregation":                "文件得分的汇总方式（支持：loc 按代码行数加权, functions 按函数数量加权, p90 第90百分位, max 最差文件，默认：loc）",
+	"cmd.workspace":                  "工作区模式，识别仓库中的子项目（go.mod、package.json 等）并分别评分",
 	"cmd.start_analyzing":            "开始嗅探：%s",
 	"cmd.exclude_patterns":           "排除以下文件/目录模式:",
 
@@ -195,6 +322,45 @@ var zhCNMessages = map[string]string{
 	"report.more_issues_short":       "个问题",
 	"report.improvement_suggestions": "改进建议",
 
+	// HTML报告
+	"report.html.treemap":       "目录矩形树图",
+	"report.html.treemap_hint":  "面积代表代码行数，颜色代表屎气指数（绿好红差），点击文件查看问题详情",
+	"report.html.file":          "文件",
+	"report.html.lines":         "行数",
+	"report.html.issues":        "问题数",
+	"report.html.comment":       "简评",
+	"report.html.sort_hint":     "点击表头可排序",
+	"report.html.issue_details": "文件问题详情",
+
+	// CI报告
+	"report.ci.threshold_exceeded": "得分 %.2f 超过阈值 %.2f",
+
+	// 指标列表
+	"metrics.list.key":           "指标键",
+	"metrics.list.name":          "名称",
+	"metrics.list.weight":        "默认权重",
+	"metrics.list.languages":     "支持语言",
+	"metrics.list.status":        "默认状态",
+	"metrics.list.all_languages": "全部",
+	"metrics.list.enabled":       "启用",
+	"metrics.list.disabled":      "禁用",
+
+	// 技术债标记列表
+	"debt.list.location": "位置",
+	"debt.list.kind":     "类型",
+	"debt.list.author":   "负责人",
+	"debt.list.ticket":   "工单",
+	"debt.list.date":     "引入时间",
+	"debt.list.text":     "说明",
+	"debt.list.total":    "共 %d 个标记，其中 %d 个没有关联工单",
+
+	// 语言服务器
+	"lsp.hover.function":   "函数",
+	"lsp.hover.complexity": "循环复杂度: %d",
+	"lsp.hover.length":     "长度: %d 行",
+	"lsp.hover.parameters": "参数: %d 个",
+	"lsp.lens.function":    "复杂度 %d · %d 行 · %d 个参数",
+
 	// 指标评分后缀
 	"metric.score.suffix": "分",
 
@@ -204,9 +370,34 @@ var zhCNMessages = map[string]string{
 	"metric.complexity.bad":    "函数像迷宫，维护像打副本",
 
 	// 函数长度评价
-	"metric.length.good":   "状态管理清晰，变量作用域合理，状态可预测",
-	"metric.length.medium": "状态管理一般，存在部分全局状态或状态变化不明确的情况",
-	"metric.length.bad":    "状态管理混乱，大量使用全局变量，状态变化难以追踪",
+	"metric.length.good":   "函数短小精悍，一屏就能看完",
+	"metric.length.medium": "函数有点长，得来回翻几屏",
+	"metric.length.bad":    "函数长得像裹脚布，又臭又长",
+
+	// 状态管理评价
+	"metric.state.good":   "状态管理清晰，变量作用域合理，状态可预测",
+	"metric.state.medium": "状态管理一般，存在部分全局状态或状态变化不明确的情况",
+	"metric.state.bad":    "状态管理混乱，大量使用全局变量，状态变化难以追踪",
+
+	// 类内聚度评价
+	"metric.cohesion.good":   "类职责单一，方法都围着同一组数据转",
+	"metric.cohesion.medium": "类有点杂，几块互不相干的职责挤在一起",
+	"metric.cohesion.bad":    "上帝类横行，一个类包办一切，改一处动全身",
+
+	// 未测试复杂度评价
+	"metric.coverage.good":   "复杂的地方都有测试兜底，改起来心里有数",
+	"metric.coverage.medium": "一部分复杂函数没人测，改动全凭运气",
+	"metric.coverage.bad":    "最绕的代码恰恰没有测试，每次改动都是在雷区蹦迪",
+
+	// 测试完备度评价
+	"metric.tests.good":   "代码基本都有测试陪着，改动有底气",
+	"metric.tests.medium": "不少文件没有测试，改的时候只能祈祷",
+	"metric.tests.bad":    "测试？不存在的，上线全靠运气",
+
+	// 技术债标记评价
+	"metric.debt.good":   "欠账不多，还得起",
+	"metric.debt.medium": "TODO越攒越多，总有一天要还",
+	"metric.debt.bad":    "满屏FIXME和HACK，利滚利的技术债",
 
 	// 注释覆盖率评价
 	"metric.comment.good":   "注释不错，能靠它活下来",
@@ -268,13 +459,25 @@ var zhCNMessages = map[string]string{
 	"advice.bad.style":           "建立并执行严格的代码规范",
 
 	// 指标描述
-	"metric.function_length.description":       "检测代码中状态变量的管理，良好的状态管理能提高代码可维护性和可预测性",
+	"metric.maintainability_index.description": "综合Halstead体积、圈复杂度和代码行数计算可维护性指数（MI），低于20的函数难以维护",
+	"metric.function_length.description":       "检测函数的代码行数，过长的函数难以理解和测试",
+	"metric.state_management.description":      "检测全局可变变量、静态可变字段和对全局状态的修改，良好的状态管理能提高代码可维护性和可预测性",
+	"metric.class_cohesion.description":        "按类统计方法数、字段数、总复杂度和LCOM4内聚度，识别上帝类、低内聚类和数据类",
+	"metric.untested_complexity.description":   "结合测试覆盖率和圈复杂度计算CRAP风险分，找出复杂却缺少测试的函数，需要通过 --coverage 提供覆盖率文件",
+	"metric.test_presence.description":         "按命名和包将测试文件与生产文件匹配，找出没有测试的文件和没有断言的测试，只在分析目录时计算",
+	"metric.debt_markers.description":          "统计注释中的 TODO、FIXME、HACK、XXX 和 @deprecated 标记，没有关联工单的标记扣分加倍",
 	"metric.comment_ratio.description":         "检测代码的注释覆盖率，良好的注释能提高代码可读性和可维护性",
 	"metric.error_handling.description":        "检测代码中的错误处理情况，良好的错误处理能提高代码的健壮性",
-	"metric.naming_convention.description":     "检测代码中的命名规范，良好的命名能提高代码可读性",
+	"metric.naming_convention.description":     "检查代码中的命名是否符合所用语言的规范，包括包名、类型名、函数名、变量名和常量名",
 	"metric.code_duplication.description":      "评估代码中重复逻辑的比例，重复代码越多，越需要抽象和重构",
 	"metric.structure_analysis.description":    "检测代码的嵌套深度和引用复杂度，评估结构清晰度",
 	"metric.cyclomatic_complexity.description": "测量函数的控制流复杂度，复杂度越高，代码越难理解和测试",
+	"metric.cognitive_complexity.description":  "测量函数读起来有多费劲，嵌套越深、流程跳转越多、逻辑运算混用越多，得分越高",
+	"metric.custom_rules.description":          "检查项目配置中声明的团队自定义规则",
+	"metric.custom_rules.issue":                "[%s] %s (规则 %s，%s，行 %d)",
+	"rules.severity.error":                     "严重",
+	"rules.severity.warning":                   "警告",
+	"rules.severity.info":                      "提示",
 
 	// 质量等级描述
 	"level.clean.description":             "代码洁净，令人赏心悦目",
@@ -302,11 +505,14 @@ var zhCNMessages = map[string]string{
 	"score.comment.90": "厄难级毒瘤，看一眼减寿十年",
 
 	// 错误消息
-	"error.path_not_accessible":    "无法访问路径: %v",
-	"error.file_read_failed":       "读取文件 %s 失败: %v",
-	"error.code_parse_failed":      "解析代码 %s 失败: %v",
-	"error.source_files_not_found": "查找源文件失败: %v",
-	"error.file_analysis_failed":   "分析文件 %s 失败: %v",
+	"error.path_not_accessible":     "无法访问路径: %v",
+	"error.workspace_not_directory": "工作区模式需要分析目录: %s",
+	"error.file_read_failed":        "读取文件 %s 失败: %v",
+	"error.code_parse_failed":       "解析代码 %s 失败: %v",
+	"error.source_files_not_found":  "查找源文件失败: %v",
+	"error.file_analysis_failed":    "分析文件 %s 失败: %v",
+	"error.unknown_metric":          "未知的指标: %s",
+	"error.unknown_aggregation":     "未知的汇总方式: %s（支持：loc, functions, p90, max）",
 
 	// 警告和提示
 	"warning.format": "警告: %v\n",
@@ -317,6 +523,47 @@ var zhCNMessages = map[string]string{
 	"issue.file_high_complexity":   "文件循环复杂度过高 (%d)，建议拆分为多个文件",
 	"issue.file_medium_complexity": "文件循环复杂度较高 (%d)，建议优化",
 
+	// 认知复杂度问题
+	"issue.high_cognitive_complexity":   "函数 '%s' (行 %d) 的认知复杂度极高 (%d)，嵌套和跳转过多，必须拆分",
+	"issue.medium_cognitive_complexity": "函数 '%s' (行 %d) 的认知复杂度过高 (%d)，建议减少嵌套",
+
+	// 可维护性问题
+	"issue.critical_maintainability": "函数 '%s' (行 %d) 的可维护性指数极低 (%.1f，Halstead体积 %.0f)，几乎无法维护",
+	"issue.low_maintainability":      "函数 '%s' (行 %d) 的可维护性指数偏低 (%.1f，Halstead体积 %.0f)，建议重构",
+
+	// 状态管理问题
+	"issue.global_variable":      "全局可变变量 '%s' (行 %d) 可能导致状态难以追踪",
+	"issue.global_write":         "函数 '%s' (行 %d) 修改了全局变量 '%s'",
+	"issue.static_mutable_field": "静态可变字段 '%s' (行 %d) 在所有实例间共享状态",
+
+	// 类内聚度问题
+	"issue.god_class":    "[structure] 类 '%s' (行 %d) 是上帝类：%d 个方法，%d 个字段，总复杂度 %d，共 %d 行，建议按职责拆分",
+	"issue.low_cohesion": "[structure] 类 '%s' (行 %d) 内聚度低 (LCOM4=%d)，方法分成几组互不共享字段和调用的职责，可以拆分",
+	"issue.data_class":   "[structure] 类 '%s' (行 %d) 是数据类：%d 个字段只有存取方法，相关行为可能散落在其他类中",
+
+	// 包耦合度问题
+	"issue.zone_of_pain": "[structure] 包 '%s' 处于痛苦区 (Ca=%d, I=%.2f, A=%.2f, D=%.2f)：被多个包依赖却稳定且具体，难以修改，建议提取接口",
+
+	// 未测试复杂度问题
+	"issue.untested_complexity": "函数 '%s' (行 %d) 复杂度 %d 但测试覆盖率只有 %.0f%%，CRAP风险分 %.1f，建议补充测试或拆分",
+
+	// 测试完备度问题
+	"issue.untested_file":           "文件没有对应的测试，建议添加 %s",
+	"issue.test_without_assertions": "测试文件 %s 中没有任何断言，跑了也等于没测",
+
+	// 技术债标记问题
+	"issue.debt_marker":           "行 %d 的技术债标记 %s（%s）：%s",
+	"issue.debt_marker_untracked": "行 %d 的技术债标记 %s 没有关联工单：%s",
+
+	// 命名规范问题
+	"issue.naming_violation": "%s '%s' (行 %d) 不符合命名规范，应使用 %s",
+	"naming.kind.package":    "包名",
+	"naming.kind.type":       "类型名",
+	"naming.kind.function":   "函数名",
+	"naming.kind.method":     "方法名",
+	"naming.kind.variable":   "变量名",
+	"naming.kind.constant":   "常量名",
+
 	// 函数长度问题
 	"issue.function_very_long": "函数 %s 代码行数过多 (%d 行)，极度建议拆分",
 	"issue.function_long":      "函数 %s 代码行数较多 (%d 行)，建议拆分为多个小函数",
@@ -324,12 +571,26 @@ var zhCNMessages = map[string]string{
 	"issue.file_very_long":     "文件代码行数过多 (%d 行)，建议拆分为多个文件",
 	"issue.file_long":          "文件代码行数较多 (%d 行)，考虑是否可以优化结构",
 
+	// 函数长度指标问题，包含函数开始行
+	"issue.function_length_extreme":   "函数 '%s' (行 %d) 极度过长 (%d 行)，必须拆分",
+	"issue.function_length_very_long": "函数 '%s' (行 %d) 过长 (%d 行)，建议拆分",
+	"issue.function_length_long":      "函数 '%s' (行 %d) 较长 (%d 行)，可考虑重构",
+
 	// 注释覆盖率问题
 	"issue.comment_very_low":         "代码注释率极低 (%.2f%%)，几乎没有注释",
 	"issue.comment_low":              "代码注释率较低 (%.2f%%)，建议增加注释",
 	"issue.exported_func_no_comment": "导出函数 %s 缺少文档注释",
 	"issue.exported_type_no_comment": "导出类型 %s 缺少文档注释",
 
+	// 注释掉的代码
+	"issue.commented_out_code": "行 %d-%d: 注释掉的代码 (%d 行)，不计入注释率，应删除或恢复",
+
+	// 文档注释
+	"issue.public_api_no_doc": "行 %d: 公开的 %s 缺少文档注释 (%s)",
+	"issue.doc_comment_name":  "行 %d: %s 的文档注释应以名称开头",
+	"issue.doc_param_missing": "行 %d: %s 的文档注释没有说明参数 %s",
+	"issue.doc_param_unknown": "行 %d: %s 的文档注释说明了不存在的参数 %s",
+
 	// 详细报告
 	"verbose.basic_statistics":  "📊 基本统计:",
 	"verbose.total_files":       "总文件数:",
@@ -358,12 +619,20 @@ var enUSMessages = map[string]string{
 
 	// 指标名称
 	"metric.cyclomatic_complexity": "Cyclomatic Complexity",
-	"metric.function_length":       "State Management",
+	"metric.cognitive_complexity":  "Cognitive Complexity",
+	"metric.maintainability_index": "Maintainability Index",
+	"metric.function_length":       "Function Length",
+	"metric.state_management":      "State Management",
+	"metric.class_cohesion":        "Class Cohesion",
+	"metric.untested_complexity":   "Untested Complexity",
+	"metric.test_presence":         "Test Presence",
+	"metric.debt_markers":          "Tech Debt Markers",
 	"metric.comment_ratio":         "Comment Ratio",
 	"metric.error_handling":        "Error Handling",
 	"metric.naming_convention":     "Naming Convention",
 	"metric.code_duplication":      "Code Duplication",
 	"metric.structure_analysis":    "Code Structure",
+	"metric.custom_rules":          "Custom Rules",
 
 	// 分析器进度
 	"analyzer.searching_files":   "Searching for source code files...",
@@ -371,8 +640,71 @@ var enUSMessages = map[string]string{
 	"analyzer.analyzing_files":   "Analyzing files...",
 	"analyzer.analysis_complete": "Analysis complete",
 
+	// 最差函数
+	"report.functions":                 "Worst Functions",
+	"report.functions.rank.complexity": "by complexity",
+	"report.functions.rank.length":     "by length",
+	"report.functions.rank.params":     "by parameter count",
+	"report.functions.rank.mi":         "by maintainability index",
+	"report.functions.rank.crap":       "by CRAP score",
+	"report.functions.name":            "Function",
+	"report.functions.location":        "Location",
+	"report.functions.complexity":      "Complexity",
+	"report.functions.lines":           "Lines",
+	"report.functions.params":          "Params",
+	"report.functions.mi":              "MI",
+	"report.functions.coverage":        "Coverage",
+	"report.functions.crap":            "CRAP",
+	"report.functions.none":            "No functions found",
+
+	// 包耦合度
+	"report.coupling":              "Package Coupling",
+	"report.coupling.legend":       "Ca afferent coupling, Ce efferent coupling, I instability, A abstractness, D distance from the main sequence; sorted by D descending",
+	"report.coupling.package":      "Package",
+	"report.coupling.fan_in":       "Fan-in",
+	"report.coupling.fan_out":      "Fan-out",
+	"report.coupling.zone_of_pain": "(zone of pain)",
+
+	// 测试情况
+	"report.tests":               "Tests",
+	"report.tests.summary":       "%d test files with %d lines; %d production lines, test-to-code ratio %.2f; %d production files without tests",
+	"report.tests.package":       "Package",
+	"report.tests.production":    "Production",
+	"report.tests.files":         "Tests",
+	"report.tests.untested":      "Untested",
+	"report.tests.ratio":         "Test ratio",
+	"report.tests.no_assertions": "Test files without assertions",
+	"report.tests.quality":       "Test Code Quality",
+
+	// 技术债
+	"report.debt":           "Tech Debt",
+	"report.debt.summary":   "%d markers, %d without a ticket (%s)",
+	"report.debt.file":      "File",
+	"report.debt.markers":   "Markers",
+	"report.debt.untracked": "No ticket",
+	"report.debt.oldest":    "Oldest markers",
+
 	// 问题分类
 	"report.no_issues":           "Congratulations! No problematic files found!",
+	"report.suppressed":          "Suppressed Issues",
+	"report.suppressed.total":    "%d issues ignored by suppression comments and excluded from scoring",
+	"report.suppressed.count":    "Suppressed",
+	"report.directories":         "Directory Scores",
+	"report.directory.summary":   "%d files, %d lines, %d issues",
+	"report.directory.worst":     "Worst files",
+	"report.aggregation":         "Aggregation",
+	"aggregation.loc":            "LOC-weighted mean",
+	"aggregation.functions":      "function-count-weighted mean",
+	"aggregation.p90":            "90th percentile",
+	"aggregation.max":            "worst file",
+	"report.workspace.overview":  "Sub-project Overview",
+	"report.workspace.count":     "%d sub-projects, worst first",
+	"report.workspace.project":   "Project",
+	"report.workspace.kind":      "Type",
+	"report.workspace.path":      "Path",
+	"report.workspace.files":     "Files",
+	"report.workspace.lines":     "Lines",
+	"report.workspace.issues":    "Issues",
 	"issue.category.complexity":  "Complexity Issues",
 	"issue.category.comment":     "Comment Issues",
 	"issue.category.naming":      "Naming Issues",
@@ -380,6 +712,7 @@ var enUSMessages = map[string]string{
 	"issue.category.duplication": "Duplication Issues",
 	"issue.category.error":       "Error Handling Issues",
 	"issue.category.other":       "Other Issues",
+	"issue.category.debt":        "Tech Debt",
 
 	// 质量等级
 	"level.clean":             "Fresh as spring breeze",
@@ -395,10 +728,41 @@ var enUSMessages = map[string]string{
 	"level.disaster.ultimate": "Ultimate King of Mess",
 
 	// 命令行
-	"cmd.short":                      "💻 fuck-u-code",
-	"cmd.long":                       "🔍 Code Quality Detector - Objectively assess your code quality\n\nIt can analyze code quality, output scores, and help you find 💩 in your code. Suitable for:\n- Quality assessment before project refactoring\n- Team code review assistance tool\n- Learning programming best practices",
-	"cmd.analyze":                    "Analyze code quality and output score",
-	"cmd.analyze.long":               "Deeply analyze the codebase, detect various potential code issues, and output a quality report. When no path is specified, the current directory is analyzed.",
+	"cmd.short":             "💻 fuck-u-code",
+	"cmd.long":              "🔍 Code Quality Detector - Objectively assess your code quality\n\nIt can analyze code quality, output scores, and help you find 💩 in your code. Suitable for:\n- Quality assessment before project refactoring\n- Team code review assistance tool\n- Learning programming best practices",
+	"cmd.analyze":           "Analyze code quality and output score",
+	"cmd.analyze.long":      "Deeply analyze the codebase, detect various potential code issues, and output a quality report. When no path is specified, the current directory is analyzed.",
+	"cmd.lsp":               "Run as a language server (LSP)",
+	"cmd.metrics":           "Manage code quality metrics",
+	"cmd.metrics.long":      "Inspect the registered code quality metrics. Use --enable-metric and --disable-metric with a metric key to toggle metrics when analyzing.",
+	"cmd.metrics.list":      "List all registered metrics",
+	"cmd.enable_metric":     "Enable a metric by key (can be repeated, see metrics list)",
+	"cmd.disable_metric":    "Disable a metric by key (can be repeated, see metrics list)",
+	"cmd.config":            "Project config file, defaults to the nearest .fuckucode.json above the analyzed path",
+	"cmd.no_ignore":         "Do not read .gitignore and .fuckucodeignore, only apply default excludes and --exclude",
+	"cmd.include_generated": "Analyze generated, minified and data files (detected by content and skipped by default)",
+	"cmd.coverage":          "Test coverage file in Go coverprofile, LCOV, Cobertura XML or JaCoCo XML format (can be repeated)",
+	"cmd.coverage_failed":   "Failed to load coverage: %v",
+	"cmd.test_report":       "Also report the quality of test code separately (tests never count toward the production score)",
+
+	// 技术债命令
+	"cmd.blame":          "Read git blame to date tech debt markers and list the oldest ones",
+	"cmd.debt":           "List tech debt markers in the code",
+	"cmd.debt.long":      "List TODO, FIXME, HACK, XXX and @deprecated markers in comments with their owner, ticket and description, optionally exported as JSON or CSV. Scans the current directory when no path is given; test files are included.",
+	"cmd.debt.format":    "Output format: text, json, csv",
+	"cmd.ticket_pattern": "Regular expression for ticket references, overrides debt.ticket_pattern in the project config",
+	"cmd.debt_failed":    "Failed to find tech debt markers: %v",
+
+	// Skipped files
+	"skip.generated.header":          "generated-code header",
+	"skip.generated.marker":          "contains @generated marker",
+	"skip.generated.protobuf":        "protobuf/gRPC generated code",
+	"skip.generated.minified":        "minified code (very long lines, little whitespace)",
+	"skip.generated.data":            "data or lock file",
+	"verbose.skipped_files":          "Skipped Files",
+	"cmd.config_failed":              "Failed to load config: %v",
+	"cmd.lsp.long":                   "Serve the Language Server Protocol over stdio, showing diagnostics, function complexity hovers and code lenses in your editor.",
+	"cmd.lsp_failed":                 "Language server exited abnormally: %v",
 	"cmd.completion":                 "Generate the autocompletion script for the specified shell",
 	"cmd.completion.long":            "Generate the autocompletion script for the specified shell, supporting bash, zsh, fish and PowerShell.",
 	"cmd.completion.long_prefix":     "Generate the autocompletion script for fuck-u-code for the specified shell.",
@@ -417,14 +781,26 @@ var enUSMessages = map[string]string{
 	"cmd.no_descriptions":            "disable completion descriptions",
 	"cmd.path_not_found":             "Path not accessible '%s': %v",
 	"cmd.analysis_failed":            "Analysis failed: %v",
+	"cmd.unknown_format":             "Unsupported output format: %s",
+	"cmd.unknown_function_ranking":   "Unsupported function ranking: %s (supported: complexity, length, params, mi, crap)",
+	"cmd.report_failed":              "Failed to generate report: %v",
 	"cmd.lang":                       "Specify output language (supported: zh-CN, en-US, default: zh-CN)",
 	"cmd.verbose":                    "Show detailed analysis report",
 	"cmd.top":                        "Show the number of files with the most issues (default 5)",
+	"cmd.top_functions":              "Number of worst functions to show (default 10, 0 to hide)",
+	"cmd.rank_functions":             "How worst functions are ranked (complexity, length, params, mi, crap, default: complexity)",
 	"cmd.issues":                     "How many issues to show for each file (default 5)",
 	"cmd.summary":                    "Show only conclusion, skip the process",
 	"cmd.markdown":                   "Output streamlined Markdown format report, suitable for AI tool processing",
+	"cmd.format":                     "Output format (supported: console, markdown, html, json, junit, checkstyle, default: console)",
+	"cmd.threshold":                  "Score threshold above which JUnit test cases fail (0-100, default 60)",
 	"cmd.exclude":                    "Exclude file/directory patterns (can be used multiple times, common dependency directories are excluded by default)",
 	"cmd.skipindex":                  "Skip all index.js/index.ts files",
+	"cmd.stdin_filename":             "File name used to detect the language when reading source from stdin (path -)",
+	"cmd.stdin_unsupported":          "Cannot detect the language from file name %q, use --stdin-filename with a file extension",
+	"cmd.stdin_read_failed":          "Failed to read stdin: %v",
+	"cmd.aggregation":                "How file scores are aggregated (supported: loc LOC-weighted, functions function-count-weighted, p90 90th percentile, max worst file, default: loc)",
+	"cmd.workspace":                  "Workspace mode: detect sub-projects in the repository (go.mod, package.json, etc.) and score each separately",
 	"cmd.start_analyzing":            "Start analyzing: %s",
 	"cmd.exclude_patterns":           "Excluding the following file/directory patterns:",
 
@@ -464,6 +840,45 @@ var enUSMessages = map[string]string{
 	"report.more_issues_short":       "more issues",
 	"report.improvement_suggestions": "Improvement Suggestions",
 
+	// HTML报告
+	"report.html.treemap":       "Directory Treemap",
+	"report.html.treemap_hint":  "Area shows lines of code, color shows the issue score (green is good, red is bad). Click a file to see its issues",
+	"report.html.file":          "File",
+	"report.html.lines":         "Lines",
+	"report.html.issues":        "Issues",
+	"report.html.comment":       "Comment",
+	"report.html.sort_hint":     "Click a column header to sort",
+	"report.html.issue_details": "File Issue Details",
+
+	// CI报告
+	"report.ci.threshold_exceeded": "Score %.2f exceeds threshold %.2f",
+
+	// Metric list
+	"metrics.list.key":           "Key",
+	"metrics.list.name":          "Name",
+	"metrics.list.weight":        "Weight",
+	"metrics.list.languages":     "Languages",
+	"metrics.list.status":        "Default",
+	"metrics.list.all_languages": "all",
+	"metrics.list.enabled":       "enabled",
+	"metrics.list.disabled":      "disabled",
+
+	// 技术债标记列表
+	"debt.list.location": "Location",
+	"debt.list.kind":     "Kind",
+	"debt.list.author":   "Owner",
+	"debt.list.ticket":   "Ticket",
+	"debt.list.date":     "Introduced",
+	"debt.list.text":     "Text",
+	"debt.list.total":    "%d markers, %d without a ticket",
+
+	// Language server
+	"lsp.hover.function":   "Function",
+	"lsp.hover.complexity": "Cyclomatic complexity: %d",
+	"lsp.hover.length":     "Length: %d lines",
+	"lsp.hover.parameters": "Parameters: %d",
+	"lsp.lens.function":    "complexity %d · %d lines · %d params",
+
 	// 指标评分后缀
 	"metric.score.suffix": " pts",
 
@@ -473,9 +888,34 @@ var enUSMessages = map[string]string{
 	"metric.complexity.bad":    "Functions like labyrinths, maintenance like a dungeon raid",
 
 	// 函数长度评价
-	"metric.length.good":   "Clear state management, reasonable variable scope, predictable state",
-	"metric.length.medium": "Average state management, some global state or unclear state changes",
-	"metric.length.bad":    "Chaotic state management, excessive use of global variables, difficult to track state changes",
+	"metric.length.good":   "Short and sweet functions, each fits on one screen",
+	"metric.length.medium": "Functions are getting long, some scrolling required",
+	"metric.length.bad":    "Functions longer than a Monday morning meeting",
+
+	// 状态管理评价
+	"metric.state.good":   "Clear state management, reasonable variable scope, predictable state",
+	"metric.state.medium": "Average state management, some global state or unclear state changes",
+	"metric.state.bad":    "Chaotic state management, excessive use of global variables, difficult to track state changes",
+
+	// 类内聚度评价
+	"metric.cohesion.good":   "Focused classes, methods revolve around the same data",
+	"metric.cohesion.medium": "Classes are a bit mixed, unrelated responsibilities squeezed together",
+	"metric.cohesion.bad":    "God classes everywhere, one class does it all and every change ripples",
+
+	// 未测试复杂度评价
+	"metric.coverage.good":   "Complex code is backed by tests, changes are safe",
+	"metric.coverage.medium": "Some complex functions have no tests, changes rely on luck",
+	"metric.coverage.bad":    "The most tangled code is exactly the untested code, every change is a minefield",
+
+	// 测试完备度评价
+	"metric.tests.good":   "Almost every file has tests, changes can be made with confidence",
+	"metric.tests.medium": "Many files have no tests, every change comes with a prayer",
+	"metric.tests.bad":    "Tests? Never heard of them, every release is a gamble",
+
+	// 技术债标记评价
+	"metric.debt.good":   "Little debt, easily paid back",
+	"metric.debt.medium": "TODOs keep piling up, the bill will come someday",
+	"metric.debt.bad":    "FIXMEs and HACKs everywhere, tech debt with compound interest",
 
 	// 注释覆盖率评价
 	"metric.comment.good":   "Good comments, they'll help you survive",
@@ -537,13 +977,25 @@ var enUSMessages = map[string]string{
 	"advice.bad.style":           "Set up strict coding standards and actually follow them",
 
 	// 指标描述
-	"metric.function_length.description":       "Detects how you manage state variables. Good state management means you won't lose your mind maintaining this code.",
+	"metric.maintainability_index.description": "Combines Halstead volume, cyclomatic complexity and line count into the Maintainability Index (MI). Functions below 20 are hard to maintain.",
+	"metric.function_length.description":       "Counts the lines in each function. Long functions are hard to read and harder to test.",
+	"metric.state_management.description":      "Detects how you manage state variables. Global mutable variables, static mutable fields and writes to global state make code unpredictable.",
+	"metric.class_cohesion.description":        "Measures methods, fields, total complexity and LCOM4 cohesion per class to find god classes, low-cohesion classes and data classes",
+	"metric.untested_complexity.description":   "Combines test coverage with cyclomatic complexity into a CRAP score to find complex functions that lack tests; requires a coverage file via --coverage",
+	"metric.test_presence.description":         "Matches test files to production files by naming and package to find untested files and tests without assertions; only computed when analyzing a directory",
+	"metric.debt_markers.description":          "Counts TODO, FIXME, HACK, XXX and @deprecated markers in comments; markers without a ticket count double",
 	"metric.comment_ratio.description":         "Checks if your code has enough comments. Good comments mean you won't curse your past self.",
 	"metric.error_handling.description":        "Sniffs out your error handling. Good error handling means your code won't explode at runtime.",
-	"metric.naming_convention.description":     "Checks if your naming is civilized. Good names mean less guessing, more coding.",
+	"metric.naming_convention.description":     "Checks that names follow the conventions of each language: packages, types, functions, variables and constants",
 	"metric.code_duplication.description":      "Evaluates how much copy-paste you did. More duplication means you need to refactor, or just admit you love Ctrl+C/V.",
 	"metric.structure_analysis.description":    "Detects nesting depth and reference complexity. The less Russian doll, the less headache.",
 	"metric.cyclomatic_complexity.description": "Measures how twisted your control flow is. The higher the complexity, the more likely you'll regret touching this code.",
+	"metric.cognitive_complexity.description":  "Measures how hard a function is to read. Deep nesting, jumps in the flow and mixed boolean operators all make it worse.",
+	"metric.custom_rules.description":          "Checks the team's house rules declared in the project config",
+	"metric.custom_rules.issue":                "[%s] %s (rule %s, %s, line %d)",
+	"rules.severity.error":                     "severe",
+	"rules.severity.warning":                   "warning",
+	"rules.severity.info":                      "info",
 
 	// 质量等级描述
 	"level.clean.description":             "Code so clean, it's a joy to read—like a spa day for your eyes.",
@@ -571,11 +1023,14 @@ var enUSMessages = map[string]string{
 	"score.comment.90": "Disaster level tumor, every glance shortens your life by ten years—run while you still can.",
 
 	// 错误消息
-	"error.path_not_accessible":    "Cannot access path: %v",
-	"error.file_read_failed":       "Failed to read file %s: %v",
-	"error.code_parse_failed":      "Failed to parse code %s: %v",
-	"error.source_files_not_found": "Failed to find source files: %v",
-	"error.file_analysis_failed":   "Failed to analyze file %s: %v",
+	"error.path_not_accessible":     "Cannot access path: %v",
+	"error.workspace_not_directory": "Workspace mode requires a directory: %s",
+	"error.file_read_failed":        "Failed to read file %s: %v",
+	"error.code_parse_failed":       "Failed to parse code %s: %v",
+	"error.source_files_not_found":  "Failed to find source files: %v",
+	"error.file_analysis_failed":    "Failed to analyze file %s: %v",
+	"error.unknown_metric":          "Unknown metric: %s",
+	"error.unknown_aggregation":     "Unknown aggregation: %s (supported: loc, functions, p90, max)",
 
 	// 警告和提示
 	"warning.format": "Warning: %v\n",
@@ -586,6 +1041,47 @@ var enUSMessages = map[string]string{
 	"issue.file_high_complexity":   "File has very high complexity (%d), consider splitting into multiple files",
 	"issue.file_medium_complexity": "File has high complexity (%d), consider optimizing",
 
+	// 认知复杂度问题
+	"issue.high_cognitive_complexity":   "Function '%s' (line %d) has extremely high cognitive complexity (%d), too much nesting and jumping, must be split",
+	"issue.medium_cognitive_complexity": "Function '%s' (line %d) has high cognitive complexity (%d), consider reducing nesting",
+
+	// 可维护性问题
+	"issue.critical_maintainability": "Function '%s' (line %d) has a critically low maintainability index (%.1f, Halstead volume %.0f), nearly unmaintainable",
+	"issue.low_maintainability":      "Function '%s' (line %d) has a low maintainability index (%.1f, Halstead volume %.0f), consider refactoring",
+
+	// 状态管理问题
+	"issue.global_variable":      "Global mutable variable '%s' (line %d) makes state hard to track",
+	"issue.global_write":         "Function '%s' (line %d) modifies global variable '%s'",
+	"issue.static_mutable_field": "Static mutable field '%s' (line %d) shares state across all instances",
+
+	// 类内聚度问题
+	"issue.god_class":    "[structure] Class '%s' (line %d) is a god class: %d methods, %d fields, total complexity %d, %d lines; split it by responsibility",
+	"issue.low_cohesion": "[structure] Class '%s' (line %d) has low cohesion (LCOM4=%d): its methods form groups that share no fields or calls and could be split",
+	"issue.data_class":   "[structure] Class '%s' (line %d) is a data class: %d fields with only accessors, related behavior probably lives elsewhere",
+
+	// 包耦合度问题
+	"issue.zone_of_pain": "[structure] Package '%s' is in the zone of pain (Ca=%d, I=%.2f, A=%.2f, D=%.2f): depended on by many packages yet stable and concrete, hard to change; consider extracting interfaces",
+
+	// 未测试复杂度问题
+	"issue.untested_complexity": "Function '%s' (line %d) has complexity %d but only %.0f%% test coverage, CRAP score %.1f; add tests or split it",
+
+	// 测试完备度问题
+	"issue.untested_file":           "File has no matching tests, consider adding %s",
+	"issue.test_without_assertions": "Test file %s contains no assertions, running it proves nothing",
+
+	// 技术债标记问题
+	"issue.debt_marker":           "Line %d has tech debt marker %s (%s): %s",
+	"issue.debt_marker_untracked": "Line %d has tech debt marker %s without a ticket: %s",
+
+	// 命名规范问题
+	"issue.naming_violation": "%s '%s' (line %d) breaks the naming convention, use %s",
+	"naming.kind.package":    "Package name",
+	"naming.kind.type":       "Type name",
+	"naming.kind.function":   "Function name",
+	"naming.kind.method":     "Method name",
+	"naming.kind.variable":   "Variable name",
+	"naming.kind.constant":   "Constant name",
+
 	// 函数长度问题
 	"issue.function_very_long": "Function %s has too many lines of code (%d), strongly recommend splitting",
 	"issue.function_long":      "Function %s has many lines of code (%d), consider splitting into smaller functions",
@@ -593,12 +1089,26 @@ var enUSMessages = map[string]string{
 	"issue.file_very_long":     "File has too many lines of code (%d), recommend splitting into multiple files",
 	"issue.file_long":          "File has many lines of code (%d), consider optimizing the structure",
 
+	// 函数长度指标问题，包含函数开始行
+	"issue.function_length_extreme":   "Function '%s' (line %d) is extremely long (%d lines), must be split",
+	"issue.function_length_very_long": "Function '%s' (line %d) is too long (%d lines), consider splitting it",
+	"issue.function_length_long":      "Function '%s' (line %d) is long (%d lines), consider refactoring",
+
 	// 注释覆盖率问题
 	"issue.comment_very_low":         "Code comment ratio is extremely low (%.2f%%), almost no comments",
 	"issue.comment_low":              "Code comment ratio is low (%.2f%%), consider adding more comments",
 	"issue.exported_func_no_comment": "Exported function %s lacks documentation comment",
 	"issue.exported_type_no_comment": "Exported type %s lacks documentation comment",
 
+	// 注释掉的代码
+	"issue.commented_out_code": "Line %d-%d: commented-out code (%d lines), excluded from the comment ratio; delete it or bring it back",
+
+	// 文档注释
+	"issue.public_api_no_doc": "Line %d: public %s has no doc comment (%s)",
+	"issue.doc_comment_name":  "Line %d: doc comment of %s should start with its name",
+	"issue.doc_param_missing": "Line %d: doc comment of %s does not describe parameter %s",
+	"issue.doc_param_unknown": "Line %d: doc comment of %s describes unknown parameter %s",
+
 	// 详细报告
 	"verbose.basic_statistics":  "📊 Basic stats (brace yourself):",
 	"verbose.total_files":       "Total files:",
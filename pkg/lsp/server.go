@@ -0,0 +1,314 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/Done-0/fuck-u-code/pkg/analyzer"
+	"github.com/Done-0/fuck-u-code/pkg/common"
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+	"github.com/Done-0/fuck-u-code/pkg/metrics"
+	"github.com/Done-0/fuck-u-code/pkg/parser"
+)
+
+// diagnosticSource 诊断来源名称
+const diagnosticSource = "fuck-u-code"
+
+// errExit 收到exit通知时结束服务
+var errExit = errors.New("exit")
+
+// windowsDrivePath 匹配URI中的Windows盘符路径，如 /C:/foo
+var windowsDrivePath = regexp.MustCompile(`^/[A-Za-z]:`)
+
+// Server LSP服务器，通过标准输入输出与编辑器通信
+type Server struct {
+	reader       *bufio.Reader
+	writer       io.Writer
+	codeAnalyzer *analyzer.CodeAnalyzer
+	translator   i18n.Translator
+	detector     common.LanguageDetector
+	documents    map[string]*document
+}
+
+// document 编辑器中打开的文档
+type document struct {
+	path    string
+	content []byte
+	result  *metrics.AnalysisResult
+}
+
+// NewServer 创建新的LSP服务器
+func NewServer(in io.Reader, out io.Writer, translator i18n.Translator) *Server {
+	return &Server{
+		reader:       bufio.NewReader(in),
+		writer:       out,
+		codeAnalyzer: analyzer.NewCodeAnalyzer(translator),
+		translator:   translator,
+		detector:     common.NewLanguageDetector(),
+		documents:    make(map[string]*document),
+	}
+}
+
+// Run 运行服务器直到收到exit通知或输入结束
+func (s *Server) Run() error {
+	for {
+		msg, err := s.readMessage()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := s.handle(msg); err != nil {
+			if err == errExit {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// readMessage 读取下一条消息
+func (s *Server) readMessage() (*message, error) {
+	return readMessage(s.reader)
+}
+
+// handle 分发处理消息
+func (s *Server) handle(msg *message) error {
+	switch msg.Method {
+	case "initialize":
+		return s.reply(msg.ID, map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync": textDocumentSyncFull,
+				"hoverProvider":    true,
+				"codeLensProvider": map[string]interface{}{"resolveProvider": false},
+			},
+			"serverInfo": map[string]string{"name": diagnosticSource},
+		})
+	case "shutdown":
+		return s.reply(msg.ID, nil)
+	case "exit":
+		return errExit
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+		return s.updateDocument(params.TextDocument.URI, []byte(params.TextDocument.Text))
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil || len(params.ContentChanges) == 0 {
+			return nil
+		}
+		text := params.ContentChanges[len(params.ContentChanges)-1].Text
+		return s.updateDocument(params.TextDocument.URI, []byte(text))
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+		delete(s.documents, params.TextDocument.URI)
+		return s.publishDiagnostics(params.TextDocument.URI, []diagnostic{})
+	case "textDocument/hover":
+		var params textDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return s.replyError(msg.ID, errInvalidParams, err.Error())
+		}
+		return s.reply(msg.ID, s.hover(params))
+	case "textDocument/codeLens":
+		var params codeLensParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return s.replyError(msg.ID, errInvalidParams, err.Error())
+		}
+		return s.reply(msg.ID, s.codeLenses(params.TextDocument.URI))
+	default:
+		// 未知请求需要返回错误，未知通知直接忽略
+		if msg.ID != nil {
+			return s.replyError(msg.ID, errMethodNotFound, msg.Method)
+		}
+		return nil
+	}
+}
+
+// updateDocument 更新文档内容并重新分析
+func (s *Server) updateDocument(uri string, content []byte) error {
+	doc, ok := s.documents[uri]
+	if !ok {
+		doc = &document{path: uriToPath(uri)}
+		s.documents[uri] = doc
+	}
+	doc.content = content
+
+	if !s.detector.IsSupportedFile(doc.path) {
+		return nil
+	}
+
+	// 编辑过程中代码经常无法解析，此时保留上一次的分析结果
+	result, err := s.codeAnalyzer.AnalyzeContent(doc.path, content)
+	if err != nil {
+		return nil
+	}
+	doc.result = result
+
+	return s.publishDiagnostics(uri, s.diagnostics(doc))
+}
+
+// diagnostics 将分析问题转换为诊断信息
+func (s *Server) diagnostics(doc *document) []diagnostic {
+	diagnostics := make([]diagnostic, 0)
+	lines := strings.Split(string(doc.content), "\n")
+
+	for _, issue := range doc.result.GetIssues() {
+		line := analyzer.LocateIssue(issue, doc.content)
+		if line == 0 {
+			line = 1
+		}
+
+		diagnostics = append(diagnostics, diagnostic{
+			Range:    lineRange(lines, line-1),
+			Severity: severityWarning,
+			Source:   diagnosticSource,
+			Message:  issue,
+		})
+	}
+
+	return diagnostics
+}
+
+// hover 返回光标所在函数的复杂度和长度信息
+func (s *Server) hover(params textDocumentPositionParams) interface{} {
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok || doc.result == nil {
+		return nil
+	}
+
+	fn := findFunction(doc.result.Functions, params.Position.Line+1)
+	if fn == nil {
+		return nil
+	}
+
+	value := fmt.Sprintf("**%s** `%s`\n\n- %s\n- %s\n- %s",
+		s.translator.Translate("lsp.hover.function"),
+		fn.Name,
+		s.translator.Translate("lsp.hover.complexity", fn.Complexity),
+		s.translator.Translate("lsp.hover.length", fn.EndLine-fn.StartLine+1),
+		s.translator.Translate("lsp.hover.parameters", fn.Parameters))
+
+	return hover{Contents: markupContent{Kind: "markdown", Value: value}}
+}
+
+// codeLenses 为文件和每个函数生成代码透镜
+func (s *Server) codeLenses(uri string) []codeLens {
+	lenses := make([]codeLens, 0)
+
+	doc, ok := s.documents[uri]
+	if !ok || doc.result == nil {
+		return lenses
+	}
+
+	lines := strings.Split(string(doc.content), "\n")
+
+	lenses = append(lenses, codeLens{
+		Range: lineRange(lines, 0),
+		Command: command{
+			Title: s.translator.Translate("report.file_score", doc.result.GetOverallScore()*100),
+		},
+	})
+
+	for _, fn := range doc.result.Functions {
+		lenses = append(lenses, codeLens{
+			Range: lineRange(lines, fn.StartLine-1),
+			Command: command{
+				Title: s.translator.Translate("lsp.lens.function", fn.Complexity, fn.EndLine-fn.StartLine+1, fn.Parameters),
+			},
+		})
+	}
+
+	return lenses
+}
+
+// publishDiagnostics 发布诊断信息
+func (s *Server) publishDiagnostics(uri string, diagnostics []diagnostic) error {
+	return writeMessage(s.writer, notification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/publishDiagnostics",
+		Params:  publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics},
+	})
+}
+
+// reply 发送成功响应
+func (s *Server) reply(id *json.RawMessage, result interface{}) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	raw := json.RawMessage(data)
+	return writeMessage(s.writer, response{JSONRPC: "2.0", ID: id, Result: &raw})
+}
+
+// replyError 发送错误响应
+func (s *Server) replyError(id *json.RawMessage, code int, msg string) error {
+	return writeMessage(s.writer, response{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   &responseError{Code: code, Message: msg},
+	})
+}
+
+// findFunction 查找包含指定行的最内层函数
+func findFunction(functions []parser.Function, line int) *parser.Function {
+	var found *parser.Function
+	for i := range functions {
+		fn := &functions[i]
+		if line < fn.StartLine || line > fn.EndLine {
+			continue
+		}
+		if found == nil || fn.EndLine-fn.StartLine < found.EndLine-found.StartLine {
+			found = fn
+		}
+	}
+	return found
+}
+
+// lineRange 返回覆盖整行的范围
+func lineRange(lines []string, line int) lspRange {
+	if line < 0 || line >= len(lines) {
+		line = 0
+	}
+
+	// LSP的列号以UTF-16代码单元计数，emoji等辅助平面字符占两个单元
+	length := 0
+	if line < len(lines) {
+		for _, r := range strings.TrimRight(lines[line], "\r") {
+			length += utf16.RuneLen(r)
+		}
+	}
+
+	return lspRange{
+		Start: position{Line: line, Character: 0},
+		End:   position{Line: line, Character: length},
+	}
+}
+
+// uriToPath 将file://形式的URI转换为本地路径
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+
+	path := u.Path
+	if windowsDrivePath.MatchString(path) {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
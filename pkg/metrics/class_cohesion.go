@@ -0,0 +1,234 @@
+// Package metrics 提供代码质量分析指标
+// 创建者：Done-0
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+	"github.com/Done-0/fuck-u-code/pkg/parser"
+)
+
+// 类内聚度阈值
+const (
+	godClassMethods    = 20   // 上帝类的方法数
+	godClassComplexity = 50   // 上帝类的总复杂度
+	godClassLines      = 1000 // 超过该行数直接视为上帝类
+	cohesionMinMethods = 4    // 方法数达到该值才检查内聚度
+	dataClassFields    = 3    // 数据类的最少字段数
+	accessorComplexity = 2    // 存取方法的最大复杂度
+	accessorLines      = 2    // 只访问一个字段的存取方法的最大行数跨度
+)
+
+// 每个问题类增加的得分
+const (
+	godClassPenalty    = 0.5
+	lowCohesionPenalty = 0.2
+	dataClassPenalty   = 0.1
+)
+
+// ClassCohesion 类的内聚度和规模
+type ClassCohesion struct {
+	Name       string // 类名
+	StartLine  int    // 开始行
+	EndLine    int    // 结束行
+	Lines      int    // 行数，Go中为类型声明和方法的行数之和
+	Methods    int    // 方法数，不含构造函数
+	Fields     int    // 字段数
+	Complexity int    // 方法圈复杂度之和
+	LCOM4      int    // 方法按共享字段和相互调用连通后的分组数，1表示内聚
+	GodClass   bool   // 是否为上帝类
+	DataClass  bool   // 是否为只有字段和存取方法的数据类
+}
+
+// parseClasses 获取解析结果中的类
+func parseClasses(parseResult parser.ParseResult) []parser.Class {
+	if source, ok := parseResult.(interface{ GetClasses() []parser.Class }); ok {
+		return source.GetClasses()
+	}
+	return nil
+}
+
+// AnalyzeClassCohesion 计算每个类的LCOM4、方法数、字段数和总复杂度，并判断上帝类和数据类
+func AnalyzeClassCohesion(parseResult parser.ParseResult) []ClassCohesion {
+	classes := parseClasses(parseResult)
+	if len(classes) == 0 {
+		return nil
+	}
+
+	results := make([]ClassCohesion, 0, len(classes))
+	for _, class := range classes {
+		c := ClassCohesion{
+			Name:      class.Name,
+			StartLine: class.StartLine,
+			EndLine:   class.EndLine,
+			Fields:    len(class.Fields),
+			LCOM4:     lcom4(class.Methods),
+		}
+
+		accessorsOnly := true
+		for _, m := range class.Methods {
+			c.Complexity += m.Complexity
+			if m.Constructor {
+				continue
+			}
+			c.Methods++
+			accessorsOnly = accessorsOnly && isAccessor(m)
+		}
+
+		c.Lines = classLines(class)
+		c.GodClass = c.Methods >= godClassMethods && c.Complexity >= godClassComplexity || c.Lines >= godClassLines
+		// Go的结构体和语言层面的记录类型本来就用于承载数据，不按数据类报告
+		c.DataClass = parseResult.GetLanguage() != common.Go && !class.Record && !c.GodClass &&
+			c.Fields >= dataClassFields && accessorsOnly
+		results = append(results, c)
+	}
+	return results
+}
+
+// classLines 返回类的行数，Go的方法不在类型声明内，取声明和各方法行数之和
+func classLines(class parser.Class) int {
+	lines := class.EndLine - class.StartLine + 1
+	methodLines := 0
+	for _, m := range class.Methods {
+		methodLines += m.EndLine - m.StartLine + 1
+	}
+	return max(lines, methodLines)
+}
+
+// lcom4 计算LCOM4：访问同一字段或相互调用的方法相连，返回连通分量数
+// 构造函数、存取方法和不访问任何成员的方法不体现职责划分，不计入
+func lcom4(methods []parser.Method) int {
+	index := make(map[string]int)
+	var nodes []parser.Method
+	for _, m := range methods {
+		if m.Constructor || isAccessor(m) || len(m.Fields) == 0 && len(m.Calls) == 0 {
+			continue
+		}
+		if _, ok := index[m.Name]; !ok {
+			index[m.Name] = len(nodes)
+			nodes = append(nodes, m)
+		}
+	}
+
+	// 并查集
+	parent := make([]int, len(nodes))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(a, b int) {
+		parent[find(a)] = find(b)
+	}
+
+	fieldOwner := make(map[string]int)
+	for i, m := range nodes {
+		for _, field := range m.Fields {
+			if j, ok := fieldOwner[field]; ok {
+				union(i, j)
+			} else {
+				fieldOwner[field] = i
+			}
+		}
+		for _, call := range m.Calls {
+			if j, ok := index[call]; ok {
+				union(i, j)
+			}
+		}
+	}
+
+	components := 0
+	for i := range nodes {
+		if find(i) == i {
+			components++
+		}
+	}
+	return components
+}
+
+// isAccessor 判断方法是否为简单的存取方法，如 getName、set_name、isValid
+// 只访问一个字段的短方法也视为存取方法，如Go中的 Name() 和TypeScript中的 get size()
+func isAccessor(m parser.Method) bool {
+	if m.Complexity > accessorComplexity || len(m.Calls) > 0 {
+		return false
+	}
+	if len(m.Fields) == 1 && m.Complexity <= 1 && m.EndLine-m.StartLine <= accessorLines {
+		return true
+	}
+	for _, prefix := range []string{"get", "set", "is", "has"} {
+		rest, ok := strings.CutPrefix(m.Name, prefix)
+		if !ok {
+			rest, ok = strings.CutPrefix(m.Name, strings.ToUpper(prefix[:1])+prefix[1:])
+		}
+		if ok && rest != "" && (rest[0] == '_' || unicode.IsUpper(rune(rest[0]))) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassCohesionMetric 检测类的内聚度，识别上帝类和数据类
+type ClassCohesionMetric struct {
+	*BaseMetric
+	translator i18n.Translator
+}
+
+// NewClassCohesionMetric 创建类内聚度指标
+func NewClassCohesionMetric() Metric {
+	translator := i18n.NewTranslator(i18n.ZhCN)
+	return &ClassCohesionMetric{
+		BaseMetric: NewBaseMetric(
+			i18n.FormatKey("metric", "class_cohesion"),
+			translator.Translate("metric.class_cohesion.description"),
+			0.1,
+			[]common.LanguageType{common.Go, common.Java, common.CSharp, common.Python, common.JavaScript, common.TypeScript},
+		),
+		translator: translator,
+	}
+}
+
+// SetTranslator 设置翻译器
+func (m *ClassCohesionMetric) SetTranslator(translator i18n.Translator) {
+	m.translator = translator
+	m.name = translator.Translate(i18n.FormatKey("metric", "class_cohesion"))
+	m.description = translator.Translate("metric.class_cohesion.description")
+}
+
+// Analyze 实现指标接口分析方法
+// 每个上帝类、低内聚类和数据类按严重程度增加得分
+func (m *ClassCohesionMetric) Analyze(parseResult parser.ParseResult) MetricResult {
+	issues := []string{}
+	score := 0.0
+	for _, c := range AnalyzeClassCohesion(parseResult) {
+		switch {
+		case c.GodClass:
+			issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.god_class"),
+				c.Name, c.StartLine, c.Methods, c.Fields, c.Complexity, c.Lines))
+			score += godClassPenalty
+		case c.DataClass:
+			issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.data_class"), c.Name, c.StartLine, c.Fields))
+			score += dataClassPenalty
+		}
+		if c.LCOM4 > 1 && c.Methods >= cohesionMinMethods {
+			issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.low_cohesion"), c.Name, c.StartLine, c.LCOM4))
+			score += lowCohesionPenalty
+		}
+	}
+
+	return MetricResult{
+		Score:       math.Min(score, 1.0),
+		Issues:      issues,
+		Description: m.Description(),
+		Weight:      m.Weight(),
+	}
+}
@@ -0,0 +1,455 @@
+// Package metrics 提供代码质量分析指标
+// 创建者：Done-0
+package metrics
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+	"github.com/Done-0/fuck-u-code/pkg/parser"
+)
+
+// 认知复杂度阈值
+const (
+	cognitiveMediumThreshold = 15 // 超过时建议简化
+	cognitiveHighThreshold   = 25 // 超过时必须拆分
+)
+
+// CognitiveComplexityMetric 计算代码的认知复杂度
+// 与循环复杂度不同，嵌套越深增量越大，扁平的switch不会比层层嵌套的if更难读
+type CognitiveComplexityMetric struct {
+	*BaseMetric
+	translator i18n.Translator
+}
+
+// NewCognitiveComplexityMetric 创建认知复杂度指标
+func NewCognitiveComplexityMetric() Metric {
+	translator := i18n.NewTranslator(i18n.ZhCN)
+	return &CognitiveComplexityMetric{
+		BaseMetric: NewBaseMetric(
+			i18n.FormatKey("metric", "cognitive_complexity"),
+			translator.Translate("metric.cognitive_complexity.description"),
+			0.25,
+			nil, // 支持所有语言
+		),
+		translator: translator,
+	}
+}
+
+// SetTranslator 设置翻译器
+func (m *CognitiveComplexityMetric) SetTranslator(translator i18n.Translator) {
+	m.translator = translator
+	m.name = translator.Translate(i18n.FormatKey("metric", "cognitive_complexity"))
+	m.description = translator.Translate("metric.cognitive_complexity.description")
+}
+
+// Analyze 实现指标接口分析方法
+func (m *CognitiveComplexityMetric) Analyze(parseResult parser.ParseResult) MetricResult {
+	functions := parseResult.GetFunctions()
+	_, content := ExtractSource(parseResult)
+
+	var complexities []int
+	if parseResult.GetLanguage() == common.Go {
+		complexities = goCognitiveComplexities(parseResult, functions)
+	}
+	if complexities == nil {
+		complexities = textCognitiveComplexities(content, functions, parseResult.GetLanguage())
+	}
+
+	var issues []string
+	total, over := 0, 0
+	for i, fn := range functions {
+		complexity := complexities[i]
+		total += complexity
+
+		switch {
+		case complexity > cognitiveHighThreshold:
+			issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.high_cognitive_complexity"), fn.Name, fn.StartLine, complexity))
+			over++
+		case complexity > cognitiveMediumThreshold:
+			issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.medium_cognitive_complexity"), fn.Name, fn.StartLine, complexity))
+			over++
+		}
+	}
+
+	return MetricResult{
+		Score:       cognitiveScore(total, over, len(functions)),
+		Issues:      issues,
+		Description: m.Description(),
+		Weight:      m.Weight(),
+	}
+}
+
+// cognitiveScore 根据平均认知复杂度和超标函数比例计算得分
+func cognitiveScore(total, over, count int) float64 {
+	if count == 0 {
+		return 0.0
+	}
+
+	avg := float64(total) / float64(count)
+	return 0.5*math.Min(avg/cognitiveMediumThreshold, 1.0) + 0.5*float64(over)/float64(count)
+}
+
+// goCognitiveComplexities 基于Go AST计算每个函数的认知复杂度，顺序与functions一致
+// 解析结果中没有AST时返回nil
+func goCognitiveComplexities(parseResult parser.ParseResult, functions []parser.Function) []int {
+	file, fileSet, _ := ExtractGoAST(parseResult)
+	if file == nil {
+		return nil
+	}
+
+	// 按开始行匹配解析器识别的函数
+	byLine := make(map[int]int)
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		counter := &goCognitiveCounter{name: funcDecl.Name.Name}
+		if funcDecl.Recv != nil && len(funcDecl.Recv.List) > 0 && len(funcDecl.Recv.List[0].Names) > 0 {
+			counter.receiver = funcDecl.Recv.List[0].Names[0].Name
+		}
+		counter.walk(funcDecl.Body, 0)
+		byLine[fileSet.Position(funcDecl.Pos()).Line] = counter.complexity
+	}
+
+	complexities := make([]int, len(functions))
+	for i, fn := range functions {
+		complexities[i] = byLine[fn.StartLine]
+	}
+	return complexities
+}
+
+// goCognitiveCounter 统计单个Go函数的认知复杂度
+type goCognitiveCounter struct {
+	name       string // 函数名，用于识别递归
+	receiver   string // 方法接收者名称
+	complexity int
+}
+
+// walk 递归遍历节点，nesting为当前嵌套层数
+func (c *goCognitiveCounter) walk(node ast.Node, nesting int) {
+	switch n := node.(type) {
+	case nil:
+		return
+	case *ast.IfStmt:
+		c.complexity += 1 + nesting
+		c.walkIf(n, nesting)
+		return
+	case *ast.ForStmt:
+		c.complexity += 1 + nesting
+		c.walk(n.Init, nesting)
+		c.walk(n.Cond, nesting)
+		c.walk(n.Post, nesting)
+		c.walk(n.Body, nesting+1)
+		return
+	case *ast.RangeStmt:
+		c.complexity += 1 + nesting
+		c.walk(n.X, nesting)
+		c.walk(n.Body, nesting+1)
+		return
+	case *ast.SwitchStmt:
+		c.complexity += 1 + nesting
+		c.walk(n.Init, nesting)
+		c.walk(n.Tag, nesting)
+		c.walk(n.Body, nesting+1)
+		return
+	case *ast.TypeSwitchStmt:
+		c.complexity += 1 + nesting
+		c.walk(n.Init, nesting)
+		c.walk(n.Assign, nesting)
+		c.walk(n.Body, nesting+1)
+		return
+	case *ast.SelectStmt:
+		c.complexity += 1 + nesting
+		c.walk(n.Body, nesting+1)
+		return
+	case *ast.FuncLit:
+		// 闭包不计增量，但增加嵌套层数
+		c.walk(n.Body, nesting+1)
+		return
+	case *ast.BranchStmt:
+		// 跳转到标签会打断线性流程
+		if n.Label != nil || n.Tok == token.GOTO {
+			c.complexity++
+		}
+	case *ast.BinaryExpr:
+		if n.Op == token.LAND || n.Op == token.LOR {
+			c.complexity += countOperatorSequences(logicalOperators(n))
+			c.walkOperands(n, nesting)
+			return
+		}
+	case *ast.CallExpr:
+		if c.isRecursiveCall(n) {
+			c.complexity++
+		}
+	}
+
+	c.walkChildren(node, nesting)
+}
+
+// walkIf 遍历if语句，else if和else只计1点增量，不计嵌套
+func (c *goCognitiveCounter) walkIf(n *ast.IfStmt, nesting int) {
+	c.walk(n.Init, nesting)
+	c.walk(n.Cond, nesting)
+	c.walk(n.Body, nesting+1)
+
+	switch elseNode := n.Else.(type) {
+	case *ast.IfStmt:
+		c.complexity++
+		c.walkIf(elseNode, nesting)
+	case *ast.BlockStmt:
+		c.complexity++
+		c.walk(elseNode, nesting+1)
+	}
+}
+
+// walkChildren 以相同嵌套层数遍历直接子节点
+func (c *goCognitiveCounter) walkChildren(node ast.Node, nesting int) {
+	if node == nil {
+		return
+	}
+	ast.Inspect(node, func(child ast.Node) bool {
+		if child == node {
+			return true
+		}
+		if child != nil {
+			c.walk(child, nesting)
+		}
+		return false
+	})
+}
+
+// walkOperands 遍历逻辑表达式中的非逻辑运算操作数
+func (c *goCognitiveCounter) walkOperands(expr ast.Expr, nesting int) {
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		if e.Op == token.LAND || e.Op == token.LOR {
+			c.walkOperands(e.X, nesting)
+			c.walkOperands(e.Y, nesting)
+			return
+		}
+	case *ast.ParenExpr:
+		c.walkOperands(e.X, nesting)
+		return
+	}
+	c.walk(expr, nesting)
+}
+
+// isRecursiveCall 判断调用是否为对自身的递归调用
+func (c *goCognitiveCounter) isRecursiveCall(call *ast.CallExpr) bool {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return c.receiver == "" && fun.Name == c.name
+	case *ast.SelectorExpr:
+		ident, ok := fun.X.(*ast.Ident)
+		return ok && c.receiver != "" && ident.Name == c.receiver && fun.Sel.Name == c.name
+	}
+	return false
+}
+
+// logicalOperators 按出现顺序返回逻辑表达式中的运算符，括号不打断序列
+func logicalOperators(expr ast.Expr) []string {
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		if e.Op == token.LAND || e.Op == token.LOR {
+			ops := logicalOperators(e.X)
+			ops = append(ops, e.Op.String())
+			return append(ops, logicalOperators(e.Y)...)
+		}
+	case *ast.ParenExpr:
+		return logicalOperators(e.X)
+	}
+	return nil
+}
+
+// countOperatorSequences 统计连续相同逻辑运算符组成的序列数，如 a && b || c 为2
+func countOperatorSequences(ops []string) int {
+	count := 0
+	for i, op := range ops {
+		if i == 0 || op != ops[i-1] {
+			count++
+		}
+	}
+	return count
+}
+
+// 文本估算使用的正则表达式
+var (
+	cognitiveElsePattern      = regexp.MustCompile(`\belse\s+if\b|\belif\b|\belsif\b|\belse\b`)
+	cognitiveStructurePattern = regexp.MustCompile(`\b(?:if|for|foreach|while|switch|match|catch|except)\b|\s\?\s`)
+	cognitiveJumpPattern      = regexp.MustCompile(`\bgoto\s+\w+|\b(?:break|continue)\s+[A-Za-z_]\w*\s*;`)
+	cognitiveLogicalPattern   = regexp.MustCompile(`&&|\|\||\band\b|\bor\b`)
+	cognitiveNestingPattern   = regexp.MustCompile(`\b(?:if|elif|else|for|foreach|while|do|switch|match|catch|except|lambda|function|func|def)\b|=>`)
+)
+
+// textCognitiveComplexities 基于源码文本估算每个函数的认知复杂度，顺序与functions一致
+// 花括号语言按代码块栈计算嵌套，Python按缩进计算嵌套
+func textCognitiveComplexities(content []byte, functions []parser.Function, language common.LanguageType) []int {
+	lines := parser.StripComments(strings.Split(string(content), "\n"), language)
+
+	complexities := make([]int, len(functions))
+	for i, fn := range functions {
+		start := max(fn.StartLine, 1)
+		end := min(fn.EndLine, len(lines))
+		if start > end {
+			continue
+		}
+
+		body := lines[start-1 : end]
+		if language == common.Python {
+			complexities[i] = indentCognitiveComplexity(body, fn.Name)
+		} else {
+			complexities[i] = braceCognitiveComplexity(body, fn.Name)
+		}
+	}
+	return complexities
+}
+
+// recursionPattern 返回匹配对函数自身调用的正则
+func recursionPattern(name string) *regexp.Regexp {
+	if name == "" {
+		return nil
+	}
+	return regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\s*\(`)
+}
+
+// lineCognitiveIncrement 计算一行代码的认知复杂度增量，recursion为nil时不计递归
+func lineCognitiveIncrement(line string, nesting int, recursion *regexp.Regexp) int {
+	increment := 0
+
+	// else if / elif / else 只计1点，其中的if不再按结构计算
+	elseMatches := cognitiveElsePattern.FindAllString(line, -1)
+	increment += len(elseMatches)
+	structures := len(cognitiveStructurePattern.FindAllString(line, -1))
+	for _, match := range elseMatches {
+		if strings.HasPrefix(match, "else") && strings.HasSuffix(match, "if") {
+			structures--
+		}
+	}
+	increment += max(structures, 0) * (1 + nesting)
+
+	increment += len(cognitiveJumpPattern.FindAllString(line, -1))
+	increment += countOperatorSequences(cognitiveLogicalPattern.FindAllString(line, -1))
+
+	if recursion != nil {
+		increment += len(recursion.FindAllString(line, -1))
+	}
+
+	return increment
+}
+
+// braceCognitiveComplexity 估算花括号语言函数的认知复杂度
+// 由控制结构或闭包打开的代码块才增加嵌套层数，函数体本身不计
+func braceCognitiveComplexity(body []string, name string) int {
+	complexity := 0
+	var stack []bool // 每个未闭合代码块是否增加嵌套
+	bodyOpened := false
+	recursion := recursionPattern(name)
+
+	nesting := func() int {
+		count := 0
+		for _, nests := range stack {
+			if nests {
+				count++
+			}
+		}
+		return count
+	}
+
+	for _, line := range body {
+		trimmed := strings.TrimSpace(line)
+
+		// 行首的右花括号先出栈，使 "} else if" 按外层嵌套计算
+		for strings.HasPrefix(trimmed, "}") {
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			trimmed = strings.TrimSpace(trimmed[1:])
+		}
+
+		// 函数签名所在的行不计算增量
+		if bodyOpened {
+			complexity += lineCognitiveIncrement(trimmed, nesting(), recursion)
+		}
+
+		nests := cognitiveNestingPattern.MatchString(trimmed)
+		for _, ch := range trimmed {
+			switch ch {
+			case '{':
+				stack = append(stack, bodyOpened && nests)
+				bodyOpened = true
+			case '}':
+				if len(stack) > 0 {
+					stack = stack[:len(stack)-1]
+				}
+			}
+		}
+	}
+
+	return complexity
+}
+
+// indentCognitiveComplexity 估算Python函数的认知复杂度
+// 以冒号结尾的控制结构行打开一个缩进块，缩进回退时关闭
+func indentCognitiveComplexity(body []string, name string) int {
+	type block struct {
+		indent int
+		nests  bool
+	}
+
+	complexity := 0
+	var stack []block
+	recursion := recursionPattern(name)
+
+	for i, line := range body {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		indent := indentWidth(line)
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		// 第一个元素是函数定义本身，不计嵌套
+		if i > 0 {
+			nesting := 0
+			for _, b := range stack[min(1, len(stack)):] {
+				if b.nests {
+					nesting++
+				}
+			}
+			complexity += lineCognitiveIncrement(trimmed, nesting, recursion)
+		}
+
+		if strings.HasSuffix(trimmed, ":") {
+			stack = append(stack, block{indent: indent, nests: cognitiveNestingPattern.MatchString(trimmed)})
+		}
+	}
+
+	return complexity
+}
+
+// indentWidth 计算行首缩进宽度，制表符按4个空格计算
+func indentWidth(line string) int {
+	width := 0
+	for _, ch := range line {
+		switch ch {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
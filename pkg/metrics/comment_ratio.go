@@ -4,7 +4,7 @@ package metrics
 
 import (
 	"fmt"
-	"go/ast"
+	"strings"
 
 	"github.com/Done-0/fuck-u-code/pkg/common"
 	"github.com/Done-0/fuck-u-code/pkg/i18n"
@@ -39,9 +39,14 @@ func (m *CommentRatioMetric) SetTranslator(translator i18n.Translator) {
 
 // Analyze 实现指标接口分析方法
 func (m *CommentRatioMetric) Analyze(parseResult parser.ParseResult) MetricResult {
-	// 直接从解析结果获取注释信息
+	// 直接从解析结果获取注释信息，注释掉的代码不算注释
 	totalLines := parseResult.GetTotalLines()
+	commentedCode := m.commentedCode(parseResult)
 	commentLines := parseResult.GetCommentLines()
+	for _, block := range commentedCode {
+		commentLines -= block.Lines()
+	}
+	commentLines = max(commentLines, 0)
 
 	// 计算注释覆盖率
 	commentRatio := 0.0
@@ -54,6 +59,9 @@ func (m *CommentRatioMetric) Analyze(parseResult parser.ParseResult) MetricResul
 
 	// 生成问题报告
 	issues := m.generateIssues(parseResult, commentRatio)
+	for _, block := range commentedCode {
+		issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.commented_out_code"), block.StartLine, block.EndLine, block.Lines()))
+	}
 
 	return MetricResult{
 		Score:       score,
@@ -63,6 +71,14 @@ func (m *CommentRatioMetric) Analyze(parseResult parser.ParseResult) MetricResul
 	}
 }
 
+// commentedCode 获取文件中注释掉的代码，解析结果不支持时返回空
+func (m *CommentRatioMetric) commentedCode(parseResult parser.ParseResult) []parser.CommentedCode {
+	if source, ok := parseResult.(interface{ GetCommentedCode() []parser.CommentedCode }); ok {
+		return source.GetCommentedCode()
+	}
+	return nil
+}
+
 // generateIssues 生成注释问题报告
 func (m *CommentRatioMetric) generateIssues(parseResult parser.ParseResult, commentRatio float64) []string {
 	var issues []string
@@ -74,47 +90,80 @@ func (m *CommentRatioMetric) generateIssues(parseResult parser.ParseResult, comm
 		issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.comment_low"), commentRatio*100))
 	}
 
-	// 对于Go语言，检查导出函数/类型是否有注释
-	if parseResult.GetLanguage() == common.Go {
-		file, _, _ := ExtractGoAST(parseResult)
-		if file != nil {
-			m.checkGoExportedComments(file, &issues)
+	// 检查公开的函数、类型是否有文档注释，以及文档注释的质量
+	if source, ok := parseResult.(interface{ GetPublicAPI() []parser.APISymbol }); ok {
+		for _, symbol := range source.GetPublicAPI() {
+			issues = append(issues, m.checkDocComment(symbol, parseResult.GetLanguage())...)
 		}
 	}
 
 	return issues
 }
 
-// checkGoExportedComments 检查Go源码中导出的函数和类型是否有注释
-func (m *CommentRatioMetric) checkGoExportedComments(file *ast.File, issues *[]string) {
-	ast.Inspect(file, func(n ast.Node) bool {
-		switch node := n.(type) {
-		case *ast.FuncDecl:
-			if node.Name.IsExported() && !m.hasDocComment(node.Doc) {
-				*issues = append(*issues, fmt.Sprintf(
-					m.translator.Translate("issue.exported_func_no_comment"),
-					node.Name.Name,
-				))
-			}
-		case *ast.GenDecl:
-			for _, spec := range node.Specs {
-				if typeSpec, ok := spec.(*ast.TypeSpec); ok &&
-					typeSpec.Name.IsExported() &&
-					!m.hasDocComment(node.Doc) {
-					*issues = append(*issues, fmt.Sprintf(
-						m.translator.Translate("issue.exported_type_no_comment"),
-						typeSpec.Name.Name,
-					))
-				}
-			}
+// docStyles 各语言文档注释的写法
+var docStyles = map[common.LanguageType]string{
+	common.Python:     "docstring",
+	common.JavaScript: "JSDoc",
+	common.TypeScript: "TSDoc",
+	common.Java:       "Javadoc",
+	common.CSharp:     "XML",
+}
+
+// goDocArticles Go文档注释可以在名称前加的冠词
+var goDocArticles = map[string]bool{"A": true, "An": true, "The": true}
+
+// checkDocComment 检查公开API的文档注释
+// Go的文档注释应以名称开头，其他语言的文档注释说明了参数时，说明的参数应与实际参数一致
+func (m *CommentRatioMetric) checkDocComment(symbol parser.APISymbol, language common.LanguageType) []string {
+	doc := symbol.Doc
+	if doc == nil {
+		switch {
+		case language != common.Go:
+			return []string{fmt.Sprintf(m.translator.Translate("issue.public_api_no_doc"), symbol.Line, symbol.Name, docStyles[language])}
+		case symbol.Kind == parser.IdentType:
+			return []string{fmt.Sprintf(m.translator.Translate("issue.exported_type_no_comment"), symbol.Name)}
+		default:
+			return []string{fmt.Sprintf(m.translator.Translate("issue.exported_func_no_comment"), symbol.Name)}
+		}
+	}
+
+	if language == common.Go {
+		words := strings.Fields(doc.Text)
+		if len(words) > 1 && goDocArticles[words[0]] {
+			words = words[1:]
+		}
+		if len(words) == 0 || strings.TrimRight(words[0], ",.:;") != symbol.Name {
+			return []string{fmt.Sprintf(m.translator.Translate("issue.doc_comment_name"), symbol.Line, symbol.Name)}
 		}
-		return true
-	})
+		return nil
+	}
+
+	if len(doc.Params) == 0 || doc.Inherited || symbol.OpaqueParams {
+		return nil
+	}
+	var issues []string
+	if missing := subtractNames(symbol.Params, doc.Params); len(missing) > 0 {
+		issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.doc_param_missing"), symbol.Line, symbol.Name, strings.Join(missing, ", ")))
+	}
+	if unknown := subtractNames(doc.Params, symbol.Params); len(unknown) > 0 {
+		issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.doc_param_unknown"), symbol.Line, symbol.Name, strings.Join(unknown, ", ")))
+	}
+	return issues
 }
 
-// hasDocComment 检查是否有文档注释
-func (m *CommentRatioMetric) hasDocComment(commentGroup *ast.CommentGroup) bool {
-	return commentGroup != nil && len(commentGroup.List) > 0
+// subtractNames 返回在names中但不在exclude中的名称
+func subtractNames(names, exclude []string) []string {
+	excluded := make(map[string]bool, len(exclude))
+	for _, name := range exclude {
+		excluded[name] = true
+	}
+	var result []string
+	for _, name := range names {
+		if !excluded[name] {
+			result = append(result, name)
+		}
+	}
+	return result
 }
 
 // calculateScore 根据注释覆盖率计算得分
@@ -0,0 +1,81 @@
+// Package metrics 提供代码质量分析指标
+// 创建者：Done-0
+package metrics
+
+import (
+	"math"
+
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+	"github.com/Done-0/fuck-u-code/pkg/parser"
+	"github.com/Done-0/fuck-u-code/pkg/rules"
+)
+
+// RuleAware 依赖项目自定义规则的指标，没有配置规则时不参与评分
+type RuleAware interface {
+	// SetRules 设置自定义规则
+	SetRules(ruleSet *rules.Set)
+}
+
+// severityPenalty 各严重程度对得分的影响
+var severityPenalty = map[string]float64{
+	rules.SeverityError:   1.0,
+	rules.SeverityWarning: 0.5,
+	rules.SeverityInfo:    0.2,
+}
+
+// CustomRulesMetric 检查项目配置中声明的自定义规则
+type CustomRulesMetric struct {
+	*BaseMetric
+	translator i18n.Translator
+	ruleSet    *rules.Set
+}
+
+// NewCustomRulesMetric 创建自定义规则指标
+func NewCustomRulesMetric() *CustomRulesMetric {
+	translator := i18n.NewTranslator(i18n.ZhCN)
+	return &CustomRulesMetric{
+		BaseMetric: NewBaseMetric(
+			i18n.FormatKey("metric", "custom_rules"),
+			translator.Translate("metric.custom_rules.description"),
+			0.1,
+			nil,
+		),
+		translator: translator,
+	}
+}
+
+// SetTranslator 设置翻译器
+func (m *CustomRulesMetric) SetTranslator(translator i18n.Translator) {
+	m.translator = translator
+	m.name = translator.Translate(i18n.FormatKey("metric", "custom_rules"))
+	m.description = translator.Translate("metric.custom_rules.description")
+}
+
+// SetRules 设置自定义规则
+func (m *CustomRulesMetric) SetRules(ruleSet *rules.Set) {
+	m.ruleSet = ruleSet
+}
+
+// Analyze 实现指标接口分析方法
+func (m *CustomRulesMetric) Analyze(parseResult parser.ParseResult) MetricResult {
+	filePath, content := ExtractSource(parseResult)
+	violations := m.ruleSet.Check(filePath, parseResult.GetLanguage(), content)
+
+	var issues []string
+	penalty := 0.0
+	for _, v := range violations {
+		penalty += severityPenalty[v.Severity]
+		issues = append(issues, m.translator.Translate("metric.custom_rules.issue",
+			v.Category, v.Message, v.RuleID, m.translator.Translate("rules.severity."+v.Severity), v.Line))
+	}
+
+	// 每个严重问题扣0.2分，最多扣满
+	score := math.Min(1.0, penalty*0.2)
+
+	return MetricResult{
+		Score:       score,
+		Issues:      issues,
+		Description: m.Description(),
+		Weight:      m.Weight(),
+	}
+}
@@ -38,7 +38,7 @@ func (m *CyclomaticComplexityMetric) SetTranslator(translator i18n.Translator) {
 
 // Analyze 实现指标接口分析方法
 func (m *CyclomaticComplexityMetric) Analyze(parseResult parser.ParseResult) MetricResult {
-	file, _, content := ExtractGoAST(parseResult)
+	file, fileSet, content := ExtractGoAST(parseResult)
 
 	// 如果content为空，使用解析结果获取
 	if len(content) == 0 {
@@ -49,7 +49,7 @@ func (m *CyclomaticComplexityMetric) Analyze(parseResult parser.ParseResult) Met
 		content = []byte(contentStr)
 	}
 
-	score, issues := m.analyzeComplexity(file, content, parseResult)
+	score, issues := m.analyzeComplexity(file, fileSet, content, parseResult)
 
 	return MetricResult{
 		Score:       score,
@@ -60,16 +60,20 @@ func (m *CyclomaticComplexityMetric) Analyze(parseResult parser.ParseResult) Met
 }
 
 // analyzeComplexity 分析代码的循环复杂度
-func (m *CyclomaticComplexityMetric) analyzeComplexity(file *ast.File, content []byte, parseResult parser.ParseResult) (float64, []string) {
+func (m *CyclomaticComplexityMetric) analyzeComplexity(file *ast.File, fileSet *token.FileSet, content []byte, parseResult parser.ParseResult) (float64, []string) {
 	var issues []string
 	funcCount := 0
 	totalComplexity := 0
 
-	// 对于Go语言使用AST分析
+	// 对于Go语言使用AST分析，只统计解析结果中的函数，被抑制的函数已从中去掉
 	if file != nil {
+		included := make(map[int]bool)
+		for _, fn := range parseResult.GetFunctions() {
+			included[fn.StartLine] = true
+		}
 		ast.Inspect(file, func(n ast.Node) bool {
 			funcDecl, ok := n.(*ast.FuncDecl)
-			if !ok {
+			if !ok || !included[fileSet.Position(funcDecl.Pos()).Line] {
 				return true
 			}
 
@@ -0,0 +1,93 @@
+// Package metrics 提供代码质量分析指标
+// 创建者：Done-0
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/Done-0/fuck-u-code/pkg/debt"
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+	"github.com/Done-0/fuck-u-code/pkg/parser"
+)
+
+// DebtAware 依赖技术债标记的指标，标记由分析器按项目配置的工单号格式提取
+type DebtAware interface {
+	// SetMarkers 设置当前文件中的技术债标记
+	SetMarkers(markers []debt.Marker)
+}
+
+// debtKindWeights 各类标记的扣分权重，没有关联工单的标记加倍
+var debtKindWeights = map[debt.Kind]float64{
+	debt.Fixme:      1.0,
+	debt.Hack:       1.0,
+	debt.XXX:        1.0,
+	debt.Todo:       0.5,
+	debt.Deprecated: 0.25,
+}
+
+// 技术债得分
+const (
+	debtUntrackedFactor = 2.0  // 没有关联工单的标记权重倍数
+	debtMinLines        = 100  // 计算密度时文件行数的下限，避免小文件中一个标记就满分
+	debtFullScore       = 10.0 // 每100行的加权标记数达到该值时得满分
+)
+
+// DebtMarkersMetric 统计注释中的 TODO、FIXME、HACK、XXX 和 @deprecated 标记
+type DebtMarkersMetric struct {
+	*BaseMetric
+	translator i18n.Translator
+	markers    []debt.Marker
+}
+
+// NewDebtMarkersMetric 创建技术债标记指标
+func NewDebtMarkersMetric() *DebtMarkersMetric {
+	translator := i18n.NewTranslator(i18n.ZhCN)
+	return &DebtMarkersMetric{
+		BaseMetric: NewBaseMetric(
+			i18n.FormatKey("metric", "debt_markers"),
+			translator.Translate("metric.debt_markers.description"),
+			0.1,
+			nil, // 支持所有语言
+		),
+		translator: translator,
+	}
+}
+
+// SetTranslator 设置翻译器
+func (m *DebtMarkersMetric) SetTranslator(translator i18n.Translator) {
+	m.translator = translator
+	m.name = translator.Translate(i18n.FormatKey("metric", "debt_markers"))
+	m.description = translator.Translate("metric.debt_markers.description")
+}
+
+// SetMarkers 设置当前文件中的技术债标记
+func (m *DebtMarkersMetric) SetMarkers(markers []debt.Marker) {
+	m.markers = markers
+}
+
+// Analyze 实现指标接口分析方法
+// 得分按每100行的加权标记数计算，FIXME、HACK、XXX比TODO更严重，没有关联工单的标记加倍
+func (m *DebtMarkersMetric) Analyze(parseResult parser.ParseResult) MetricResult {
+	issues := []string{}
+	weighted := 0.0
+	for _, marker := range m.markers {
+		weight := debtKindWeights[marker.Kind]
+		if marker.Tracked() {
+			issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.debt_marker"), marker.Line, marker.Kind, marker.Ticket, marker.Text))
+		} else {
+			weight *= debtUntrackedFactor
+			issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.debt_marker_untracked"), marker.Line, marker.Kind, marker.Text))
+		}
+		weighted += weight
+	}
+
+	lines := max(parseResult.GetTotalLines(), debtMinLines)
+	score := min(weighted*100/float64(lines)/debtFullScore, 1.0)
+
+	return MetricResult{
+		Score:       score,
+		Issues:      issues,
+		Description: m.Description(),
+		Weight:      m.Weight(),
+	}
+}
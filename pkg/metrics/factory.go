@@ -25,17 +25,55 @@ func (f *MetricFactory) SetTranslator(translator i18n.Translator) {
 	f.translator = translator
 }
 
-// CreateAllMetrics 创建所有指标
+// init 注册内置指标
+func init() {
+	builtin := []MetricInfo{
+		{Key: "cyclomatic_complexity", Weight: 0.3, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateCyclomaticComplexity() }},
+		{Key: "cognitive_complexity", Weight: 0.25, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateCognitiveComplexity() }},
+		{Key: "maintainability_index", Weight: 0.15, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateMaintainability() }},
+		{Key: "function_length", Weight: 0.2, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateFunctionLength() }},
+		{Key: "state_management", Weight: 0.1, Languages: []common.LanguageType{common.Go, common.Python, common.Java, common.CSharp, common.JavaScript, common.TypeScript}, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateStateManagement() }},
+		{Key: "class_cohesion", Weight: 0.1, Languages: []common.LanguageType{common.Go, common.Java, common.CSharp, common.Python, common.JavaScript, common.TypeScript}, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateClassCohesion() }},
+		{Key: "comment_ratio", Weight: 0.15, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateCommentRatio() }},
+		{Key: "error_handling", Weight: 0.1, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateErrorHandling() }},
+		{Key: "naming_convention", Weight: 0.08, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateNamingConvention() }},
+		{Key: "code_duplication", Weight: 0.15, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateCodeDuplication() }},
+		{Key: "structure_analysis", Weight: 0.15, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateStructureAnalysis() }},
+		{Key: "untested_complexity", Weight: 0.15, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateUntestedComplexity() }},
+		{Key: "test_presence", Weight: 0.1, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateTestPresence() }},
+		{Key: "debt_markers", Weight: 0.1, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateDebtMarkers() }},
+		{Key: "custom_rules", Weight: 0.1, New: func(t i18n.Translator) Metric { return NewMetricFactory(t).CreateCustomRules() }},
+	}
+
+	for _, info := range builtin {
+		MustRegister(info)
+	}
+}
+
+// CreateAllMetrics 创建所有默认启用的指标
 func (f *MetricFactory) CreateAllMetrics() []Metric {
-	return []Metric{
-		f.CreateCyclomaticComplexity(),
-		f.CreateFunctionLength(),
-		f.CreateCommentRatio(),
-		f.CreateErrorHandling(),
-		f.CreateNamingConvention(),
-		f.CreateCodeDuplication(),
-		f.CreateStructureAnalysis(),
+	keys := DefaultKeys()
+	metrics := make([]Metric, 0, len(keys))
+	for _, key := range keys {
+		if metric, ok := f.CreateMetric(key); ok {
+			metrics = append(metrics, metric)
+		}
 	}
+	return metrics
+}
+
+// CreateMetric 根据指标键创建已注册的指标，键不存在时返回false
+func (f *MetricFactory) CreateMetric(key string) (Metric, bool) {
+	info, ok := Lookup(key)
+	if !ok {
+		return nil, false
+	}
+
+	translator := f.translator
+	if translator == nil {
+		translator = i18n.NewTranslator(i18n.ZhCN)
+	}
+	return info.New(translator), true
 }
 
 // CreateCyclomaticComplexity 创建循环复杂度指标
@@ -47,6 +85,24 @@ func (f *MetricFactory) CreateCyclomaticComplexity() Metric {
 	return metric
 }
 
+// CreateCognitiveComplexity 创建认知复杂度指标
+func (f *MetricFactory) CreateCognitiveComplexity() Metric {
+	metric := NewCognitiveComplexityMetric()
+	if f.translator != nil {
+		metric.SetTranslator(f.translator)
+	}
+	return metric
+}
+
+// CreateMaintainability 创建可维护性指数指标
+func (f *MetricFactory) CreateMaintainability() Metric {
+	metric := NewMaintainabilityMetric()
+	if f.translator != nil {
+		metric.SetTranslator(f.translator)
+	}
+	return metric
+}
+
 // CreateFunctionLength 创建函数长度指标
 func (f *MetricFactory) CreateFunctionLength() Metric {
 	metric := NewFunctionLengthMetric()
@@ -56,6 +112,24 @@ func (f *MetricFactory) CreateFunctionLength() Metric {
 	return metric
 }
 
+// CreateStateManagement 创建状态管理指标
+func (f *MetricFactory) CreateStateManagement() Metric {
+	metric := NewStateManagementMetric()
+	if f.translator != nil {
+		metric.SetTranslator(f.translator)
+	}
+	return metric
+}
+
+// CreateClassCohesion 创建类内聚度指标
+func (f *MetricFactory) CreateClassCohesion() Metric {
+	metric := NewClassCohesionMetric()
+	if f.translator != nil {
+		metric.SetTranslator(f.translator)
+	}
+	return metric
+}
+
 // CreateCommentRatio 创建注释覆盖率指标
 func (f *MetricFactory) CreateCommentRatio() Metric {
 	metric := NewCommentRatioMetric()
@@ -89,6 +163,42 @@ func (f *MetricFactory) CreateStructureAnalysis() Metric {
 	return f.createSimpleMetric("structure_analysis", 0.15)
 }
 
+// CreateUntestedComplexity 创建未测试复杂度指标
+func (f *MetricFactory) CreateUntestedComplexity() Metric {
+	metric := NewUntestedComplexityMetric()
+	if f.translator != nil {
+		metric.SetTranslator(f.translator)
+	}
+	return metric
+}
+
+// CreateTestPresence 创建测试存在性指标
+func (f *MetricFactory) CreateTestPresence() Metric {
+	metric := NewTestPresenceMetric()
+	if f.translator != nil {
+		metric.SetTranslator(f.translator)
+	}
+	return metric
+}
+
+// CreateDebtMarkers 创建技术债标记指标
+func (f *MetricFactory) CreateDebtMarkers() Metric {
+	metric := NewDebtMarkersMetric()
+	if f.translator != nil {
+		metric.SetTranslator(f.translator)
+	}
+	return metric
+}
+
+// CreateCustomRules 创建自定义规则指标
+func (f *MetricFactory) CreateCustomRules() Metric {
+	metric := NewCustomRulesMetric()
+	if f.translator != nil {
+		metric.SetTranslator(f.translator)
+	}
+	return metric
+}
+
 // createSimpleMetric 创建简单指标通用方法
 func (f *MetricFactory) createSimpleMetric(metricKey string, weight float64) Metric {
 	metric := &SimpleMetric{
@@ -0,0 +1,212 @@
+// Package metrics 提供代码质量分析指标
+// 创建者：Done-0
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+	"github.com/Done-0/fuck-u-code/pkg/parser"
+)
+
+// 可维护性指数阈值，与Visual Studio的划分一致
+const (
+	maintainabilityLowThreshold      = 20.0 // 低于时建议重构
+	maintainabilityCriticalThreshold = 10.0 // 低于时难以维护
+	maintainabilityGoodThreshold     = 60.0 // 高于时不扣分
+)
+
+// Halstead Halstead度量的基础计数
+type Halstead struct {
+	DistinctOperators int // 不同操作符数 n1
+	DistinctOperands  int // 不同操作数数 n2
+	TotalOperators    int // 操作符总数 N1
+	TotalOperands     int // 操作数总数 N2
+}
+
+// NewHalstead 统计词法单元中的操作符和操作数
+func NewHalstead(tokens []parser.Token) Halstead {
+	operators := make(map[string]bool)
+	operands := make(map[string]bool)
+	var h Halstead
+	for _, tok := range tokens {
+		if tok.Kind == parser.TokenOperator {
+			operators[tok.Text] = true
+			h.TotalOperators++
+		} else {
+			operands[tok.Text] = true
+			h.TotalOperands++
+		}
+	}
+	h.DistinctOperators = len(operators)
+	h.DistinctOperands = len(operands)
+	return h
+}
+
+// Vocabulary 词汇量 n = n1 + n2
+func (h Halstead) Vocabulary() int {
+	return h.DistinctOperators + h.DistinctOperands
+}
+
+// Length 程序长度 N = N1 + N2
+func (h Halstead) Length() int {
+	return h.TotalOperators + h.TotalOperands
+}
+
+// Volume 体积 V = N * log2(n)
+func (h Halstead) Volume() float64 {
+	if h.Vocabulary() < 2 {
+		return 0
+	}
+	return float64(h.Length()) * math.Log2(float64(h.Vocabulary()))
+}
+
+// Difficulty 难度 D = n1/2 * N2/n2
+func (h Halstead) Difficulty() float64 {
+	if h.DistinctOperands == 0 {
+		return 0
+	}
+	return float64(h.DistinctOperators) / 2 * float64(h.TotalOperands) / float64(h.DistinctOperands)
+}
+
+// Effort 工作量 E = D * V
+func (h Halstead) Effort() float64 {
+	return h.Difficulty() * h.Volume()
+}
+
+// Bugs 估计缺陷数 B = V / 3000
+func (h Halstead) Bugs() float64 {
+	return h.Volume() / 3000
+}
+
+// MaintainabilityIndex 计算归一化到0-100的可维护性指数，越高越好
+// MI = max(0, (171 - 5.2*ln(V) - 0.23*CC - 16.2*ln(LOC)) * 100 / 171)
+func MaintainabilityIndex(volume float64, complexity, lines int) float64 {
+	mi := 171 - 0.23*float64(complexity)
+	if volume > 0 {
+		mi -= 5.2 * math.Log(volume)
+	}
+	if lines > 0 {
+		mi -= 16.2 * math.Log(float64(lines))
+	}
+	return math.Max(0, math.Min(100, mi*100/171))
+}
+
+// FunctionMaintainability 函数的Halstead度量和可维护性指数
+type FunctionMaintainability struct {
+	Halstead
+	MaintainabilityIndex float64 // 可维护性指数
+}
+
+// parseTokens 获取解析结果的词法单元
+func parseTokens(parseResult parser.ParseResult) []parser.Token {
+	if source, ok := parseResult.(interface{ GetTokens() []parser.Token }); ok {
+		return source.GetTokens()
+	}
+	_, content := ExtractSource(parseResult)
+	return parser.Tokenize(content, parseResult.GetLanguage())
+}
+
+// AnalyzeFunctionMaintainability 计算每个函数的Halstead度量和可维护性指数，顺序与GetFunctions一致
+func AnalyzeFunctionMaintainability(parseResult parser.ParseResult) []FunctionMaintainability {
+	functions := parseResult.GetFunctions()
+	if len(functions) == 0 {
+		return nil
+	}
+
+	tokens := parseTokens(parseResult)
+	results := make([]FunctionMaintainability, len(functions))
+	for i, fn := range functions {
+		// 词法单元按行排列，二分查找函数所在的区间
+		start := sort.Search(len(tokens), func(i int) bool { return tokens[i].Line >= fn.StartLine })
+		end := sort.Search(len(tokens), func(i int) bool { return tokens[i].Line > fn.EndLine })
+
+		h := NewHalstead(tokens[start:max(start, end)])
+		results[i] = FunctionMaintainability{
+			Halstead:             h,
+			MaintainabilityIndex: MaintainabilityIndex(h.Volume(), fn.Complexity, max(fn.EndLine-fn.StartLine+1, 1)),
+		}
+	}
+	return results
+}
+
+// FileMaintainabilityIndex 计算文件的可维护性指数
+// 经典公式对整个大文件计算时几乎总是0，因此取各函数的平均值，没有函数时才按整个文件计算
+func FileMaintainabilityIndex(parseResult parser.ParseResult) float64 {
+	if results := AnalyzeFunctionMaintainability(parseResult); len(results) > 0 {
+		total := 0.0
+		for _, r := range results {
+			total += r.MaintainabilityIndex
+		}
+		return total / float64(len(results))
+	}
+
+	h := NewHalstead(parseTokens(parseResult))
+	return MaintainabilityIndex(h.Volume(), 1, parseResult.GetTotalLines())
+}
+
+// MaintainabilityMetric 基于Halstead体积、圈复杂度和代码行数计算可维护性指数
+type MaintainabilityMetric struct {
+	*BaseMetric
+	translator i18n.Translator
+}
+
+// NewMaintainabilityMetric 创建可维护性指数指标
+func NewMaintainabilityMetric() Metric {
+	translator := i18n.NewTranslator(i18n.ZhCN)
+	return &MaintainabilityMetric{
+		BaseMetric: NewBaseMetric(
+			i18n.FormatKey("metric", "maintainability_index"),
+			translator.Translate("metric.maintainability_index.description"),
+			0.15,
+			nil, // 支持所有语言
+		),
+		translator: translator,
+	}
+}
+
+// SetTranslator 设置翻译器
+func (m *MaintainabilityMetric) SetTranslator(translator i18n.Translator) {
+	m.translator = translator
+	m.name = translator.Translate(i18n.FormatKey("metric", "maintainability_index"))
+	m.description = translator.Translate("metric.maintainability_index.description")
+}
+
+// Analyze 实现指标接口分析方法
+// 得分由平均可维护性指数和低于阈值的函数比例各占一部分
+func (m *MaintainabilityMetric) Analyze(parseResult parser.ParseResult) MetricResult {
+	functions := parseResult.GetFunctions()
+	results := AnalyzeFunctionMaintainability(parseResult)
+
+	issues := []string{}
+	total, low := 0.0, 0
+	for i, fn := range functions {
+		mi := results[i].MaintainabilityIndex
+		total += mi
+
+		switch {
+		case mi < maintainabilityCriticalThreshold:
+			issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.critical_maintainability"), fn.Name, fn.StartLine, mi, results[i].Volume()))
+			low++
+		case mi < maintainabilityLowThreshold:
+			issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.low_maintainability"), fn.Name, fn.StartLine, mi, results[i].Volume()))
+			low++
+		}
+	}
+
+	score := 0.0
+	if len(functions) > 0 {
+		avg := total / float64(len(functions))
+		avgScore := math.Max(0, math.Min(1, (maintainabilityGoodThreshold-avg)/(maintainabilityGoodThreshold-maintainabilityCriticalThreshold)))
+		score = avgScore*0.6 + float64(low)/float64(len(functions))*0.4
+	}
+
+	return MetricResult{
+		Score:       score,
+		Issues:      issues,
+		Description: m.Description(),
+		Weight:      m.Weight(),
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"go/token"
 
 	"github.com/Done-0/fuck-u-code/pkg/common"
+	"github.com/Done-0/fuck-u-code/pkg/coverage"
+	"github.com/Done-0/fuck-u-code/pkg/debt"
 	"github.com/Done-0/fuck-u-code/pkg/i18n"
 	"github.com/Done-0/fuck-u-code/pkg/parser"
 )
@@ -50,6 +52,15 @@ type AnalysisResult struct {
 	Functions     []parser.Function       // 函数列表
 	Language      common.LanguageType     // 语言类型
 	ParseResult   parser.ParseResult      // 解析结果
+	Suppressed    []SuppressedIssue       // 被抑制注释忽略的问题
+	Coverage      *coverage.File          // 测试覆盖率，没有加载该语言的覆盖率时为nil
+	Markers       []debt.Marker           // 注释中的技术债标记
+}
+
+// SuppressedIssue 被抑制注释忽略的问题
+type SuppressedIssue struct {
+	Metric string // 指标名称
+	Issue  string // 问题描述
 }
 
 // GetOverallScore 获取总体评分
@@ -88,6 +99,13 @@ func (r *AnalysisResult) AddMetricResult(name string, result MetricResult) {
 	r.MetricResults[name] = result
 }
 
+// AddSuppressedIssues 记录被抑制的问题
+func (r *AnalysisResult) AddSuppressedIssues(metricName string, issues []string) {
+	for _, issue := range issues {
+		r.Suppressed = append(r.Suppressed, SuppressedIssue{Metric: metricName, Issue: issue})
+	}
+}
+
 // GetIssues 获取所有问题
 func (r *AnalysisResult) GetIssues() []string {
 	issues := make([]string, 0, len(r.MetricResults)*2)
@@ -166,6 +184,18 @@ func NewAnalysisResult(filePath string, parseResult parser.ParseResult) *Analysi
 	}
 }
 
+// ExtractSource 从解析结果中提取文件路径和源码内容
+func ExtractSource(parseResult parser.ParseResult) (string, []byte) {
+	provider, ok := parseResult.(interface {
+		GetFilePath() string
+		GetSource() []byte
+	})
+	if !ok {
+		return "", nil
+	}
+	return provider.GetFilePath(), provider.GetSource()
+}
+
 // ExtractGoAST 从解析结果中提取Go语言的AST信息
 func ExtractGoAST(parseResult parser.ParseResult) (*ast.File, *token.FileSet, []byte) {
 	// 检查是否为Go语言
@@ -185,14 +215,12 @@ func ExtractGoAST(parseResult parser.ParseResult) (*ast.File, *token.FileSet, []
 		return nil, nil, nil
 	}
 
-	// 创建文件集
-	fileSet := token.NewFileSet()
-
-	// 获取源代码内容
-	var content []byte
-	if contentProvider, ok := parseResult.(interface{ GetContent() []byte }); ok {
-		content = contentProvider.GetContent()
+	// 使用解析时的文件集，AST节点的位置才能对应到源码行
+	provider, ok := parseResult.(interface{ GetFileSet() *token.FileSet })
+	if !ok || provider.GetFileSet() == nil {
+		return nil, nil, nil
 	}
 
-	return file, fileSet, content
+	_, content := ExtractSource(parseResult)
+	return file, provider.GetFileSet(), content
 }
@@ -2,8 +2,7 @@ package metrics
 
 import (
 	"fmt"
-	"go/ast"
-	"go/token"
+	"math"
 	"strings"
 	"unicode"
 
@@ -12,10 +11,147 @@ import (
 	"github.com/Done-0/fuck-u-code/pkg/parser"
 )
 
+// NamingAware 支持按项目配置覆盖命名风格的指标
+type NamingAware interface {
+	// SetNamingStyles 设置项目配置的命名风格
+	SetNamingStyles(styles NamingStyles)
+}
+
+// NamingStyle 命名风格
+type NamingStyle string
+
+// 支持的命名风格
+const (
+	StyleSnakeCase      NamingStyle = "snake_case"
+	StyleCamelCase      NamingStyle = "camelCase"
+	StylePascalCase     NamingStyle = "PascalCase"
+	StyleUpperSnakeCase NamingStyle = "UPPER_SNAKE_CASE"
+	StyleLowerCase      NamingStyle = "lowercase"
+)
+
+// namingCheckers 各命名风格的检查函数
+var namingCheckers = map[NamingStyle]func(string) bool{
+	StyleSnakeCase:      isSnakeCase,
+	StyleCamelCase:      isCamelCase,
+	StylePascalCase:     isPascalCase,
+	StyleUpperSnakeCase: isUpperSnakeCase,
+	StyleLowerCase:      isLowerCase,
+}
+
+// NamingStyles 各语言中每类标识符允许的命名风格，满足其一即可
+type NamingStyles map[common.LanguageType]map[parser.IdentifierKind][]NamingStyle
+
+// defaultNamingStyles 各语言社区通行的命名规范
+var defaultNamingStyles = NamingStyles{
+	common.Go: {
+		parser.IdentPackage:  {StyleLowerCase},
+		parser.IdentType:     {StyleCamelCase, StylePascalCase},
+		parser.IdentFunction: {StyleCamelCase, StylePascalCase},
+		parser.IdentMethod:   {StyleCamelCase, StylePascalCase},
+		parser.IdentVariable: {StyleCamelCase, StylePascalCase},
+		parser.IdentConstant: {StyleCamelCase, StylePascalCase, StyleUpperSnakeCase},
+	},
+	// PEP 8
+	common.Python: {
+		parser.IdentType:     {StylePascalCase},
+		parser.IdentFunction: {StyleSnakeCase},
+		parser.IdentMethod:   {StyleSnakeCase},
+		parser.IdentVariable: {StyleSnakeCase},
+		parser.IdentConstant: {StyleUpperSnakeCase, StyleSnakeCase},
+	},
+	common.Java: {
+		parser.IdentPackage:  {StyleLowerCase},
+		parser.IdentType:     {StylePascalCase},
+		parser.IdentFunction: {StyleCamelCase},
+		parser.IdentMethod:   {StyleCamelCase},
+		parser.IdentVariable: {StyleCamelCase},
+		parser.IdentConstant: {StyleUpperSnakeCase},
+	},
+	common.CSharp: {
+		parser.IdentPackage:  {StylePascalCase},
+		parser.IdentType:     {StylePascalCase},
+		parser.IdentFunction: {StylePascalCase, StyleCamelCase},
+		parser.IdentMethod:   {StylePascalCase, StyleCamelCase},
+		parser.IdentVariable: {StyleCamelCase, StylePascalCase},
+		parser.IdentConstant: {StylePascalCase, StyleUpperSnakeCase},
+	},
+	// 函数可以是PascalCase的组件
+	common.JavaScript: {
+		parser.IdentType:     {StylePascalCase},
+		parser.IdentFunction: {StyleCamelCase, StylePascalCase},
+		parser.IdentMethod:   {StyleCamelCase},
+		parser.IdentVariable: {StyleCamelCase},
+		parser.IdentConstant: {StyleCamelCase, StylePascalCase, StyleUpperSnakeCase},
+	},
+	common.TypeScript: {
+		parser.IdentType:     {StylePascalCase},
+		parser.IdentFunction: {StyleCamelCase, StylePascalCase},
+		parser.IdentMethod:   {StyleCamelCase},
+		parser.IdentVariable: {StyleCamelCase},
+		parser.IdentConstant: {StyleCamelCase, StylePascalCase, StyleUpperSnakeCase},
+	},
+	// C和C++项目风格差异大，默认较宽松，可通过配置收紧
+	common.C: {
+		parser.IdentType:     {StyleSnakeCase, StylePascalCase},
+		parser.IdentFunction: {StyleSnakeCase, StyleCamelCase},
+		parser.IdentMethod:   {StyleSnakeCase, StyleCamelCase},
+		parser.IdentVariable: {StyleSnakeCase, StyleCamelCase},
+		parser.IdentConstant: {StyleUpperSnakeCase},
+	},
+	common.CPlusPlus: {
+		parser.IdentType:     {StylePascalCase, StyleSnakeCase},
+		parser.IdentFunction: {StyleSnakeCase, StyleCamelCase, StylePascalCase},
+		parser.IdentMethod:   {StyleSnakeCase, StyleCamelCase, StylePascalCase},
+		parser.IdentVariable: {StyleSnakeCase, StyleCamelCase},
+		parser.IdentConstant: {StyleUpperSnakeCase, StylePascalCase, StyleCamelCase},
+	},
+}
+
+// ParseNamingStyles 解析配置中的命名风格，格式为 语言 -> 标识符类别 -> 风格，多个风格用"|"分隔
+func ParseNamingStyles(config map[string]map[string]string) (NamingStyles, error) {
+	languages := make(map[common.LanguageType]bool)
+	for language := range defaultNamingStyles {
+		languages[language] = true
+	}
+	kinds := make(map[parser.IdentifierKind]bool)
+	for _, kind := range parser.IdentifierKinds() {
+		kinds[kind] = true
+	}
+
+	styles := make(NamingStyles, len(config))
+	for languageName, kindStyles := range config {
+		language := common.LanguageType(strings.ToLower(languageName))
+		if !languages[language] {
+			return nil, fmt.Errorf("未知的命名规范语言: %s", languageName)
+		}
+
+		styles[language] = make(map[parser.IdentifierKind][]NamingStyle, len(kindStyles))
+		for kindName, value := range kindStyles {
+			kind := parser.IdentifierKind(strings.ToLower(kindName))
+			if !kinds[kind] {
+				return nil, fmt.Errorf("未知的标识符类别: %s", kindName)
+			}
+
+			var allowed []NamingStyle
+			for _, name := range strings.Split(value, "|") {
+				style := NamingStyle(strings.TrimSpace(name))
+				if _, ok := namingCheckers[style]; !ok {
+					return nil, fmt.Errorf("未知的命名风格: %s", name)
+				}
+				allowed = append(allowed, style)
+			}
+			styles[language][kind] = allowed
+		}
+	}
+
+	return styles, nil
+}
+
 // NamingConventionMetric 检测命名规范
 type NamingConventionMetric struct {
 	*BaseMetric
 	translator i18n.Translator
+	styles     NamingStyles // 项目配置覆盖的命名风格
 }
 
 // NewNamingConventionMetric 创建命名规范指标
@@ -23,10 +159,11 @@ func NewNamingConventionMetric() *NamingConventionMetric {
 	return &NamingConventionMetric{
 		BaseMetric: NewBaseMetric(
 			"命名规范",
-			"检查代码中的命名是否符合规范，包括包名、变量名、函数名、类型名等",
+			"检查代码中的命名是否符合所用语言的规范，包括包名、类型名、函数名、变量名和常量名",
 			0.08,
-			[]common.LanguageType{common.Go},
+			nil,
 		),
+		translator: i18n.NewTranslator(i18n.ZhCN),
 	}
 }
 
@@ -35,22 +172,23 @@ func (m *NamingConventionMetric) SetTranslator(translator i18n.Translator) {
 	m.translator = translator
 	if translator != nil {
 		m.name = translator.Translate(i18n.FormatKey("metric", "naming_convention"))
+		m.description = translator.Translate("metric.naming_convention.description")
 	}
 }
 
+// SetNamingStyles 设置项目配置的命名风格
+func (m *NamingConventionMetric) SetNamingStyles(styles NamingStyles) {
+	m.styles = styles
+}
+
 // Analyze 实现指标接口分析方法
 func (m *NamingConventionMetric) Analyze(parseResult parser.ParseResult) MetricResult {
-	file, _, _ := ExtractGoAST(parseResult)
-	if file == nil {
-		return MetricResult{
-			Score:       0.0,
-			Issues:      []string{},
-			Description: m.Description(),
-			Weight:      m.Weight(),
-		}
+	var identifiers []parser.Identifier
+	if source, ok := parseResult.(interface{ GetIdentifiers() []parser.Identifier }); ok {
+		identifiers = source.GetIdentifiers()
 	}
 
-	score, issues := m.analyzeNaming(file)
+	score, issues := m.analyzeNaming(parseResult.GetLanguage(), identifiers)
 
 	return MetricResult{
 		Score:       score,
@@ -60,136 +198,100 @@ func (m *NamingConventionMetric) Analyze(parseResult parser.ParseResult) MetricR
 	}
 }
 
-// analyzeNaming 分析命名规范
-func (m *NamingConventionMetric) analyzeNaming(file *ast.File) (float64, []string) {
-	var issues []string
-
-	// 统计各种命名问题
+// analyzeNaming 逐个检查标识符，同名同类的标识符只检查第一次声明
+func (m *NamingConventionMetric) analyzeNaming(language common.LanguageType, identifiers []parser.Identifier) (float64, []string) {
+	issues := []string{}
+	seen := make(map[string]bool)
 	badNames := 0
 	totalNames := 0
 
-	// 分析包名
-	if !m.isValidPackageName(file.Name.Name) {
-		issues = append(issues, fmt.Sprintf("包名 '%s' 不符合规范，应使用小写字母且不包含下划线", file.Name.Name))
-		badNames++
-	}
-	totalNames++
-
-	// 分析变量、常量、函数和类型名称
-	ast.Inspect(file, func(n ast.Node) bool {
-		switch node := n.(type) {
-		case *ast.FuncDecl:
-			totalNames++
-			if !m.isValidFuncName(node.Name.Name) {
-				issues = append(issues, fmt.Sprintf("函数名 '%s' 不符合规范", node.Name.Name))
-				badNames++
-			}
-
-		case *ast.TypeSpec:
-			totalNames++
-			if !m.isValidTypeName(node.Name.Name) {
-				issues = append(issues, fmt.Sprintf("类型名 '%s' 不符合规范", node.Name.Name))
-				badNames++
-			}
+	for _, ident := range identifiers {
+		key := string(ident.Kind) + ":" + ident.Name
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
 
-		case *ast.ValueSpec:
-			for _, name := range node.Names {
-				totalNames++
-				if name.Name == "_" {
-					continue
-				}
+		allowed := m.allowedStyles(language, ident.Kind)
+		if len(allowed) == 0 {
+			continue
+		}
 
-				// 检查是否是常量
-				if m.isConstDecl(n) {
-					if !m.isValidConstName(name.Name) {
-						issues = append(issues, fmt.Sprintf("常量名 '%s' 不符合规范", name.Name))
-						badNames++
-					}
-				} else {
-					// 变量名检查
-					if !m.isValidVarName(name.Name) {
-						issues = append(issues, fmt.Sprintf("变量名 '%s' 不符合规范", name.Name))
-						badNames++
-					}
-				}
-			}
+		totalNames++
+		if matchesNamingStyle(ident, allowed) {
+			continue
+		}
 
-		case *ast.AssignStmt:
-			// 检查短变量声明
-			if node.Tok == token.DEFINE {
-				for _, expr := range node.Lhs {
-					if ident, ok := expr.(*ast.Ident); ok {
-						if ident.Name != "_" {
-							totalNames++
-							if !m.isValidVarName(ident.Name) {
-								issues = append(issues, fmt.Sprintf("变量名 '%s' 不符合规范", ident.Name))
-								badNames++
-							}
-						}
-					}
-				}
-			}
+		badNames++
+		names := make([]string, len(allowed))
+		for i, style := range allowed {
+			names[i] = string(style)
 		}
-		return true
-	})
+		issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.naming_violation"),
+			m.translator.Translate("naming.kind."+string(ident.Kind)), ident.Name, ident.Line, strings.Join(names, " / ")))
+	}
 
 	// 如果没有名称，返回0分
 	if totalNames == 0 {
 		return 0.0, issues
 	}
 
-	// 计算命名规范得分
-	badRatio := float64(badNames) / float64(totalNames)
-	return m.calculateScore(badRatio), issues
-}
-
-// isConstDecl 检查是否是常量声明
-func (m *NamingConventionMetric) isConstDecl(node ast.Node) bool {
-	var isConst bool
-	ast.Inspect(node, func(n ast.Node) bool {
-		if genDecl, ok := n.(*ast.GenDecl); ok {
-			isConst = genDecl.Tok == token.CONST
-			return false
-		}
-		return true
-	})
-	return isConst
+	return m.calculateScore(float64(badNames) / float64(totalNames)), issues
 }
 
-// isValidPackageName 检查包名是否符合规范
-func (m *NamingConventionMetric) isValidPackageName(name string) bool {
-	// 包名应该是小写字母，不含下划线
-	return m.isLowerCase(name) && !strings.Contains(name, "_")
+// allowedStyles 返回某类标识符允许的命名风格，项目配置优先
+func (m *NamingConventionMetric) allowedStyles(language common.LanguageType, kind parser.IdentifierKind) []NamingStyle {
+	if styles, ok := m.styles[language][kind]; ok {
+		return styles
+	}
+	return defaultNamingStyles[language][kind]
 }
 
-// isValidVarName 检查变量名是否符合规范
-func (m *NamingConventionMetric) isValidVarName(name string) bool {
-	// 检查是否是有效的骆驼命名法
-	return m.isCamelCase(name) || m.isLowerCase(name)
-}
+// matchesNamingStyle 检查标识符是否符合任一允许的风格
+// 包名按"."分段检查，首尾的下划线和开头的$不参与检查
+func matchesNamingStyle(ident parser.Identifier, allowed []NamingStyle) bool {
+	parts := []string{ident.Name}
+	if ident.Kind == parser.IdentPackage {
+		parts = strings.Split(ident.Name, ".")
+	}
 
-// isValidFuncName 检查函数名是否符合规范
-func (m *NamingConventionMetric) isValidFuncName(name string) bool {
-	// 函数名应该是驼峰命名法
-	return m.isCamelCase(name)
-}
+	for _, part := range parts {
+		name := strings.Trim(strings.TrimPrefix(part, "$"), "_")
+		if name == "" {
+			continue
+		}
 
-// isValidTypeName 检查类型名是否符合规范
-func (m *NamingConventionMetric) isValidTypeName(name string) bool {
-	// 类型名应该是大写开头的驼峰命名法
-	return m.isPascalCase(name)
+		matched := false
+		for _, style := range allowed {
+			if namingCheckers[style](name) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
 }
 
-// isValidConstName 检查常量名是否符合规范
-func (m *NamingConventionMetric) isValidConstName(name string) bool {
-	// 常量名应该是全大写加下划线，或者驼峰命名法
-	return m.isUpperSnakeCase(name) || m.isPascalCase(name)
+// isLowerCase 检查是否只包含小写字母和数字
+func isLowerCase(name string) bool {
+	for _, r := range name {
+		if !unicode.IsLower(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
 }
 
-// isLowerCase 检查是否是小写字母
-func (m *NamingConventionMetric) isLowerCase(name string) bool {
+// isSnakeCase 检查是否是蛇形命名法（小写加下划线）
+func isSnakeCase(name string) bool {
+	if unicode.IsDigit([]rune(name)[0]) {
+		return false
+	}
 	for _, r := range name {
-		if !unicode.IsLower(r) && !unicode.IsDigit(r) {
+		if !unicode.IsLower(r) && !unicode.IsDigit(r) && r != '_' {
 			return false
 		}
 	}
@@ -197,8 +299,8 @@ func (m *NamingConventionMetric) isLowerCase(name string) bool {
 }
 
 // isCamelCase 检查是否是驼峰命名法（小写开头）
-func (m *NamingConventionMetric) isCamelCase(name string) bool {
-	if name == "" || strings.Contains(name, "_") || unicode.IsUpper([]rune(name)[0]) {
+func isCamelCase(name string) bool {
+	if name == "" || strings.Contains(name, "_") || !unicode.IsLower([]rune(name)[0]) {
 		return false
 	}
 
@@ -212,7 +314,7 @@ func (m *NamingConventionMetric) isCamelCase(name string) bool {
 }
 
 // isPascalCase 检查是否是帕斯卡命名法（大写开头）
-func (m *NamingConventionMetric) isPascalCase(name string) bool {
+func isPascalCase(name string) bool {
 	if name == "" || strings.Contains(name, "_") || !unicode.IsUpper([]rune(name)[0]) {
 		return false
 	}
@@ -227,7 +329,10 @@ func (m *NamingConventionMetric) isPascalCase(name string) bool {
 }
 
 // isUpperSnakeCase 检查是否是大写蛇形命名法
-func (m *NamingConventionMetric) isUpperSnakeCase(name string) bool {
+func isUpperSnakeCase(name string) bool {
+	if unicode.IsDigit([]rune(name)[0]) {
+		return false
+	}
 	for _, r := range name {
 		if !unicode.IsUpper(r) && !unicode.IsDigit(r) && r != '_' {
 			return false
@@ -236,18 +341,7 @@ func (m *NamingConventionMetric) isUpperSnakeCase(name string) bool {
 	return true
 }
 
-// calculateScore 根据不良命名比例计算得分
+// calculateScore 根据不良命名比例计算得分，四分之一的命名不合规即为满分
 func (m *NamingConventionMetric) calculateScore(badRatio float64) float64 {
-	// 础分0.4，每1%不良命名增加0.1分
-	baseScore := 0.4
-	increasePerPercent := 10.0 // 因为badRatio是0-1的比例
-
-	score := baseScore + (badRatio * increasePerPercent)
-
-	// 限制范围
-	if score > 1.0 {
-		return 1.0
-	}
-
-	return score
+	return math.Min(badRatio*4, 1.0)
 }
@@ -0,0 +1,107 @@
+// Package metrics 提供代码质量分析指标
+// 创建者：Done-0
+package metrics
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+)
+
+// MetricInfo 注册到指标注册表中的指标信息
+type MetricInfo struct {
+	Key               string                                  // 指标键，如 "cyclomatic_complexity"
+	NameKey           string                                  // 指标名称的翻译键
+	DescriptionKey    string                                  // 指标描述的翻译键
+	Weight            float64                                 // 默认权重，为0时使用指标Weight()返回的权重
+	Languages         []common.LanguageType                   // 支持的语言，为空表示支持所有语言
+	DisabledByDefault bool                                    // 是否默认禁用，需要显式启用
+	New               func(translator i18n.Translator) Metric // 创建指标实例
+}
+
+// 指标注册表
+var (
+	registryMu    sync.RWMutex
+	registry      = make(map[string]MetricInfo)
+	registryOrder []string
+)
+
+// Register 注册指标，应在分析开始前调用，键为空或重复时返回错误
+func Register(info MetricInfo) error {
+	if info.Key == "" || info.New == nil {
+		return fmt.Errorf("指标键和构造函数不能为空")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[info.Key]; exists {
+		return fmt.Errorf("指标 %s 已注册", info.Key)
+	}
+
+	if info.NameKey == "" {
+		info.NameKey = i18n.FormatKey("metric", info.Key)
+	}
+	if info.DescriptionKey == "" {
+		info.DescriptionKey = i18n.FormatKey("metric", info.Key, "description")
+	}
+
+	registry[info.Key] = info
+	registryOrder = append(registryOrder, info.Key)
+	return nil
+}
+
+// MustRegister 注册指标，失败时panic，用于包初始化
+func MustRegister(info MetricInfo) {
+	if err := Register(info); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup 根据键查找已注册的指标
+func Lookup(key string) (MetricInfo, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	info, ok := registry[key]
+	return info, ok
+}
+
+// Registered 按注册顺序返回所有已注册的指标
+func Registered() []MetricInfo {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	infos := make([]MetricInfo, 0, len(registryOrder))
+	for _, key := range registryOrder {
+		infos = append(infos, registry[key])
+	}
+	return infos
+}
+
+// DefaultKeys 按注册顺序返回默认启用的指标键
+func DefaultKeys() []string {
+	keys := make([]string, 0)
+	for _, info := range Registered() {
+		if !info.DisabledByDefault {
+			keys = append(keys, info.Key)
+		}
+	}
+	return keys
+}
+
+// SupportsLanguage 判断指标是否支持指定语言
+func (info MetricInfo) SupportsLanguage(language common.LanguageType) bool {
+	if len(info.Languages) == 0 {
+		return true
+	}
+
+	for _, lang := range info.Languages {
+		if lang == language {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,367 @@
+// Package metrics 提供代码质量分析指标
+// 创建者：Done-0
+package metrics
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+	"github.com/Done-0/fuck-u-code/pkg/parser"
+)
+
+// statePenalty 每处全局可变状态增加的得分，五处即为满分
+const statePenalty = 0.2
+
+// StateManagementMetric 检测全局可变状态
+// 包括Go的包级变量、Python的模块级变量和global声明、Java/C#的静态可变字段以及JS的顶层let/var
+type StateManagementMetric struct {
+	*BaseMetric
+	translator i18n.Translator
+}
+
+// NewStateManagementMetric 创建状态管理指标
+func NewStateManagementMetric() Metric {
+	translator := i18n.NewTranslator(i18n.ZhCN)
+	return &StateManagementMetric{
+		BaseMetric: NewBaseMetric(
+			i18n.FormatKey("metric", "state_management"),
+			translator.Translate("metric.state_management.description"),
+			0.1,
+			[]common.LanguageType{common.Go, common.Python, common.Java, common.CSharp, common.JavaScript, common.TypeScript},
+		),
+		translator: translator,
+	}
+}
+
+// SetTranslator 设置翻译器
+func (m *StateManagementMetric) SetTranslator(translator i18n.Translator) {
+	m.translator = translator
+	m.name = translator.Translate(i18n.FormatKey("metric", "state_management"))
+	m.description = translator.Translate("metric.state_management.description")
+}
+
+// Analyze 实现指标接口分析方法
+func (m *StateManagementMetric) Analyze(parseResult parser.ParseResult) MetricResult {
+	_, content := ExtractSource(parseResult)
+	lines := strings.Split(string(content), "\n")
+
+	var issues []string
+	switch parseResult.GetLanguage() {
+	case common.Go:
+		issues = m.analyzeGo(parseResult)
+	case common.Python:
+		issues = m.analyzePython(lines)
+	case common.Java, common.CSharp:
+		issues = m.analyzeStaticFields(lines, parseResult.GetLanguage())
+	case common.JavaScript, common.TypeScript:
+		issues = m.analyzeTopLevelBindings(lines, parseResult.GetLanguage())
+	}
+	if issues == nil {
+		issues = []string{}
+	}
+
+	return MetricResult{
+		Score:       math.Min(float64(len(issues))*statePenalty, 1.0),
+		Issues:      issues,
+		Description: m.Description(),
+		Weight:      m.Weight(),
+	}
+}
+
+// analyzeGo 检测包级可变变量以及函数中对它们的修改
+// 只有声明时未初始化或在函数中被修改的包级变量才视为可变，只读的查找表、哨兵错误和预编译正则不计入
+func (m *StateManagementMetric) analyzeGo(parseResult parser.ParseResult) []string {
+	file, fileSet, _ := ExtractGoAST(parseResult)
+	if file == nil {
+		return nil
+	}
+
+	// 收集包级变量
+	var globals []*ast.Ident
+	isGlobal := make(map[string]bool)
+	initialized := make(map[string]bool)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			if isImmutableGoInit(valueSpec) {
+				continue
+			}
+			for _, name := range valueSpec.Names {
+				if name.Name == "_" {
+					continue
+				}
+				globals = append(globals, name)
+				isGlobal[name.Name] = true
+				initialized[name.Name] = len(valueSpec.Values) > 0
+			}
+		}
+	}
+
+	// 检测函数对包级变量的修改，每个函数每个变量只报告一次
+	var writeIssues []string
+	written := make(map[string]bool)
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		locals := goLocalNames(funcDecl)
+		reported := make(map[string]bool)
+		report := func(expr ast.Expr) {
+			// cache[key] = v、config.Field = v 同样修改了全局状态
+			for {
+				if index, ok := expr.(*ast.IndexExpr); ok {
+					expr = index.X
+				} else if selector, ok := expr.(*ast.SelectorExpr); ok {
+					expr = selector.X
+				} else {
+					break
+				}
+			}
+			ident, ok := expr.(*ast.Ident)
+			if !ok || !isGlobal[ident.Name] || locals[ident.Name] || reported[ident.Name] {
+				return
+			}
+			reported[ident.Name] = true
+			written[ident.Name] = true
+			writeIssues = append(writeIssues, fmt.Sprintf(m.translator.Translate("issue.global_write"), funcDecl.Name.Name, fileSet.Position(ident.Pos()).Line, ident.Name))
+		}
+
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				if node.Tok != token.DEFINE {
+					for _, lhs := range node.Lhs {
+						report(lhs)
+					}
+				}
+			case *ast.IncDecStmt:
+				report(node.X)
+			}
+			return true
+		})
+	}
+
+	var issues []string
+	for _, name := range globals {
+		if initialized[name.Name] && !written[name.Name] {
+			continue
+		}
+		issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.global_variable"), name.Name, fileSet.Position(name.Pos()).Line))
+	}
+	return append(issues, writeIssues...)
+}
+
+// immutableGoInitializers 初始化后通常不再修改的包级变量构造函数
+var immutableGoInitializers = map[string]bool{
+	"errors.New":          true,
+	"fmt.Errorf":          true,
+	"regexp.MustCompile":  true,
+	"template.Must":       true,
+	"reflect.TypeOf":      true,
+	"sync.OnceFunc":       true,
+	"sync.OnceValue":      true,
+	"strings.NewReplacer": true,
+}
+
+// isImmutableGoInit 判断包级变量是否由不可变的构造函数初始化
+func isImmutableGoInit(spec *ast.ValueSpec) bool {
+	if len(spec.Values) == 0 {
+		return false
+	}
+	for _, value := range spec.Values {
+		call, ok := value.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		pkg, ok := selector.X.(*ast.Ident)
+		if !ok || !immutableGoInitializers[pkg.Name+"."+selector.Sel.Name] {
+			return false
+		}
+	}
+	return true
+}
+
+// goLocalNames 收集函数的参数、接收者和局部变量名，用于排除同名遮蔽
+func goLocalNames(funcDecl *ast.FuncDecl) map[string]bool {
+	locals := make(map[string]bool)
+	addFields := func(fields *ast.FieldList) {
+		if fields == nil {
+			return
+		}
+		for _, field := range fields.List {
+			for _, name := range field.Names {
+				locals[name.Name] = true
+			}
+		}
+	}
+	addFields(funcDecl.Recv)
+	addFields(funcDecl.Type.Params)
+	addFields(funcDecl.Type.Results)
+
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if node.Tok == token.DEFINE {
+				for _, lhs := range node.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						locals[ident.Name] = true
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for _, name := range node.Names {
+				locals[name.Name] = true
+			}
+		case *ast.RangeStmt:
+			for _, expr := range []ast.Expr{node.Key, node.Value} {
+				if ident, ok := expr.(*ast.Ident); ok && node.Tok == token.DEFINE {
+					locals[ident.Name] = true
+				}
+			}
+		}
+		return true
+	})
+
+	return locals
+}
+
+// Python状态检测使用的正则表达式
+var (
+	pyModuleAssignPattern    = regexp.MustCompile(`^([A-Za-z_]\w*)\s*(?::[^=]+)?=[^=]`)
+	pyModuleAugAssignPattern = regexp.MustCompile(`^([A-Za-z_]\w*)\s*(?:\+|-|\*\*?|//?|%|@|&|\||\^|<<|>>)=`)
+	pyGlobalPattern          = regexp.MustCompile(`^\s+global\s+([\w\s,]+)`)
+	pyFunctionPattern        = regexp.MustCompile(`^\s*(?:async\s+)?def\s+([A-Za-z_]\w*)`)
+)
+
+// analyzePython 检测会被修改的模块级变量和函数中的global声明
+// 与Go一致，只赋值一次的模块级变量（如 logger = logging.getLogger(...)）不计入，
+// 在模块级再次赋值或在函数中声明为global的才视为可变全局状态；
+// 全大写的模块级变量按约定视为常量，双下划线包围的模块属性不计入
+func (m *StateManagementMetric) analyzePython(lines []string) []string {
+	lines = parser.StripComments(lines, common.Python)
+
+	// 统计模块级变量的写入次数和函数中的global声明
+	writes := make(map[string]int)
+	declaredGlobal := make(map[string]bool)
+	for _, line := range lines {
+		if match := pyModuleAssignPattern.FindStringSubmatch(line); match != nil {
+			writes[match[1]]++
+		} else if match := pyModuleAugAssignPattern.FindStringSubmatch(line); match != nil {
+			writes[match[1]]++
+		} else if match := pyGlobalPattern.FindStringSubmatch(line); match != nil {
+			for _, name := range pyGlobalNames(match[1]) {
+				declaredGlobal[name] = true
+			}
+		}
+	}
+
+	var issues []string
+	seen := make(map[string]bool)
+	function := ""
+
+	for i, line := range lines {
+		if match := pyFunctionPattern.FindStringSubmatch(line); match != nil {
+			function = match[1]
+			continue
+		}
+
+		if match := pyModuleAssignPattern.FindStringSubmatch(line); match != nil {
+			name := match[1]
+			if seen[name] || strings.ToUpper(name) == name || (strings.HasPrefix(name, "__") && strings.HasSuffix(name, "__")) {
+				continue
+			}
+			seen[name] = true
+			if writes[name] > 1 || declaredGlobal[name] {
+				issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.global_variable"), name, i+1))
+			}
+			continue
+		}
+
+		if match := pyGlobalPattern.FindStringSubmatch(line); match != nil {
+			for _, name := range pyGlobalNames(match[1]) {
+				issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.global_write"), function, i+1, name))
+			}
+		}
+	}
+
+	return issues
+}
+
+// pyGlobalNames 拆分global声明中的变量名
+func pyGlobalNames(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Java/C#静态字段检测使用的正则表达式
+var (
+	staticPattern         = regexp.MustCompile(`\bstatic\b`)
+	staticExcludedPattern = regexp.MustCompile(`\b(?:final|readonly|const|class|interface|enum|record|struct|import|using|void)\b`)
+	fieldNamePattern      = regexp.MustCompile(`([A-Za-z_]\w*)\s*$`)
+)
+
+// analyzeStaticFields 检测Java和C#中非final/readonly/const的静态字段
+func (m *StateManagementMetric) analyzeStaticFields(lines []string, language common.LanguageType) []string {
+	var issues []string
+
+	for i, line := range parser.StripComments(lines, language) {
+		if !staticPattern.MatchString(line) || staticExcludedPattern.MatchString(line) {
+			continue
+		}
+
+		// 字段声明以 = 或 ; 结束，声明部分不含括号和代码块
+		end := strings.IndexAny(line, "=;")
+		if end == -1 || strings.ContainsAny(line[:end], "(){") {
+			continue
+		}
+		if match := fieldNamePattern.FindStringSubmatch(line[:end]); match != nil {
+			issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.static_mutable_field"), match[1], i+1))
+		}
+	}
+
+	return issues
+}
+
+// jsTopLevelBindingPattern JS顶层的let/var声明
+var jsTopLevelBindingPattern = regexp.MustCompile(`^\s*(?:export\s+)?(?:let|var)\s+([A-Za-z_$][\w$]*)`)
+
+// analyzeTopLevelBindings 检测JS/TS模块顶层的let和var声明
+func (m *StateManagementMetric) analyzeTopLevelBindings(lines []string, language common.LanguageType) []string {
+	var issues []string
+	depth := 0
+
+	for i, line := range parser.StripComments(lines, language) {
+		if depth == 0 {
+			if match := jsTopLevelBindingPattern.FindStringSubmatch(line); match != nil {
+				issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.global_variable"), match[1], i+1))
+			}
+		}
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		if depth < 0 {
+			depth = 0
+		}
+	}
+
+	return issues
+}
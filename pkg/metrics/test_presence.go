@@ -0,0 +1,98 @@
+// Package metrics 提供代码质量分析指标
+// 创建者：Done-0
+package metrics
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+	"github.com/Done-0/fuck-u-code/pkg/parser"
+)
+
+// TestAware 依赖测试文件匹配结果的指标，只在分析目录时参与评分
+type TestAware interface {
+	// SetTests 设置当前文件对应的测试
+	SetTests(tests *FileTests)
+}
+
+// FileTests 生产文件对应的测试
+type FileTests struct {
+	TestFiles     []string // 按命名匹配到的测试文件
+	PackageTested bool     // 所在包中有不对应具体文件的包级测试
+	NoAssertions  []string // 匹配到的测试文件中没有断言的文件
+}
+
+// Tested 判断文件是否有测试
+func (t *FileTests) Tested() bool {
+	return t != nil && (len(t.TestFiles) > 0 || t.PackageTested)
+}
+
+// 测试存在性得分
+const (
+	untestedPenalty     = 1.0 // 没有任何测试
+	noAssertionsPenalty = 0.5 // 匹配到的测试都没有断言
+)
+
+// TestPresenceMetric 检查生产文件是否有对应的测试，以及测试中是否有断言
+type TestPresenceMetric struct {
+	*BaseMetric
+	translator i18n.Translator
+	tests      *FileTests
+}
+
+// NewTestPresenceMetric 创建测试存在性指标
+func NewTestPresenceMetric() *TestPresenceMetric {
+	translator := i18n.NewTranslator(i18n.ZhCN)
+	return &TestPresenceMetric{
+		BaseMetric: NewBaseMetric(
+			i18n.FormatKey("metric", "test_presence"),
+			translator.Translate("metric.test_presence.description"),
+			0.1,
+			nil, // 支持所有语言
+		),
+		translator: translator,
+	}
+}
+
+// SetTranslator 设置翻译器
+func (m *TestPresenceMetric) SetTranslator(translator i18n.Translator) {
+	m.translator = translator
+	m.name = translator.Translate(i18n.FormatKey("metric", "test_presence"))
+	m.description = translator.Translate("metric.test_presence.description")
+}
+
+// SetTests 设置当前文件对应的测试
+func (m *TestPresenceMetric) SetTests(tests *FileTests) {
+	m.tests = tests
+}
+
+// Analyze 实现指标接口分析方法
+// 没有函数的文件（只有类型或常量声明）不要求测试；没有测试得满分，匹配到的测试没有断言按比例扣分
+func (m *TestPresenceMetric) Analyze(parseResult parser.ParseResult) MetricResult {
+	issues := []string{}
+	score := 0.0
+
+	switch {
+	case len(parseResult.GetFunctions()) == 0:
+	case !m.tests.Tested():
+		filePath, _ := ExtractSource(parseResult)
+		issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.untested_file"), common.SuggestTestFile(filePath)))
+		score = untestedPenalty
+	case len(m.tests.NoAssertions) > 0:
+		for _, file := range m.tests.NoAssertions {
+			issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.test_without_assertions"), filepath.Base(file)))
+		}
+		if len(m.tests.TestFiles) > 0 {
+			score = noAssertionsPenalty * float64(len(m.tests.NoAssertions)) / float64(len(m.tests.TestFiles))
+		}
+	}
+
+	return MetricResult{
+		Score:       score,
+		Issues:      issues,
+		Description: m.Description(),
+		Weight:      m.Weight(),
+	}
+}
@@ -0,0 +1,95 @@
+// Package metrics 提供代码质量分析指标
+// 创建者：Done-0
+package metrics
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Done-0/fuck-u-code/pkg/coverage"
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+	"github.com/Done-0/fuck-u-code/pkg/parser"
+)
+
+// CoverageAware 依赖测试覆盖率的指标，没有加载该文件所属语言的覆盖率时不参与评分
+type CoverageAware interface {
+	// SetCoverage 设置当前文件的覆盖率
+	SetCoverage(file *coverage.File)
+}
+
+// crapThreshold CRAP超过该值的函数视为复杂且缺少测试
+const crapThreshold = 30
+
+// CRAP 计算函数的CRAP风险分：复杂度² × (1 - 覆盖率)³ + 复杂度，覆盖率为0-1
+// 完全覆盖时等于圈复杂度，完全未覆盖时随复杂度平方增长
+func CRAP(complexity int, coverage float64) float64 {
+	cc := float64(complexity)
+	return cc*cc*math.Pow(1-coverage, 3) + cc
+}
+
+// UntestedComplexityMetric 结合测试覆盖率和圈复杂度，找出复杂却缺少测试的函数
+type UntestedComplexityMetric struct {
+	*BaseMetric
+	translator i18n.Translator
+	coverage   *coverage.File
+}
+
+// NewUntestedComplexityMetric 创建未测试复杂度指标
+func NewUntestedComplexityMetric() *UntestedComplexityMetric {
+	translator := i18n.NewTranslator(i18n.ZhCN)
+	return &UntestedComplexityMetric{
+		BaseMetric: NewBaseMetric(
+			i18n.FormatKey("metric", "untested_complexity"),
+			translator.Translate("metric.untested_complexity.description"),
+			0.15,
+			nil, // 支持所有语言
+		),
+		translator: translator,
+	}
+}
+
+// SetTranslator 设置翻译器
+func (m *UntestedComplexityMetric) SetTranslator(translator i18n.Translator) {
+	m.translator = translator
+	m.name = translator.Translate(i18n.FormatKey("metric", "untested_complexity"))
+	m.description = translator.Translate("metric.untested_complexity.description")
+}
+
+// SetCoverage 设置当前文件的覆盖率
+func (m *UntestedComplexityMetric) SetCoverage(file *coverage.File) {
+	m.coverage = file
+}
+
+// Analyze 实现指标接口分析方法
+// 得分为CRAP超标函数的复杂度占文件总复杂度的比例，没有覆盖率数据的函数不计入
+func (m *UntestedComplexityMetric) Analyze(parseResult parser.ParseResult) MetricResult {
+	issues := []string{}
+	total, risky := 0, 0
+	for _, fn := range parseResult.GetFunctions() {
+		ratio, ok := m.coverage.Ratio(fn.StartLine, fn.EndLine)
+		if !ok {
+			continue
+		}
+		total += fn.Complexity
+
+		crap := CRAP(fn.Complexity, ratio)
+		if crap <= crapThreshold {
+			continue
+		}
+		risky += fn.Complexity
+		issues = append(issues, fmt.Sprintf(m.translator.Translate("issue.untested_complexity"),
+			fn.Name, fn.StartLine, fn.Complexity, ratio*100, crap))
+	}
+
+	score := 0.0
+	if total > 0 {
+		score = float64(risky) / float64(total)
+	}
+
+	return MetricResult{
+		Score:       score,
+		Issues:      issues,
+		Description: m.Description(),
+		Weight:      m.Weight(),
+	}
+}
@@ -0,0 +1,549 @@
+// Package parser 提供多语言代码解析功能
+package parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+)
+
+// APISymbol 公开的类型、函数或方法及其文档注释
+type APISymbol struct {
+	Name         string         // 名称
+	Kind         IdentifierKind // 类别，C#属性为 IdentVariable
+	Line         int            // 声明所在行，从1开始
+	Params       []string       // 参数名，不含 self、this 等隐含参数
+	OpaqueParams bool           // 有无法对应到名称的参数，如解构参数，此时不检查参数说明
+	Doc          *DocComment    // 文档注释，没有时为nil
+}
+
+// DocComment 文档注释，如Go的声明注释、Python的docstring、JSDoc、Javadoc和C#的XML文档注释
+type DocComment struct {
+	Text      string   // 去掉注释符号后的内容
+	Params    []string // 注释中说明的参数
+	Inherited bool     // 沿用父类的文档，如 {@inheritDoc}、<inheritdoc/>
+}
+
+// GetPublicAPI 获取公开的API及其文档注释，首次调用时才识别
+func (r *BaseParseResult) GetPublicAPI() []APISymbol {
+	if r.publicAPI == nil {
+		r.publicAPI = PublicAPI(r.Source, r.Language)
+	}
+	return r.publicAPI
+}
+
+// PublicAPI 识别源码中公开的API及其文档注释，顺序与源码一致
+// Go为导出的函数、方法和类型，Python为不以下划线开头的模块级函数、类和公开类的方法，
+// JavaScript/TypeScript为导出的函数和类，Java和C#为public的类型和方法，C#还包括public属性
+func PublicAPI(source []byte, language common.LanguageType) []APISymbol {
+	switch language {
+	case common.Go:
+		return goPublicAPI(source)
+	case common.Python:
+		return pythonPublicAPI(strings.Split(string(source), "\n"))
+	case common.JavaScript, common.TypeScript, common.Java, common.CSharp:
+		return bracePublicAPI(source, language)
+	default:
+		return []APISymbol{}
+	}
+}
+
+// goPublicAPI 使用标准库的解析器识别Go中导出的函数、方法和类型
+func goPublicAPI(source []byte) []APISymbol {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", source, parser.ParseComments)
+	if err != nil {
+		return []APISymbol{}
+	}
+
+	symbols := []APISymbol{}
+	for _, decl := range file.Decls {
+		switch node := decl.(type) {
+		case *ast.FuncDecl:
+			if !node.Name.IsExported() {
+				continue
+			}
+			kind := IdentFunction
+			if node.Recv != nil {
+				kind = IdentMethod
+			}
+			var params []string
+			for _, field := range node.Type.Params.List {
+				for _, name := range field.Names {
+					params = append(params, name.Name)
+				}
+			}
+			symbols = append(symbols, APISymbol{
+				Name:   node.Name.Name,
+				Kind:   kind,
+				Line:   fileSet.Position(node.Pos()).Line,
+				Params: params,
+				Doc:    goDocComment(node.Doc),
+			})
+		case *ast.GenDecl:
+			for _, spec := range node.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || !typeSpec.Name.IsExported() {
+					continue
+				}
+				// 分组声明中的类型可以沿用整组的注释
+				doc := typeSpec.Doc
+				if doc == nil {
+					doc = node.Doc
+				}
+				symbols = append(symbols, APISymbol{
+					Name: typeSpec.Name.Name,
+					Kind: IdentType,
+					Line: fileSet.Position(typeSpec.Pos()).Line,
+					Doc:  goDocComment(doc),
+				})
+			}
+		}
+	}
+	return symbols
+}
+
+// goDocComment 转换Go的声明注释，Go的文档注释不逐个说明参数
+func goDocComment(group *ast.CommentGroup) *DocComment {
+	if group == nil || len(group.List) == 0 {
+		return nil
+	}
+	return &DocComment{Text: group.Text()}
+}
+
+// Python文档相关模式
+var (
+	pyDocstringPattern    = regexp.MustCompile(`^[rRuUbB]{0,2}("""|'''|"|')`)
+	pySphinxParamPattern  = regexp.MustCompile(`:(?:param|parameter|arg|argument|key|keyword)\s+(?:[^:]*\s)?\**([A-Za-z_]\w*)\s*:`)
+	pyGoogleHeaderPattern = regexp.MustCompile(`^(?:Args|Arguments|Parameters|Params|Keyword Args|Keyword Arguments|Other Parameters)\s*:$`)
+	pyGoogleParamPattern  = regexp.MustCompile(`^\**([A-Za-z_]\w*)\s*(?:\([^)]*\))?\s*:`)
+	pyNumpyHeaderPattern  = regexp.MustCompile(`^(?:Parameters|Other Parameters)$`)
+	pyNumpyParamPattern   = regexp.MustCompile(`^(\**[A-Za-z_]\w*(?:\s*,\s*\**[A-Za-z_]\w*)*)\s*(?::.*)?$`)
+	pyNumpyRulePattern    = regexp.MustCompile(`^-{3,}$`)
+)
+
+// pythonPublicAPI 按缩进识别Python中公开的函数、类和方法，文档为声明后的第一个字符串
+func pythonPublicAPI(lines []string) []APISymbol {
+	type scope struct {
+		indent   int
+		isClass  bool
+		isPublic bool
+	}
+
+	symbols := []APISymbol{}
+	var scopes []scope
+	stripped := StripComments(lines, common.Python)
+
+	for i, line := range stripped {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		for len(scopes) > 0 && scopes[len(scopes)-1].indent >= indent {
+			scopes = scopes[:len(scopes)-1]
+		}
+		// 只有模块级和公开类中的声明是公开的
+		visible := len(scopes) == 0 || (scopes[len(scopes)-1].isClass && scopes[len(scopes)-1].isPublic)
+
+		kind := IdentType
+		match := pyClassPattern.FindStringSubmatchIndex(line)
+		if match == nil {
+			if match = pyDefPattern.FindStringSubmatchIndex(line); match == nil {
+				continue
+			}
+			kind = IdentFunction
+			if len(scopes) > 0 && scopes[len(scopes)-1].isClass {
+				kind = IdentMethod
+			}
+		}
+		name := line[match[4]:match[5]]
+
+		public := visible && !strings.HasPrefix(name, "_")
+		scopes = append(scopes, scope{indent: indent, isClass: kind == IdentType, isPublic: public})
+		if !public || (i > 0 && strings.Contains(stripped[i-1], "@") && strings.Contains(stripped[i-1], "overload")) {
+			continue
+		}
+
+		params, closeLine := signatureParams(stripped, i, line[match[5]:])
+		end := pythonDeclarationEnd(stripped, closeLine)
+		symbol := APISymbol{Name: name, Kind: kind, Line: i + 1, Doc: pythonDocstring(lines, stripped, end)}
+		if kind != IdentType {
+			for j, param := range splitParams(params) {
+				param = strings.TrimLeft(param, "*")
+				if idx := strings.IndexAny(param, ":="); idx != -1 {
+					param = param[:idx]
+				}
+				param = strings.TrimSpace(param)
+				if param == "" || param == "/" || (j == 0 && kind == IdentMethod && (param == "self" || param == "cls")) {
+					continue
+				}
+				symbol.Params = append(symbol.Params, param)
+			}
+		}
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// pythonDeclarationEnd 返回Python声明结束的行，即参数列表之后第一个以冒号结尾的行
+func pythonDeclarationEnd(stripped []string, start int) int {
+	for i := start; i < len(stripped) && i < start+maxSignatureLines; i++ {
+		if strings.HasSuffix(strings.TrimSpace(stripped[i]), ":") {
+			return i
+		}
+	}
+	return start
+}
+
+// pythonDocstring 提取声明后的docstring，end为声明最后一行的下标
+func pythonDocstring(lines, stripped []string, end int) *DocComment {
+	// 函数体与声明写在同一行时没有docstring
+	if colon := strings.LastIndex(stripped[end], ":"); colon == -1 || strings.TrimSpace(stripped[end][colon+1:]) != "" {
+		return nil
+	}
+
+	for i := end + 1; i < len(lines); i++ {
+		first := strings.TrimSpace(lines[i])
+		if first == "" {
+			continue
+		}
+		match := pyDocstringPattern.FindStringSubmatch(first)
+		if match == nil {
+			return nil
+		}
+
+		quote := match[1]
+		text := first[len(match[0]):]
+		for j := i + 1; !strings.Contains(text, quote) && j < len(lines); j++ {
+			text += "\n" + lines[j]
+		}
+		if idx := strings.Index(text, quote); idx != -1 {
+			text = text[:idx]
+		}
+		return &DocComment{Text: text, Params: pythonDocParams(text)}
+	}
+	return nil
+}
+
+// pythonDocParams 提取docstring中说明的参数，支持Sphinx、Google和NumPy风格
+func pythonDocParams(text string) []string {
+	var params []string
+	for _, match := range pySphinxParamPattern.FindAllStringSubmatch(text, -1) {
+		params = append(params, match[1])
+	}
+
+	lines := strings.Split(text, "\n")
+	for i := 0; i < len(lines); i++ {
+		header := strings.TrimSpace(lines[i])
+		headerIndent := indentOf(lines[i])
+		switch {
+		case pyGoogleHeaderPattern.MatchString(header):
+			// Google风格：Args: 下缩进的 "name (type): 说明"
+			itemIndent := -1
+			for i++; i < len(lines); i++ {
+				item := strings.TrimSpace(lines[i])
+				if item == "" {
+					continue
+				}
+				indent := indentOf(lines[i])
+				if indent <= headerIndent {
+					i--
+					break
+				}
+				if itemIndent == -1 {
+					itemIndent = indent
+				}
+				if match := pyGoogleParamPattern.FindStringSubmatch(item); match != nil && indent == itemIndent {
+					params = append(params, match[1])
+				}
+			}
+		case pyNumpyHeaderPattern.MatchString(header) && i+1 < len(lines) && pyNumpyRulePattern.MatchString(strings.TrimSpace(lines[i+1])):
+			// NumPy风格：Parameters 和分隔线下与标题同样缩进的 "name : type"，到下一个标题为止
+			for i += 2; i < len(lines); i++ {
+				item := strings.TrimSpace(lines[i])
+				if i+1 < len(lines) && pyNumpyRulePattern.MatchString(strings.TrimSpace(lines[i+1])) {
+					i--
+					break
+				}
+				if item == "" || indentOf(lines[i]) != headerIndent {
+					continue
+				}
+				if match := pyNumpyParamPattern.FindStringSubmatch(item); match != nil {
+					for _, name := range strings.Split(match[1], ",") {
+						params = append(params, strings.TrimLeft(strings.TrimSpace(name), "*"))
+					}
+				}
+			}
+		}
+	}
+	return params
+}
+
+// indentOf 返回行首空白的长度
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// 花括号语言的公开声明模式
+var (
+	jsExportFunctionPattern     = regexp.MustCompile(`^\s*export\s+(?:default\s+)?(?:declare\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)`)
+	jsExportArrowPattern        = regexp.MustCompile(`^\s*export\s+(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=\s*(?:async\s+)?(?:function\b|\(|<|([A-Za-z_$][\w$]*)\s*=>)`)
+	jsExportClassPattern        = regexp.MustCompile(`^\s*export\s+(?:default\s+)?(?:declare\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][\w$]*)`)
+	javaPublicTypePattern       = regexp.MustCompile(`^\s*public\s+(?:(?:static|final|abstract|sealed|non-sealed|strictfp)\s+)*(?:class|interface|enum|record|@interface)\s+([A-Za-z_$][\w$]*)`)
+	csharpPublicTypePattern     = regexp.MustCompile(`^\s*public\s+(?:(?:static|sealed|abstract|partial|readonly|unsafe|new|ref)\s+)*(?:class|struct|interface|enum|record(?:\s+class|\s+struct)?)\s+([A-Za-z_]\w*)`)
+	csharpPublicPropertyPattern = regexp.MustCompile(`^\s*public\s+(?:(?:static|virtual|override|abstract|sealed|new|required|readonly)\s+)*[\w<>\[\],.?]+\s+([A-Za-z_]\w*)\s*(?:\{|=>|$)`)
+	publicMethodPattern         = regexp.MustCompile(`^\s*public\s+([^=(]*?)([A-Za-z_$][\w$]*)\s*(?:<[^>]*>)?\s*\(`)
+	trailingIdentPattern        = regexp.MustCompile(`([A-Za-z_$][\w$]*)\s*(?:\[\s*\]\s*)*$`)
+	leadingIdentPattern         = regexp.MustCompile(`^[A-Za-z_$][\w$]*`)
+	jsDocParamPattern           = regexp.MustCompile(`@param\s+(?:\{[^}]*\}\s*)?\[?([A-Za-z_$][\w$.]*)`)
+	csharpDocParamPattern       = regexp.MustCompile(`<param\s+name\s*=\s*"([^"]+)"`)
+)
+
+// methodModifiers Java和C#方法声明中返回类型前的修饰符
+var methodModifiers = map[string]bool{
+	"static": true, "final": true, "abstract": true, "synchronized": true, "native": true, "default": true,
+	"strictfp": true, "virtual": true, "override": true, "sealed": true, "async": true, "extern": true,
+	"unsafe": true, "new": true, "partial": true, "readonly": true,
+}
+
+// bracePublicAPI 识别花括号语言中公开的声明，文档为声明前紧挨着的 /** */ 或 /// 注释，中间可以有注解
+// 重写父类的方法（@Override、override）沿用父类的文档，不参与检查
+func bracePublicAPI(source []byte, language common.LanguageType) []APISymbol {
+	lines := strings.Split(string(source), "\n")
+	stripped := StripComments(lines, language)
+	docs := make(map[int]Comment)
+	for _, comment := range ExtractComments(source, language) {
+		if !comment.Inline {
+			docs[comment.EndLine] = comment
+		}
+	}
+
+	symbols := []APISymbol{}
+	for i, line := range stripped {
+		symbol, rest, ok := braceDeclaration(line, language)
+		if !ok {
+			continue
+		}
+
+		// 声明前的注解和特性，重写的方法不检查
+		j := i - 1
+		for j >= 0 && isAnnotationLine(stripped[j], language) {
+			if strings.Contains(stripped[j], "@Override") {
+				ok = false
+			}
+			j--
+		}
+		if !ok {
+			continue
+		}
+
+		symbol.Line = i + 1
+		symbol.Doc = braceDocComment(docs, j+1, language)
+		if rest != "" {
+			params, _ := signatureParams(stripped, i, rest)
+			symbol.Params, symbol.OpaqueParams = braceParamNames(params, language)
+		}
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// braceDeclaration 识别一行中的公开声明，返回声明和名称之后的文本，没有参数列表时文本为空
+func braceDeclaration(line string, language common.LanguageType) (APISymbol, string, bool) {
+	switch language {
+	case common.JavaScript, common.TypeScript:
+		if match := jsExportClassPattern.FindStringSubmatch(line); match != nil {
+			return APISymbol{Name: match[1], Kind: IdentType}, "", true
+		}
+		if match := jsExportFunctionPattern.FindStringSubmatchIndex(line); match != nil {
+			return APISymbol{Name: line[match[2]:match[3]], Kind: IdentFunction}, line[match[1]:], true
+		}
+		if match := jsExportArrowPattern.FindStringSubmatchIndex(line); match != nil {
+			symbol := APISymbol{Name: line[match[2]:match[3]], Kind: IdentFunction}
+			if match[4] != -1 {
+				// 不带括号的单个参数，如 x => x * 2
+				symbol.Params = []string{line[match[4]:match[5]]}
+				return symbol, "", true
+			}
+			return symbol, line[match[3]:], true
+		}
+		return APISymbol{}, "", false
+	case common.Java:
+		if match := javaPublicTypePattern.FindStringSubmatch(line); match != nil {
+			return APISymbol{Name: match[1], Kind: IdentType}, "", true
+		}
+	case common.CSharp:
+		if match := csharpPublicTypePattern.FindStringSubmatch(line); match != nil {
+			return APISymbol{Name: match[1], Kind: IdentType}, "", true
+		}
+		if match := csharpPublicPropertyPattern.FindStringSubmatch(line); match != nil && !strings.Contains(line, "(") && !strings.Contains(line, " override ") {
+			return APISymbol{Name: match[1], Kind: IdentVariable}, "", true
+		}
+	}
+
+	// Java和C#的public方法，修饰符之后没有返回类型的是构造函数
+	match := publicMethodPattern.FindStringSubmatchIndex(line)
+	if match == nil {
+		return APISymbol{}, "", false
+	}
+	var returnType []string
+	for _, word := range strings.Fields(line[match[2]:match[3]]) {
+		if word == "override" {
+			return APISymbol{}, "", false
+		}
+		if !methodModifiers[word] {
+			returnType = append(returnType, word)
+		}
+	}
+	if len(returnType) == 0 {
+		return APISymbol{}, "", false
+	}
+	return APISymbol{Name: line[match[4]:match[5]], Kind: IdentMethod}, line[match[5]:], true
+}
+
+// isAnnotationLine 判断是否为单独一行的注解（Java、TypeScript）或特性（C#）
+func isAnnotationLine(line string, language common.LanguageType) bool {
+	line = strings.TrimSpace(line)
+	if language == common.CSharp {
+		return strings.HasPrefix(line, "[")
+	}
+	return strings.HasPrefix(line, "@")
+}
+
+// braceDocComment 查找在指定行结束的文档注释：C#为连续的 /// 注释，其他语言为 /** */
+func braceDocComment(docs map[int]Comment, line int, language common.LanguageType) *DocComment {
+	if language == common.CSharp {
+		var parts []string
+		for comment, ok := docs[line]; ok && strings.HasPrefix(comment.Text, "///"); comment, ok = docs[line] {
+			parts = append([]string{strings.TrimSpace(strings.TrimPrefix(comment.Text, "///"))}, parts...)
+			line--
+		}
+		if len(parts) == 0 {
+			return nil
+		}
+		text := strings.Join(parts, "\n")
+		doc := &DocComment{Text: text, Inherited: strings.Contains(text, "<inheritdoc")}
+		for _, match := range csharpDocParamPattern.FindAllStringSubmatch(text, -1) {
+			doc.Params = append(doc.Params, match[1])
+		}
+		return doc
+	}
+
+	comment, ok := docs[line]
+	if !ok || !strings.HasPrefix(comment.Text, "/**") {
+		return nil
+	}
+	text := strings.Join(comment.Lines(), "\n")
+	doc := &DocComment{Text: text, Inherited: strings.Contains(text, "@inheritDoc")}
+	for _, match := range jsDocParamPattern.FindAllStringSubmatch(text, -1) {
+		// 对象参数的属性，如 @param options.timeout
+		if !strings.Contains(match[1], ".") {
+			doc.Params = append(doc.Params, match[1])
+		}
+	}
+	return doc
+}
+
+// signatureParams 返回声明中参数列表的内容和右括号所在行的下标，rest为start行中声明名称之后的部分
+// 参数列表可以跨行，没有括号时返回空内容和声明所在行
+func signatureParams(lines []string, start int, rest string) (string, int) {
+	open := strings.Index(rest, "(")
+	if open == -1 {
+		return "", start
+	}
+
+	var b strings.Builder
+	depth := 0
+	text := rest[open:]
+	for i := start; i < len(lines) && i < start+maxSignatureLines; i++ {
+		if i > start {
+			text = "\n" + lines[i]
+		}
+		for _, c := range text {
+			switch c {
+			case '(':
+				depth++
+				if depth == 1 {
+					continue
+				}
+			case ')':
+				depth--
+				if depth == 0 {
+					return b.String(), i
+				}
+			}
+			b.WriteRune(c)
+		}
+	}
+	return b.String(), start
+}
+
+// maxSignatureLines 跨行的声明最多读取的行数
+const maxSignatureLines = 30
+
+// splitParams 按顶层的逗号切分参数列表，泛型、默认值中的逗号不切分
+func splitParams(text string) []string {
+	var params []string
+	depth, start := 0, 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '(', '[', '{', '<':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case '>':
+			// 箭头函数的 => 不是泛型的结束
+			if depth > 0 && (i == 0 || text[i-1] != '=') {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				params = append(params, text[start:i])
+				start = i + 1
+			}
+		}
+	}
+	params = append(params, text[start:])
+
+	result := params[:0]
+	for _, param := range params {
+		if param = strings.TrimSpace(param); param != "" {
+			result = append(result, param)
+		}
+	}
+	return result
+}
+
+// braceParamNames 提取花括号语言参数列表中的参数名，有解构参数时返回true
+func braceParamNames(text string, language common.LanguageType) ([]string, bool) {
+	var names []string
+	for _, param := range splitParams(text) {
+		// 参数前的注解和特性，如 @NonNull、[FromBody]
+		param = annotationPattern.ReplaceAllString(param, "")
+		switch language {
+		case common.JavaScript, common.TypeScript:
+			for _, modifier := range []string{"public ", "private ", "protected ", "readonly ", "..."} {
+				param = strings.TrimPrefix(strings.TrimSpace(param), modifier)
+			}
+			if strings.HasPrefix(param, "{") || strings.HasPrefix(param, "[") {
+				return names, true
+			}
+			if name := leadingIdentPattern.FindString(param); name != "" && name != "this" {
+				names = append(names, name)
+			}
+		default:
+			if idx := strings.Index(param, "="); idx != -1 {
+				param = param[:idx]
+			}
+			if match := trailingIdentPattern.FindStringSubmatch(strings.TrimSuffix(strings.TrimSpace(param), "...")); match != nil {
+				names = append(names, strings.TrimPrefix(match[1], "@"))
+			}
+		}
+	}
+	return names, false
+}
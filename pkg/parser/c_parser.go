@@ -29,6 +29,9 @@ func (p *CParser) Parse(filePath string, content []byte) (ParseResult, error) {
 		CommentLines: 0,
 		TotalLines:   len(lines),
 		Language:     language,
+		FilePath:     filePath,
+		Source:       content,
+		Identifiers:  textIdentifiers(lines, language),
 	}
 
 	// 计算注释行数
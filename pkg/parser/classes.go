@@ -0,0 +1,508 @@
+// Package parser 提供多语言代码解析功能
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+)
+
+// Class 类及其方法，Go中为结构体等命名类型及其接收者方法
+type Class struct {
+	Name      string   // 类名
+	StartLine int      // 开始行，Go中为类型声明所在行
+	EndLine   int      // 结束行
+	Fields    []string // 字段，包括类级变量和属性
+	Methods   []Method // 方法，不含嵌套类的方法
+	Record    bool     // 语言层面声明的数据类型，如Python的@dataclass
+}
+
+// Method 类的方法及其访问的本类成员
+type Method struct {
+	Name        string   // 方法名
+	StartLine   int      // 开始行
+	EndLine     int      // 结束行
+	Complexity  int      // 圈复杂度
+	Constructor bool     // 是否为构造函数
+	Fields      []string // 访问的本类字段
+	Calls       []string // 调用的本类方法
+}
+
+// GetClasses 获取解析出的类
+func (r *BaseParseResult) GetClasses() []Class {
+	return r.Classes
+}
+
+// assignOwners 根据方法名和所在行设置函数所属的类
+func assignOwners(functions []Function, classes []Class) {
+	for i := range functions {
+		fn := &functions[i]
+		for _, class := range classes {
+			for _, m := range class.Methods {
+				if m.Name == fn.Name && fn.StartLine >= m.StartLine-1 && fn.StartLine <= m.EndLine {
+					fn.Owner = class.Name
+				}
+			}
+		}
+	}
+}
+
+// memberUsage 收集方法中使用的成员名，类结束后再区分字段和方法
+type memberUsage map[string]bool
+
+// resolveMembers 将方法中使用的成员名区分为本类字段和本类方法
+func resolveMembers(class *Class, usages []memberUsage, inferFields bool) {
+	methods := make(map[string]bool, len(class.Methods))
+	for _, m := range class.Methods {
+		methods[m.Name] = true
+	}
+	fields := make(map[string]bool, len(class.Fields))
+	for _, f := range class.Fields {
+		fields[f] = true
+	}
+
+	// 字段声明不在当前文件中时，把使用到的非方法成员视为字段
+	if inferFields {
+		for _, used := range usages {
+			for name := range used {
+				if !methods[name] && !fields[name] {
+					fields[name] = true
+					class.Fields = append(class.Fields, name)
+				}
+			}
+		}
+		sort.Strings(class.Fields)
+	}
+
+	for i := range class.Methods {
+		for name := range usages[i] {
+			switch {
+			case fields[name]:
+				class.Methods[i].Fields = append(class.Methods[i].Fields, name)
+			case methods[name] && name != class.Methods[i].Name:
+				class.Methods[i].Calls = append(class.Methods[i].Calls, name)
+			}
+		}
+		sort.Strings(class.Methods[i].Fields)
+		sort.Strings(class.Methods[i].Calls)
+	}
+}
+
+// goClasses 将Go的方法按接收者类型归组，通过接收者访问的成员区分为字段和方法
+func goClasses(fileSet *token.FileSet, file *ast.File) []Class {
+	line := func(pos token.Pos) int {
+		return fileSet.Position(pos).Line
+	}
+
+	var classes []*Class
+	index := make(map[string]int)
+	declared := make(map[string]bool)
+	classFor := func(name string, start, end int) *Class {
+		if i, ok := index[name]; ok {
+			return classes[i]
+		}
+		index[name] = len(classes)
+		classes = append(classes, &Class{Name: name, StartLine: start, EndLine: end})
+		return classes[len(classes)-1]
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if _, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+				continue
+			}
+			class := classFor(typeSpec.Name.Name, line(typeSpec.Pos()), line(typeSpec.End()))
+			declared[class.Name] = true
+			if st, ok := typeSpec.Type.(*ast.StructType); ok {
+				for _, field := range st.Fields.List {
+					for _, name := range field.Names {
+						class.Fields = append(class.Fields, name.Name)
+					}
+					if len(field.Names) == 0 {
+						class.Fields = append(class.Fields, goEmbeddedName(field.Type))
+					}
+				}
+			}
+		}
+	}
+
+	usages := make(map[string][]memberUsage)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+			continue
+		}
+		recv := fn.Recv.List[0]
+		typeName := goEmbeddedName(recv.Type)
+		if typeName == "" {
+			continue
+		}
+		class := classFor(typeName, line(fn.Pos()), line(fn.End()))
+		class.Methods = append(class.Methods, Method{
+			Name:       fn.Name.Name,
+			StartLine:  line(fn.Pos()),
+			EndLine:    line(fn.End()),
+			Complexity: calculateComplexity(fn),
+		})
+
+		used := make(memberUsage)
+		if len(recv.Names) > 0 && recv.Names[0].Name != "_" && fn.Body != nil {
+			recvName := recv.Names[0].Name
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == recvName {
+						used[sel.Sel.Name] = true
+					}
+				}
+				return true
+			})
+		}
+		usages[typeName] = append(usages[typeName], used)
+	}
+
+	result := make([]Class, 0, len(classes))
+	for _, class := range classes {
+		resolveMembers(class, usages[class.Name], !declared[class.Name])
+		result = append(result, *class)
+	}
+	return result
+}
+
+// goEmbeddedName 返回类型表达式的类型名，用于嵌入字段和接收者
+func goEmbeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return goEmbeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.IndexExpr:
+		return goEmbeddedName(t.X)
+	case *ast.IndexListExpr:
+		return goEmbeddedName(t.X)
+	}
+	return ""
+}
+
+// 类结构识别使用的模式
+var (
+	braceClassPattern    = regexp.MustCompile(`\b(?:class|struct)\s+([A-Za-z_$][\w$]*)`)
+	jsClassPattern       = regexp.MustCompile(`\bclass\s+([A-Za-z_$][\w$]*)`)
+	braceNestedPattern   = regexp.MustCompile(`\b(?:interface|enum)\s+[A-Za-z_$]`)
+	annotationPattern    = regexp.MustCompile(`^\s*(?:(?:@[\w.]+(?:\([^)]*\))?|\[[^\]]*\])\s*)+`)
+	methodNamePattern    = regexp.MustCompile(`(#?[A-Za-z_$][\w$]*)\s*(?:<[^()]*>)?\s*$`)
+	javaFieldPattern     = regexp.MustCompile(`^\s*(?:[\w<>\[\],.?]+\s+)+([A-Za-z_]\w*)\s*(?:=|;|\{|=>|$)`)
+	jsFieldPattern       = regexp.MustCompile(`^\s*(?:(?:private|public|protected|readonly|static|declare|override|abstract|accessor)\s+)*(#?[A-Za-z_$][\w$]*)\s*[?!]?\s*[:=;]`)
+	thisMemberPattern    = regexp.MustCompile(`\bthis\.(#?[A-Za-z_$][\w$]*)`)
+	bareIdentPattern     = regexp.MustCompile(`(?:^|[^\w$.])([A-Za-z_$][\w$]*)`)
+	decisionPointPattern = regexp.MustCompile(`\b(?:if|for|foreach|while|case|catch|elif|except)\b|&&|\|\||\?\?|\band\b|\bor\b`)
+	pySelfMemberPattern  = regexp.MustCompile(`\b(?:self|cls)\.([A-Za-z_]\w*)`)
+	pySelfAssignPattern  = regexp.MustCompile(`\bself\.([A-Za-z_]\w*)\s*(?::[^=]+)?=[^=]`)
+	pyClassFieldPattern  = regexp.MustCompile(`^\s*([A-Za-z_]\w*)\s*(?::[^=]+|(?::[^=]+)?=[^=].*)$`)
+	pyDataclassPattern   = regexp.MustCompile(`^\s*@(?:dataclasses\.)?dataclass\b|^\s*@(?:attr\.)?(?:s|attrs|define|frozen)\b`)
+)
+
+// braceClasses 根据花括号深度识别Java、C#、JavaScript和TypeScript中的类
+// Java和C#可以省略this，方法中与字段同名的标识符都视为访问字段；JavaScript和TypeScript只识别this成员
+func braceClasses(lines []string, language common.LanguageType) []Class {
+	type openClass struct {
+		class   Class
+		depth   int  // 类体所在的括号深度
+		entered bool // 类体是否已经开始
+		usages  []memberUsage
+		method  int  // 当前所在方法的下标，-1表示不在方法中
+		inBody  bool // 当前方法的方法体是否已经开始
+		arrow   bool // 当前方法是否为表达式方法
+	}
+
+	classPattern := braceClassPattern
+	if language == common.JavaScript || language == common.TypeScript {
+		classPattern = jsClassPattern
+	}
+	implicitThis := language == common.Java || language == common.CSharp
+	var classes []Class
+	var stack []*openClass
+	depth := 0
+
+	closeClass := func(open *openClass, endLine int) {
+		if open.method != -1 {
+			open.class.Methods[open.method].EndLine = endLine
+		}
+		open.class.EndLine = endLine
+		resolveMembers(&open.class, open.usages, false)
+		classes = append(classes, open.class)
+	}
+
+	for i, line := range StripComments(lines, language) {
+		lineDepth := depth
+		var top *openClass
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		member := annotationPattern.ReplaceAllString(line, "")
+		usage := ""
+		switch {
+		case classPattern.MatchString(line):
+			loc := classPattern.FindStringSubmatchIndex(line)
+			stack = append(stack, &openClass{
+				class:   Class{Name: line[loc[2]:loc[3]], StartLine: i + 1},
+				depth:   lineDepth + 1,
+				entered: strings.Contains(line[loc[1]:], "{"),
+				method:  -1,
+			})
+		case top != nil && top.method == -1 && lineDepth == top.depth && strings.TrimSpace(member) != "" && !braceNestedPattern.MatchString(member):
+			if name, ok := braceMethodName(member); ok {
+				top.class.Methods = append(top.class.Methods, Method{
+					Name:        strings.TrimPrefix(name, "#"),
+					StartLine:   i + 1,
+					Constructor: name == top.class.Name || name == "constructor",
+				})
+				top.usages = append(top.usages, make(memberUsage))
+				top.method = len(top.class.Methods) - 1
+				top.inBody = false
+				top.arrow = false
+				if idx := strings.IndexAny(member, "{"); idx != -1 {
+					usage = member[idx:]
+				} else if idx := strings.Index(member, "=>"); idx != -1 {
+					usage = member[idx:]
+				}
+			} else if name := braceFieldName(member, language); name != "" {
+				top.class.Fields = append(top.class.Fields, name)
+			}
+		case top != nil && top.method != -1:
+			usage = line
+		}
+
+		if top != nil && top.method != -1 && usage != "" {
+			used := top.usages[top.method]
+			for _, match := range thisMemberPattern.FindAllStringSubmatch(usage, -1) {
+				used[strings.TrimPrefix(match[1], "#")] = true
+			}
+			if implicitThis {
+				for _, match := range bareIdentPattern.FindAllStringSubmatch(usage, -1) {
+					used[match[1]] = true
+				}
+			}
+			top.class.Methods[top.method].Complexity += len(decisionPointPattern.FindAllString(usage, -1))
+		}
+
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+
+		// 方法体结束；没有方法体的抽象方法不计入，表达式方法以分号结束
+		if top != nil && top.method != -1 {
+			top.inBody = top.inBody || strings.Contains(line, "{")
+			top.arrow = top.arrow || !top.inBody && strings.Contains(line, "=>")
+			switch {
+			case top.inBody && depth <= top.depth, top.arrow && strings.Contains(line, ";"):
+				top.class.Methods[top.method].EndLine = i + 1
+				top.class.Methods[top.method].Complexity++
+				top.method = -1
+			case !top.inBody && !top.arrow && strings.Contains(line, ";"):
+				top.class.Methods = top.class.Methods[:top.method]
+				top.usages = top.usages[:top.method]
+				top.method = -1
+			}
+		}
+
+		// 类体结束
+		for len(stack) > 0 {
+			open := stack[len(stack)-1]
+			if depth >= open.depth {
+				open.entered = true
+				break
+			}
+			if !open.entered {
+				// 只有声明没有类体，或类体在后续行才开始
+				if strings.Contains(line, ";") {
+					stack = stack[:len(stack)-1]
+					continue
+				}
+				break
+			}
+			closeClass(open, i+1)
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	// 括号不配对时，未结束的类到文件末尾为止
+	for len(stack) > 0 {
+		closeClass(stack[len(stack)-1], len(lines))
+		stack = stack[:len(stack)-1]
+	}
+
+	sort.Slice(classes, func(i, j int) bool { return classes[i].StartLine < classes[j].StartLine })
+	return classes
+}
+
+// braceMethodName 识别类体中的方法声明，返回方法名
+func braceMethodName(member string) (string, bool) {
+	idx := strings.Index(member, "(")
+	if idx == -1 {
+		return "", false
+	}
+	prefix := member[:idx]
+	if strings.ContainsAny(prefix, "=:") {
+		return "", false
+	}
+	match := methodNamePattern.FindStringSubmatch(prefix)
+	if match == nil || notIdentifiers[match[1]] && match[1] != "constructor" {
+		return "", false
+	}
+	return match[1], true
+}
+
+// braceFieldName 识别类体中的字段和属性声明，返回字段名
+func braceFieldName(member string, language common.LanguageType) string {
+	pattern := javaFieldPattern
+	if language == common.JavaScript || language == common.TypeScript {
+		pattern = jsFieldPattern
+	}
+	match := pattern.FindStringSubmatch(member)
+	if match == nil || notIdentifiers[match[1]] {
+		return ""
+	}
+	return strings.TrimPrefix(match[1], "#")
+}
+
+// pythonClasses 按缩进识别Python中的类，字段为类级变量和方法中赋值的self属性
+func pythonClasses(lines []string) []Class {
+	type openClass struct {
+		class      Class
+		indent     int
+		bodyIndent int // 类体缩进，-1表示尚未确定
+		usages     []memberUsage
+		fields     map[string]bool
+		method     int
+	}
+
+	var classes []Class
+	var stack []*openClass
+	record := false
+
+	closeClass := func(open *openClass, endLine int) {
+		if open.method != -1 {
+			open.class.Methods[open.method].EndLine = endLine
+		}
+		open.class.EndLine = endLine
+		names := make([]string, 0, len(open.fields))
+		for name := range open.fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		open.class.Fields = names
+		resolveMembers(&open.class, open.usages, false)
+		classes = append(classes, open.class)
+	}
+
+	stripped := StripComments(lines, common.Python)
+	lastCode := 0
+	for i, line := range stripped {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		for len(stack) > 0 {
+			open := stack[len(stack)-1]
+			if open.bodyIndent == -1 && indent > open.indent {
+				open.bodyIndent = indent
+			}
+			if indent > open.indent {
+				break
+			}
+			closeClass(open, lastCode)
+			stack = stack[:len(stack)-1]
+		}
+		lastCode = i + 1
+
+		var top *openClass
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+			if top.method != -1 && indent <= top.bodyIndent {
+				top.class.Methods[top.method].EndLine = lastPythonLine(stripped, i)
+				top.method = -1
+			}
+		}
+
+		switch {
+		case pyDataclassPattern.MatchString(line):
+			record = true
+			continue
+		case pyClassPattern.MatchString(line):
+			stack = append(stack, &openClass{
+				class:      Class{Name: pyClassPattern.FindStringSubmatch(line)[2], StartLine: i + 1, Record: record},
+				indent:     indent,
+				bodyIndent: -1,
+				fields:     make(map[string]bool),
+				method:     -1,
+			})
+		case top != nil && indent == top.bodyIndent && pyDefPattern.MatchString(line):
+			name := pyDefPattern.FindStringSubmatch(line)[2]
+			top.class.Methods = append(top.class.Methods, Method{
+				Name:        name,
+				StartLine:   i + 1,
+				EndLine:     i + 1,
+				Complexity:  1,
+				Constructor: name == "__init__",
+			})
+			top.usages = append(top.usages, make(memberUsage))
+			top.method = len(top.class.Methods) - 1
+		case top != nil && indent == top.bodyIndent && top.method == -1:
+			if match := pyClassFieldPattern.FindStringSubmatch(line); match != nil {
+				top.fields[match[1]] = true
+			}
+		case top != nil && top.method != -1:
+			used := top.usages[top.method]
+			for _, match := range pySelfMemberPattern.FindAllStringSubmatch(line, -1) {
+				used[match[1]] = true
+			}
+			for _, match := range pySelfAssignPattern.FindAllStringSubmatch(line, -1) {
+				top.fields[match[1]] = true
+			}
+			top.class.Methods[top.method].Complexity += len(decisionPointPattern.FindAllString(line, -1))
+		}
+		if !strings.HasPrefix(strings.TrimSpace(line), "@") {
+			record = false
+		}
+	}
+
+	for len(stack) > 0 {
+		closeClass(stack[len(stack)-1], lastCode)
+		stack = stack[:len(stack)-1]
+	}
+
+	sort.Slice(classes, func(i, j int) bool { return classes[i].StartLine < classes[j].StartLine })
+	return classes
+}
+
+// lastPythonLine 返回第end行之前最后一个非空行的行号
+func lastPythonLine(lines []string, end int) int {
+	for i := end - 1; i >= 0; i-- {
+		if strings.TrimSpace(lines[i]) != "" {
+			return i + 1
+		}
+	}
+	return end
+}
+
+// textClasses 根据语言选择基于文本的类识别方式
+func textClasses(lines []string, language common.LanguageType) []Class {
+	switch language {
+	case common.Python:
+		return pythonClasses(lines)
+	case common.Java, common.CSharp, common.JavaScript, common.TypeScript:
+		return braceClasses(lines, language)
+	}
+	return nil
+}
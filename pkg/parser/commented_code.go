@@ -0,0 +1,212 @@
+// Package parser 提供多语言代码解析功能
+package parser
+
+import (
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+)
+
+// CommentedCode 注释掉的代码块，连续的单行注释合并为一块
+type CommentedCode struct {
+	StartLine int // 开始行
+	EndLine   int // 结束行
+}
+
+// Lines 返回代码块占用的行数
+func (c CommentedCode) Lines() int {
+	return c.EndLine - c.StartLine + 1
+}
+
+// GetCommentedCode 获取源码中注释掉的代码，首次调用时才识别
+func (r *BaseParseResult) GetCommentedCode() []CommentedCode {
+	if r.commentedCode == nil {
+		r.commentedCode = FindCommentedCode(r.Source, r.GetComments(), r.Language)
+	}
+	return r.commentedCode
+}
+
+// codeSymbols 说明文字中很少出现的符号，句号、逗号、冒号等标点在说明文字中也很常见，不算在内
+var codeSymbols = map[string]bool{
+	"(": true, "[": true, "{": true, ";": true, "=": true, ":=": true, "==": true, "!=": true,
+	"===": true, "!==": true, "+=": true, "-=": true, "*=": true, "/=": true, "=>": true, "->": true,
+	"<-": true, "&&": true, "||": true, "++": true, "--": true, "<": true, ">": true, "<=": true, ">=": true,
+}
+
+// proseLinePattern 以说明性词语开头的行，如 "TODO: 调用 foo()"、"e.g. bar(1)"
+var proseLinePattern = regexp.MustCompile(`^(?:TODO|FIXME|HACK|XXX|NOTE|Note|See|see|Deprecated|e\.g\.|i\.e\.)\b`)
+
+// minCommentedCodeLines 注释掉的代码块至少包含的代码行数
+// 单独一行的 "obj != nil"、"f(a, b)" 常用来说明条件或调用形式，不算注释掉的代码
+const minCommentedCodeLines = 2
+
+// goDeclPattern Go声明的开头，紧挨着声明的注释是文档注释
+var goDeclPattern = regexp.MustCompile(`^(?:func|type|var|const|package)\b`)
+
+// FindCommentedCode 识别注释中被注释掉的代码
+// 连续的整行单行注释合并为一块，块注释单独成块，文档注释（/**、///、紧挨着Go声明的注释）和编译指令不参与识别
+// 一块中至少有两行、且至少一半的非空行像代码时视为注释掉的代码，Go代码块还会尝试用标准库的解析器解析，报告的范围不包括首尾的说明
+func FindCommentedCode(source []byte, comments []Comment, language common.LanguageType) []CommentedCode {
+	sourceLines := strings.Split(string(source), "\n")
+	blocks := []CommentedCode{}
+
+	for _, group := range groupComments(comments) {
+		if language == common.Go && group.followedBy(sourceLines, goDeclPattern) {
+			continue
+		}
+		if !isCommentedCode(group.lines(), language) {
+			continue
+		}
+		if group = group.trim(language); len(group) > 0 {
+			blocks = append(blocks, CommentedCode{StartLine: group[0].StartLine, EndLine: group[len(group)-1].EndLine})
+		}
+	}
+	return blocks
+}
+
+// commentGroup 一组相邻的注释
+type commentGroup []Comment
+
+// lines 返回组内所有注释去掉注释符号后的各行内容
+func (g commentGroup) lines() []string {
+	var lines []string
+	for _, comment := range g {
+		lines = append(lines, comment.Lines()...)
+	}
+	return lines
+}
+
+// trim 去掉组首尾只有说明文字的注释，如代码块前的说明
+func (g commentGroup) trim(language common.LanguageType) commentGroup {
+	isProse := func(comment Comment) bool {
+		for _, line := range comment.Lines() {
+			if _, prose := classifyLine(line, language); line != "" && !prose {
+				return false
+			}
+		}
+		return true
+	}
+	for len(g) > 0 && isProse(g[0]) {
+		g = g[1:]
+	}
+	for len(g) > 0 && isProse(g[len(g)-1]) {
+		g = g[:len(g)-1]
+	}
+	return g
+}
+
+// followedBy 判断注释组的下一行是否匹配pattern
+func (g commentGroup) followedBy(sourceLines []string, pattern *regexp.Regexp) bool {
+	next := g[len(g)-1].EndLine // 行号从1开始，正好是下一行的下标
+	return next < len(sourceLines) && pattern.MatchString(strings.TrimSpace(sourceLines[next]))
+}
+
+// groupComments 将连续的整行单行注释合并为一组，块注释单独成组，跳过行尾注释和文档注释
+func groupComments(comments []Comment) []commentGroup {
+	var groups []commentGroup
+	for _, comment := range comments {
+		if comment.Inline || isDocComment(comment) {
+			continue
+		}
+		if n := len(groups); n > 0 && !comment.Block {
+			last := groups[n-1][len(groups[n-1])-1]
+			if !last.Block && last.EndLine+1 == comment.StartLine {
+				groups[n-1] = append(groups[n-1], comment)
+				continue
+			}
+		}
+		groups = append(groups, commentGroup{comment})
+	}
+	return groups
+}
+
+// directivePattern 编译指令和工具指令，如 //go:build、//nolint:errcheck、# +build
+var directivePattern = regexp.MustCompile(`^(?://|#)(?:[a-z]+:|\s*\+build\b)`)
+
+// isDocComment 判断是否为文档注释或特殊注释，如 /** */、///、//!、#! 和编译指令
+func isDocComment(comment Comment) bool {
+	for _, prefix := range []string{"/**", "/*!", "///", "//!", "#!"} {
+		if strings.HasPrefix(comment.Text, prefix) {
+			return true
+		}
+	}
+	return directivePattern.MatchString(comment.Text)
+}
+
+// isCommentedCode 判断注释内容是否为代码
+func isCommentedCode(lines []string, language common.LanguageType) bool {
+	nonEmpty, code := 0, 0
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		nonEmpty++
+		if isCode, _ := classifyLine(line, language); isCode {
+			code++
+		}
+	}
+	if code < minCommentedCodeLines {
+		return false
+	}
+
+	// Go代码能被解析时，return、break 这类不带符号的语句也算代码
+	if language == common.Go && parsesAsGo(strings.Join(lines, "\n")) {
+		return true
+	}
+	return code*2 >= nonEmpty
+}
+
+// classifyLine 使用语言的词法规则判断一行注释像代码还是像说明文字，两者都不像时（如 return x）都返回false
+// 代码中的标识符之间通常隔着运算符或括号，而说明文字中的单词大多直接相连
+func classifyLine(line string, language common.LanguageType) (code, prose bool) {
+	if strings.Trim(line, "{}()[];,") == "" {
+		// 单独的括号和分号，如代码块末尾的 "})"
+		return true, false
+	}
+	if proseLinePattern.MatchString(line) || (strings.HasSuffix(line, ".") && !strings.HasSuffix(line, "...")) {
+		return false, true
+	}
+
+	operands, symbols, adjacent := 0, 0, 0
+	prevWord := false
+	for _, tok := range genericTokens(line, language) {
+		if !strings.ContainsAny(tok.Text[:1], "\"'`") && strings.IndexFunc(tok.Text, isNonASCII) != -1 {
+			// 字符串以外的非ASCII字符，如中文说明
+			return false, true
+		}
+		word := isIdentStart(tok.Text[0])
+		switch {
+		case tok.Kind == TokenOperand:
+			operands++
+			if word && prevWord {
+				adjacent++
+			}
+		case codeSymbols[tok.Text]:
+			symbols++
+		}
+		prevWord = word && tok.Kind == TokenOperand
+	}
+	if adjacent > 0 && adjacent >= symbols {
+		return false, true
+	}
+	return symbols > 0 && operands > 0, false
+}
+
+// parsesAsGo 判断文本能否作为Go的声明或函数体中的语句解析
+func parsesAsGo(text string) bool {
+	fileSet := token.NewFileSet()
+	if _, err := parser.ParseFile(fileSet, "", "package p\n"+text, 0); err == nil {
+		return true
+	}
+	_, err := parser.ParseFile(fileSet, "", "package p\nfunc _() {\n"+text+"\n}", 0)
+	return err == nil
+}
+
+// isNonASCII 判断字符是否为非ASCII字符
+func isNonASCII(r rune) bool {
+	return r >= utf8.RuneSelf
+}
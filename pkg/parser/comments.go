@@ -0,0 +1,137 @@
+// Package parser 提供多语言代码解析功能
+package parser
+
+import (
+	"go/scanner"
+	"go/token"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+)
+
+// Comment 源码中的一条注释，连续的单行注释各自独立
+type Comment struct {
+	Text      string // 原文，包括注释符号
+	StartLine int    // 开始行，从1开始
+	EndLine   int    // 结束行
+	Block     bool   // 是否为块注释（/* */）
+	Inline    bool   // 同一行注释前面有代码
+}
+
+// Lines 返回去掉注释符号后的各行内容，块注释每行开头的 * 一并去掉
+func (c Comment) Lines() []string {
+	text := c.Text
+	if c.Block {
+		text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+	} else {
+		text = strings.TrimPrefix(strings.TrimPrefix(text, "//"), "#")
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if c.Block {
+			line = strings.TrimSpace(strings.TrimLeft(line, "*"))
+		}
+		lines[i] = line
+	}
+	return lines
+}
+
+// GetComments 获取源码中的注释，首次调用时才提取
+func (r *BaseParseResult) GetComments() []Comment {
+	if r.comments == nil {
+		r.comments = ExtractComments(r.Source, r.Language)
+	}
+	return r.comments
+}
+
+// ExtractComments 提取源码中的注释，顺序与源码一致
+// Go使用标准库的词法分析器，Python使用#注释，其他语言使用 // 和 /* */，字符串中的注释符号不算注释
+func ExtractComments(source []byte, language common.LanguageType) []Comment {
+	if language == common.Go {
+		return goComments(source)
+	}
+	return genericComments(string(source), language)
+}
+
+// goComments 使用go/scanner提取Go源码中的注释
+func goComments(source []byte) []Comment {
+	fileSet := token.NewFileSet()
+	file := fileSet.AddFile("", fileSet.Base(), len(source))
+
+	var s scanner.Scanner
+	s.Init(file, source, nil, scanner.ScanComments)
+
+	text := string(source)
+	comments := []Comment{}
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok != token.COMMENT {
+			continue
+		}
+		line := file.Line(pos)
+		comments = append(comments, Comment{
+			Text:      lit,
+			StartLine: line,
+			EndLine:   line + strings.Count(lit, "\n"),
+			Block:     strings.HasPrefix(lit, "/*"),
+			Inline:    codeBefore(text, file.Offset(pos)),
+		})
+	}
+	return comments
+}
+
+// genericComments 使用与genericTokens相同的词法规则提取注释
+func genericComments(source string, language common.LanguageType) []Comment {
+	python := language == common.Python
+	comments := []Comment{}
+	line := 1
+
+	for i := 0; i < len(source); {
+		c := source[i]
+		rest := source[i:]
+
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case python && c == '#', !python && strings.HasPrefix(rest, "//"):
+			end := strings.IndexByte(rest, '\n')
+			if end == -1 {
+				end = len(rest)
+			}
+			text := strings.TrimRight(rest[:end], "\r")
+			comments = append(comments, Comment{Text: text, StartLine: line, EndLine: line, Inline: codeBefore(source, i)})
+			i += end
+		case !python && strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end == -1 {
+				end = len(rest)
+			} else {
+				end += 4
+			}
+			endLine := line + strings.Count(rest[:end], "\n")
+			comments = append(comments, Comment{Text: rest[:end], StartLine: line, EndLine: endLine, Block: true, Inline: codeBefore(source, i)})
+			line = endLine
+			i += end
+		case c == '"' || c == '\'' || c == '`':
+			end := stringLiteralEnd(rest, python)
+			line += strings.Count(rest[:end], "\n")
+			i += end
+		default:
+			i++
+		}
+	}
+
+	return comments
+}
+
+// codeBefore 判断offset所在行在offset之前是否有空白以外的内容
+func codeBefore(source string, offset int) bool {
+	start := strings.LastIndexByte(source[:offset], '\n') + 1
+	return strings.TrimSpace(source[start:offset]) != ""
+}
@@ -30,6 +30,14 @@ func (p *CSharpParser) Parse(filePath string, content []byte) (ParseResult, erro
         CommentLines: 0,
         TotalLines:   len(lines),
         Language:     common.CSharp,
+        FilePath:     filePath,
+        Source:       content,
+    }
+
+    if !isRazor {
+        result.Identifiers = textIdentifiers(lines, common.CSharp)
+        result.Dependencies = textDependencies(lines, common.CSharp)
+        result.Classes = textClasses(lines, common.CSharp)
     }
 
     if isRazor {
@@ -40,6 +48,7 @@ func (p *CSharpParser) Parse(filePath string, content []byte) (ParseResult, erro
         // 普通C#文件处理
         result.CommentLines = p.countCommentLines(contentStr)
         result.Functions = p.detectCSharpMethods(contentStr, lines)
+        assignOwners(result.Functions, result.Classes)
     }
 
     return result, nil
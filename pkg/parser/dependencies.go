@@ -0,0 +1,122 @@
+// Package parser 提供多语言代码解析功能
+package parser
+
+import (
+	"go/ast"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+)
+
+// Dependencies 文件的包归属和依赖信息，用于包级耦合度分析
+type Dependencies struct {
+	Package       string   // 所属包，Go为包名，Java为package声明，C#为命名空间
+	Imports       []string // 导入的包，Java的类导入保留完整类名
+	References    []string // 对导入包中符号的引用，格式为 导入路径#符号，同一符号只记录一次
+	Types         int      // 声明的类型数
+	AbstractTypes int      // 声明的接口和抽象类数
+}
+
+// GetDependencies 获取文件的依赖信息，不支持的语言返回nil
+func (r *BaseParseResult) GetDependencies() *Dependencies {
+	return r.Dependencies
+}
+
+// GetImportPaths 获取导入的包
+func (r *BaseParseResult) GetImportPaths() []string {
+	if r.Dependencies == nil {
+		return nil
+	}
+	return r.Dependencies.Imports
+}
+
+// goDependencies 从Go AST中收集导入、对导入包的引用和类型声明
+func goDependencies(file *ast.File) *Dependencies {
+	deps := &Dependencies{Package: file.Name.Name}
+
+	// 导入名到导入路径的映射，未指定别名时取路径最后一段
+	aliases := make(map[string]string)
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		deps.Imports = append(deps.Imports, path)
+
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		aliases[name] = path
+	}
+
+	seen := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.SelectorExpr:
+			if ident, ok := node.X.(*ast.Ident); ok && ident.Obj == nil {
+				if path, ok := aliases[ident.Name]; ok {
+					ref := path + "#" + node.Sel.Name
+					if !seen[ref] {
+						seen[ref] = true
+						deps.References = append(deps.References, ref)
+					}
+				}
+			}
+		case *ast.TypeSpec:
+			deps.Types++
+			if _, ok := node.Type.(*ast.InterfaceType); ok {
+				deps.AbstractTypes++
+			}
+		}
+		return true
+	})
+
+	return deps
+}
+
+// Java和C#依赖识别使用的正则表达式
+var (
+	javaPackagePattern     = regexp.MustCompile(`^\s*package\s+([\w.]+)\s*;`)
+	javaImportPattern      = regexp.MustCompile(`^\s*import\s+(?:static\s+)?([\w.]+(?:\.\*)?)\s*;`)
+	csharpNamespacePattern = regexp.MustCompile(`^\s*namespace\s+([\w.]+)`)
+	csharpUsingPattern     = regexp.MustCompile(`^\s*(?:global\s+)?using\s+(?:static\s+)?(?:\w+\s*=\s*)?([\w.]+)\s*;`)
+	typeDeclPattern        = regexp.MustCompile(`\b(?:class|interface|enum|record|struct)\s+[A-Za-z_]\w*`)
+	abstractTypePattern    = regexp.MustCompile(`\binterface\s+[A-Za-z_]\w*|\babstract\s+(?:\w+\s+)*class\s+[A-Za-z_]\w*`)
+)
+
+// textDependencies 识别Java的package/import和C#的namespace/using
+// Java导入的类作为对该包中符号的引用，C#只能识别到命名空间级别
+func textDependencies(lines []string, language common.LanguageType) *Dependencies {
+	var packagePattern, importPattern *regexp.Regexp
+	switch language {
+	case common.Java:
+		packagePattern, importPattern = javaPackagePattern, javaImportPattern
+	case common.CSharp:
+		packagePattern, importPattern = csharpNamespacePattern, csharpUsingPattern
+	default:
+		return nil
+	}
+
+	deps := &Dependencies{}
+	for _, line := range StripComments(lines, language) {
+		if match := packagePattern.FindStringSubmatch(line); match != nil && deps.Package == "" {
+			deps.Package = match[1]
+			continue
+		}
+		if match := importPattern.FindStringSubmatch(line); match != nil {
+			deps.Imports = append(deps.Imports, match[1])
+			if language == common.Java && !strings.HasSuffix(match[1], ".*") {
+				idx := strings.LastIndex(match[1], ".")
+				deps.References = append(deps.References, match[1][:max(idx, 0)]+"#"+match[1][idx+1:])
+			}
+			continue
+		}
+		deps.Types += len(typeDeclPattern.FindAllString(line, -1))
+		deps.AbstractTypes += len(abstractTypePattern.FindAllString(line, -1))
+	}
+
+	return deps
+}
@@ -26,17 +26,23 @@ func (p *GenericParser) Parse(filePath string, content []byte) (ParseResult, err
 		CommentLines: 0,
 		TotalLines:   len(lines),
 		Language:     common.Unsupported,
+		FilePath:     filePath,
+		Source:       content,
 	}
 
 	// 检测语言类型
 	detector := common.NewLanguageDetector()
 	result.Language = detector.DetectLanguage(filePath)
+	result.Identifiers = textIdentifiers(lines, result.Language)
+	result.Dependencies = textDependencies(lines, result.Language)
+	result.Classes = textClasses(lines, result.Language)
 
 	// 计算注释行数
 	result.CommentLines = p.countCommentLines(contentStr, result.Language)
 
 	// 尝试识别函数
 	result.Functions = p.detectFunctions(contentStr, lines, result.Language)
+	assignOwners(result.Functions, result.Classes)
 
 	return result, nil
 }
@@ -32,6 +32,13 @@ func (p *GoParser) Parse(filePath string, content []byte) (ParseResult, error) {
 		CommentLines: 0,
 		TotalLines:   strings.Count(string(content), "\n") + 1,
 		Language:     common.Go,
+		ASTRoot:      file,
+		FileSet:      fileSet,
+		FilePath:     filePath,
+		Source:       content,
+		Identifiers:  goIdentifiers(fileSet, file),
+		Dependencies: goDependencies(file),
+		Classes:      goClasses(fileSet, file),
 	}
 
 	// 计算注释行数
@@ -59,6 +66,7 @@ func (p *GoParser) Parse(filePath string, content []byte) (ParseResult, error) {
 		}
 		return true
 	})
+	assignOwners(result.Functions, result.Classes)
 
 	return result, nil
 }
@@ -0,0 +1,345 @@
+// Package parser 提供多语言代码解析功能
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+)
+
+// IdentifierKind 标识符类别
+type IdentifierKind string
+
+// 标识符类别
+const (
+	IdentPackage  IdentifierKind = "package"  // 包名、命名空间
+	IdentType     IdentifierKind = "type"     // 类、结构体、接口、枚举等类型
+	IdentFunction IdentifierKind = "function" // 函数
+	IdentMethod   IdentifierKind = "method"   // 方法
+	IdentVariable IdentifierKind = "variable" // 变量和字段
+	IdentConstant IdentifierKind = "constant" // 常量和宏
+)
+
+// IdentifierKinds 返回所有标识符类别
+func IdentifierKinds() []IdentifierKind {
+	return []IdentifierKind{IdentPackage, IdentType, IdentFunction, IdentMethod, IdentVariable, IdentConstant}
+}
+
+// Identifier 代码中声明的标识符
+type Identifier struct {
+	Name string         // 名称
+	Kind IdentifierKind // 类别
+	Line int            // 声明所在行，从1开始
+}
+
+// GetIdentifiers 获取解析出的标识符声明
+func (r *BaseParseResult) GetIdentifiers() []Identifier {
+	return r.Identifiers
+}
+
+// stringLiteralPattern 单行字符串字面量
+var stringLiteralPattern = regexp.MustCompile(`"(?:\\.|[^"\\])*"|'(?:\\.|[^'\\])*'|` + "`[^`]*`")
+
+// StripComments 将字符串字面量替换为空字符串并去掉注释，行数保持不变
+func StripComments(lines []string, language common.LanguageType) []string {
+	result := make([]string, len(lines))
+	inBlock := false
+	for i, line := range lines {
+		line = stringLiteralPattern.ReplaceAllString(line, `""`)
+
+		if language == common.Python {
+			if idx := strings.Index(line, "#"); idx != -1 {
+				line = line[:idx]
+			}
+			result[i] = line
+			continue
+		}
+
+		var b strings.Builder
+		for j := 0; j < len(line); j++ {
+			switch {
+			case inBlock:
+				if strings.HasPrefix(line[j:], "*/") {
+					inBlock = false
+					j++
+				}
+			case strings.HasPrefix(line[j:], "/*"):
+				inBlock = true
+				j++
+			case strings.HasPrefix(line[j:], "//"):
+				j = len(line)
+			default:
+				b.WriteByte(line[j])
+			}
+		}
+		result[i] = b.String()
+	}
+	return result
+}
+
+// goIdentifiers 从Go AST中收集标识符声明
+func goIdentifiers(fileSet *token.FileSet, file *ast.File) []Identifier {
+	line := func(pos token.Pos) int {
+		return fileSet.Position(pos).Line
+	}
+
+	identifiers := []Identifier{{Name: file.Name.Name, Kind: IdentPackage, Line: line(file.Name.Pos())}}
+	add := func(ident *ast.Ident, kind IdentifierKind) {
+		if ident != nil && ident.Name != "_" {
+			identifiers = append(identifiers, Identifier{Name: ident.Name, Kind: kind, Line: line(ident.Pos())})
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncDecl:
+			if node.Recv != nil {
+				add(node.Name, IdentMethod)
+			} else {
+				add(node.Name, IdentFunction)
+			}
+		case *ast.TypeSpec:
+			add(node.Name, IdentType)
+		case *ast.GenDecl:
+			kind := IdentVariable
+			if node.Tok == token.CONST {
+				kind = IdentConstant
+			}
+			for _, spec := range node.Specs {
+				if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+					for _, name := range valueSpec.Names {
+						add(name, kind)
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			if node.Tok == token.DEFINE {
+				for _, expr := range node.Lhs {
+					if ident, ok := expr.(*ast.Ident); ok {
+						add(ident, IdentVariable)
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	return identifiers
+}
+
+// Python标识符声明模式
+var (
+	pyClassPattern  = regexp.MustCompile(`^(\s*)class\s+([A-Za-z_]\w*)`)
+	pyDefPattern    = regexp.MustCompile(`^(\s*)(?:async\s+)?def\s+([A-Za-z_]\w*)`)
+	pyAssignPattern = regexp.MustCompile(`^(\s*)([A-Za-z_]\w*)\s*(?::\s*[^=]+)?=[^=]`)
+)
+
+// pythonIdentifiers 按缩进识别Python中的类、函数、方法和变量
+// 模块级的全大写变量视为常量
+func pythonIdentifiers(lines []string) []Identifier {
+	type scope struct {
+		indent  int
+		isClass bool
+	}
+
+	var identifiers []Identifier
+	var scopes []scope
+
+	for i, line := range StripComments(lines, common.Python) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		for len(scopes) > 0 && scopes[len(scopes)-1].indent >= indent {
+			scopes = scopes[:len(scopes)-1]
+		}
+		inClass := len(scopes) > 0 && scopes[len(scopes)-1].isClass
+
+		switch {
+		case pyClassPattern.MatchString(line):
+			name := pyClassPattern.FindStringSubmatch(line)[2]
+			identifiers = append(identifiers, Identifier{Name: name, Kind: IdentType, Line: i + 1})
+			scopes = append(scopes, scope{indent: indent, isClass: true})
+		case pyDefPattern.MatchString(line):
+			kind := IdentFunction
+			if inClass {
+				kind = IdentMethod
+			}
+			name := pyDefPattern.FindStringSubmatch(line)[2]
+			identifiers = append(identifiers, Identifier{Name: name, Kind: kind, Line: i + 1})
+			scopes = append(scopes, scope{indent: indent})
+		case pyAssignPattern.MatchString(line):
+			name := pyAssignPattern.FindStringSubmatch(line)[2]
+			kind := IdentVariable
+			if len(scopes) == 0 && strings.ToUpper(name) == name {
+				kind = IdentConstant
+			}
+			identifiers = append(identifiers, Identifier{Name: name, Kind: kind, Line: i + 1})
+		}
+	}
+
+	return identifiers
+}
+
+// identifierPattern 一种标识符声明模式，名称位于第一个捕获组
+type identifierPattern struct {
+	kind    IdentifierKind
+	pattern *regexp.Regexp
+}
+
+// 花括号语言的标识符声明模式，同一行按顺序只取第一个匹配
+var (
+	typePattern = identifierPattern{IdentType, regexp.MustCompile(`\b(?:class|interface|enum|struct|record|union|trait)\s+([A-Za-z_$][\w$]*)`)}
+
+	javaIdentifierPatterns = []identifierPattern{
+		{IdentPackage, regexp.MustCompile(`^\s*package\s+([\w.]+)\s*;`)},
+		typePattern,
+		{IdentConstant, regexp.MustCompile(`\bstatic\s+final\s+[\w<>\[\],.? ]+?\s+([A-Za-z_$][\w$]*)\s*=`)},
+		{IdentMethod, regexp.MustCompile(`^\s*(?:(?:public|private|protected|static|final|abstract|synchronized|native|default)\s+)*(?:<[^>]+>\s+)?[\w<>\[\],.?]+\s+([A-Za-z_$][\w$]*)\s*\((?:[^;)]*$|[^;]*?\)\s*(?:throws\s+[\w.,\s]+?)?\s*(?:\{|$))`)},
+		{IdentVariable, regexp.MustCompile(`^\s*(?:(?:public|private|protected|static|final|volatile|transient)\s+)*(?:[A-Z][\w.]*(?:<[^;=()]*>)?|int|long|double|float|boolean|char|byte|short|var)(?:\[\])*\s+([A-Za-z_$][\w$]*)\s*[=;]`)},
+	}
+
+	csharpIdentifierPatterns = []identifierPattern{
+		{IdentPackage, regexp.MustCompile(`^\s*namespace\s+([\w.]+)`)},
+		typePattern,
+		{IdentConstant, regexp.MustCompile(`\bconst\s+[\w<>\[\],.?]+\s+([A-Za-z_]\w*)\s*=`)},
+		{IdentMethod, regexp.MustCompile(`^\s*(?:(?:public|private|protected|internal|static|virtual|override|abstract|sealed|async|extern|unsafe|new|partial)\s+)*[\w<>\[\],.?]+\s+([A-Za-z_]\w*)\s*(?:<[^>]*>)?\s*\((?:[^;)]*$|[^;]*?\)\s*(?:where\s+[^{;]+?)?\s*(?:\{|=>|$))`)},
+		{IdentVariable, regexp.MustCompile(`^\s*(?:(?:public|private|protected|internal|static|readonly|volatile)\s+)*(?:[A-Z][\w.]*(?:<[^;=()]*>)?|int|long|double|float|bool|char|byte|short|string|object|decimal|var)(?:\[\])*\??\s+([A-Za-z_]\w*)\s*[=;]`)},
+	}
+
+	jsIdentifierPatterns = []identifierPattern{
+		typePattern,
+		{IdentType, regexp.MustCompile(`^\s*(?:export\s+)?type\s+([A-Za-z_$][\w$]*)\s*(?:<[^>]*>)?\s*=`)},
+		{IdentFunction, regexp.MustCompile(`\bfunction\s*\*?\s*([A-Za-z_$][\w$]*)`)},
+		{IdentFunction, regexp.MustCompile(`\b(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=\s*(?:async\s+)?(?:function\b|\([^)]*\)\s*(?::[^=]+)?=>|[A-Za-z_$][\w$]*\s*=>)`)},
+		{IdentConstant, regexp.MustCompile(`\bconst\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=`)},
+		{IdentVariable, regexp.MustCompile(`\b(?:let|var)\s+([A-Za-z_$][\w$]*)`)},
+		{IdentMethod, regexp.MustCompile(`^\s*(?:(?:static|async|get|set|public|private|protected|readonly|override)\s+)*([A-Za-z_$][\w$]*)\s*\([^)]*\)\s*(?::\s*[^{=]+)?\{\s*$`)},
+	}
+
+	cIdentifierPatterns = []identifierPattern{
+		{IdentConstant, regexp.MustCompile(`^\s*#\s*define\s+([A-Za-z_]\w*)`)},
+		{IdentType, regexp.MustCompile(`^\s*typedef\b.*?\b([A-Za-z_]\w*)\s*;\s*$`)},
+		{IdentType, regexp.MustCompile(`^\s*(?:typedef\s+)?(?:class|struct|union|enum(?:\s+class)?)\s+([A-Za-z_]\w*)\s*(?:[:{]|$)`)},
+		{IdentFunction, regexp.MustCompile(`^\s*(?:[\w:*&<>,]+\s+)+[*&]*([A-Za-z_~][\w:~]*)\s*\([^;]*\)\s*(?:const\s*)?(?:noexcept\s*)?(?:override\s*)?\{?\s*$`)},
+		{IdentVariable, regexp.MustCompile(`^\s*(?:(?:static|const|unsigned|signed|extern|volatile|register)\s+)*(?:int|char|float|double|long|short|bool|size_t|auto|[A-Za-z_]\w*_t)\s*[*&]*\s*([A-Za-z_]\w*)\s*(?:=|;|\[)`)},
+	}
+)
+
+// notIdentifiers 会被声明模式误匹配的关键字
+var notIdentifiers = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true, "return": true,
+	"else": true, "new": true, "sizeof": true, "typeof": true, "do": true, "try": true,
+	"case": true, "throw": true, "delete": true, "await": true, "function": true, "using": true,
+	"constructor": true, "operator": true, "main": true,
+}
+
+// braceIdentifiers 使用声明模式识别花括号语言中的标识符
+// 与类型同名的方法视为构造函数，不作为方法检查
+func braceIdentifiers(lines []string, language common.LanguageType) []Identifier {
+	var patterns []identifierPattern
+	switch language {
+	case common.Java:
+		patterns = javaIdentifierPatterns
+	case common.CSharp:
+		patterns = csharpIdentifierPatterns
+	case common.JavaScript, common.TypeScript:
+		patterns = jsIdentifierPatterns
+	case common.C, common.CPlusPlus:
+		patterns = cIdentifierPatterns
+	default:
+		return nil
+	}
+
+	var identifiers []Identifier
+	typeNames := make(map[string]bool)
+
+	for i, line := range StripComments(lines, language) {
+		for _, p := range patterns {
+			match := p.pattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+
+			name, kind := match[1], p.kind
+			// C++中 Class::method 形式的定义是方法
+			if idx := strings.LastIndex(name, "::"); idx != -1 {
+				name, kind = name[idx+2:], IdentMethod
+			}
+			if name == "" || notIdentifiers[name] || strings.HasPrefix(name, "~") {
+				break
+			}
+
+			if kind == IdentType {
+				typeNames[name] = true
+			}
+			identifiers = append(identifiers, Identifier{Name: name, Kind: kind, Line: i + 1})
+			break
+		}
+
+		// 单行代码块中的局部变量，如 void f(int x) { int y = x; }
+		for _, name := range inlineVariables(line, patterns) {
+			if !notIdentifiers[name] && !declaredOnLine(identifiers, name, i+1) {
+				identifiers = append(identifiers, Identifier{Name: name, Kind: IdentVariable, Line: i + 1})
+			}
+		}
+	}
+
+	// 构造函数与类型同名，不单独检查
+	result := identifiers[:0]
+	for _, ident := range identifiers {
+		if (ident.Kind == IdentMethod || ident.Kind == IdentFunction) && typeNames[ident.Name] {
+			continue
+		}
+		result = append(result, ident)
+	}
+	return result
+}
+
+// inlineVariables 识别行内第一个 { 之后各语句声明的变量
+// 变量声明模式从行首匹配，这里把 { 和 ; 之后的每个位置当作语句开头
+func inlineVariables(line string, patterns []identifierPattern) []string {
+	start := strings.Index(line, "{")
+	if start == -1 {
+		return nil
+	}
+
+	var names []string
+	for i := start; i < len(line); i++ {
+		if line[i] != '{' && line[i] != ';' {
+			continue
+		}
+		for _, p := range patterns {
+			if p.kind != IdentVariable {
+				continue
+			}
+			if match := p.pattern.FindStringSubmatch(line[i+1:]); match != nil {
+				names = append(names, match[1])
+				break
+			}
+		}
+	}
+	return names
+}
+
+// declaredOnLine 判断同一行是否已识别出同名标识符
+func declaredOnLine(identifiers []Identifier, name string, line int) bool {
+	for i := len(identifiers) - 1; i >= 0 && identifiers[i].Line == line; i-- {
+		if identifiers[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// textIdentifiers 根据语言选择基于文本的标识符识别方式
+func textIdentifiers(lines []string, language common.LanguageType) []Identifier {
+	if language == common.Python {
+		return pythonIdentifiers(lines)
+	}
+	return braceIdentifiers(lines, language)
+}
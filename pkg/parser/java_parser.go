@@ -25,6 +25,11 @@ func (p *JavaParser) Parse(filePath string, content []byte) (ParseResult, error)
 		CommentLines: 0,
 		TotalLines:   len(lines),
 		Language:     common.Java,
+		FilePath:     filePath,
+		Source:       content,
+		Identifiers:  textIdentifiers(lines, common.Java),
+		Dependencies: textDependencies(lines, common.Java),
+		Classes:      textClasses(lines, common.Java),
 	}
 
 	// 计算注释行数
@@ -33,6 +38,7 @@ func (p *JavaParser) Parse(filePath string, content []byte) (ParseResult, error)
 	// 由于ANTLR解析器的复杂性，这里使用基于文本的简化分析
 	// 对于实际应用中的完整功能，需要使用完整的ANTLR生成的解析器
 	result.Functions = p.detectJavaFunctions(contentStr, lines)
+	assignOwners(result.Functions, result.Classes)
 
 	return result, nil
 }
@@ -27,6 +27,10 @@ func (p *JavaScriptParser) Parse(filePath string, content []byte) (ParseResult,
 		CommentLines: 0,
 		TotalLines:   len(lines),
 		Language:     common.JavaScript,
+		FilePath:     filePath,
+		Source:       content,
+		Identifiers:  textIdentifiers(lines, common.JavaScript),
+		Classes:      textClasses(lines, common.JavaScript),
 	}
 
 	// 计算注释行数
@@ -73,6 +77,8 @@ func (p *JavaScriptParser) Parse(filePath string, content []byte) (ParseResult,
 		}
 	}
 
+	assignOwners(result.Functions, result.Classes)
+
 	return result, nil
 }
 
@@ -3,6 +3,8 @@
 package parser
 
 import (
+	"go/token"
+
 	"github.com/Done-0/fuck-u-code/pkg/common"
 )
 
@@ -46,6 +48,7 @@ type Function struct {
 	EndLine    int         // 结束行
 	Complexity int         // 复杂度
 	Parameters int         // 参数数量
+	Owner      string      // 所属的类或接收者类型，普通函数为空
 	Node       interface{} // AST节点(可选)
 }
 
@@ -56,6 +59,17 @@ type BaseParseResult struct {
 	TotalLines   int                 // 总行数
 	Language     common.LanguageType // 语言类型
 	ASTRoot      interface{}         // AST根节点
+	FileSet      *token.FileSet      // AST节点位置所在的文件集，只有Go解析结果提供
+	FilePath     string              // 文件路径
+	Source       []byte              // 源码内容
+	Identifiers  []Identifier        // 声明的标识符
+	Dependencies *Dependencies       // 包归属和依赖信息
+	Classes      []Class             // 类及其方法
+
+	tokens        []Token         // 词法单元，首次使用时生成
+	comments      []Comment       // 注释，首次使用时提取
+	commentedCode []CommentedCode // 注释掉的代码，首次使用时识别
+	publicAPI     []APISymbol     // 公开的API，首次使用时识别
 }
 
 // GetFunctions 获取解析出的所有函数
@@ -83,6 +97,21 @@ func (r *BaseParseResult) GetASTRoot() interface{} {
 	return r.ASTRoot
 }
 
+// GetFileSet 获取AST节点位置所在的文件集
+func (r *BaseParseResult) GetFileSet() *token.FileSet {
+	return r.FileSet
+}
+
+// GetFilePath 获取文件路径
+func (r *BaseParseResult) GetFilePath() string {
+	return r.FilePath
+}
+
+// GetSource 获取源码内容
+func (r *BaseParseResult) GetSource() []byte {
+	return r.Source
+}
+
 // CreateParser 根据语言类型创建解析器
 func CreateParser(language common.LanguageType) Parser {
 	switch language {
@@ -47,6 +47,10 @@ func (p *PythonParser) Parse(filePath string, content []byte) (ParseResult, erro
 		CommentLines: 0,
 		TotalLines:   len(lines),
 		Language:     common.Python,
+		FilePath:     filePath,
+		Source:       content,
+		Identifiers:  textIdentifiers(lines, common.Python),
+		Classes:      textClasses(lines, common.Python),
 	}
 
 	// 计算注释行数
@@ -58,6 +62,7 @@ func (p *PythonParser) Parse(filePath string, content []byte) (ParseResult, erro
 		// 如果解析失败，使用备用的基于文本的方法
 		functions := p.detectFunctions(contentStr, lines)
 		result.Functions = functions
+		assignOwners(result.Functions, result.Classes)
 		return result, nil
 	}
 
@@ -66,6 +71,7 @@ func (p *PythonParser) Parse(filePath string, content []byte) (ParseResult, erro
 
 	// 提取函数信息
 	p.extractFunctions(ast, contentStr, &result.Functions)
+	assignOwners(result.Functions, result.Classes)
 
 	return result, nil
 }
@@ -329,7 +335,7 @@ func (p *PythonParser) calculateComplexity(stmts []PythonStatement, complexity *
 	}
 }
 
-// Python AST结构定义
+// PythonAST Python AST结构定义
 type PythonAST struct {
 	Statements []PythonStatement `parser:"@@*"`
 }
@@ -0,0 +1,207 @@
+// Package parser 提供多语言代码解析功能
+package parser
+
+import (
+	"go/scanner"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+)
+
+// TokenKind 词法单元类别，按Halstead度量的定义区分操作符和操作数
+type TokenKind int
+
+// 词法单元类别
+const (
+	TokenOperator TokenKind = iota // 运算符、关键字和分隔符
+	TokenOperand                   // 标识符和字面量
+)
+
+// Token 词法单元，注释和空白不产生词法单元
+type Token struct {
+	Text string    // 文本，字符串字面量保留原文
+	Kind TokenKind // 类别
+	Line int       // 所在行，从1开始
+}
+
+// GetTokens 获取源码的词法单元，首次调用时才进行切分
+func (r *BaseParseResult) GetTokens() []Token {
+	if r.tokens == nil {
+		r.tokens = Tokenize(r.Source, r.Language)
+	}
+	return r.tokens
+}
+
+// Tokenize 将源码切分为词法单元
+// Go使用标准库的词法分析器，其他语言使用通用的类C词法规则，Python额外支持#注释和三引号字符串
+func Tokenize(source []byte, language common.LanguageType) []Token {
+	if language == common.Go {
+		return goTokens(source)
+	}
+	return genericTokens(string(source), language)
+}
+
+// goTokens 使用go/scanner切分Go源码
+func goTokens(source []byte) []Token {
+	fileSet := token.NewFileSet()
+	file := fileSet.AddFile("", fileSet.Base(), len(source))
+
+	var s scanner.Scanner
+	s.Init(file, source, nil, 0)
+
+	tokens := make([]Token, 0, len(source)/4)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+
+		switch {
+		case tok == token.SEMICOLON && lit == "\n":
+			// 自动插入的分号不是源码中的运算符
+			continue
+		case tok == token.RPAREN || tok == token.RBRACK || tok == token.RBRACE:
+			// 成对的括号只计一次
+			continue
+		case tok == token.IDENT || tok.IsLiteral():
+			tokens = append(tokens, Token{Text: lit, Kind: TokenOperand, Line: file.Line(pos)})
+		default:
+			tokens = append(tokens, Token{Text: tok.String(), Kind: TokenOperator, Line: file.Line(pos)})
+		}
+	}
+	return tokens
+}
+
+// genericKeywords 通用词法规则中按运算符处理的关键字
+var genericKeywords = map[string]bool{
+	"if": true, "else": true, "elif": true, "for": true, "foreach": true, "while": true, "do": true,
+	"switch": true, "case": true, "default": true, "break": true, "continue": true, "return": true,
+	"goto": true, "try": true, "catch": true, "finally": true, "throw": true, "throws": true,
+	"except": true, "raise": true, "with": true, "yield": true, "await": true, "async": true,
+	"new": true, "delete": true, "typeof": true, "instanceof": true, "sizeof": true, "in": true,
+	"is": true, "not": true, "and": true, "or": true, "lambda": true, "def": true, "class": true,
+	"struct": true, "interface": true, "enum": true, "function": true, "var": true, "let": true,
+	"const": true, "static": true, "final": true, "public": true, "private": true, "protected": true,
+	"internal": true, "import": true, "from": true, "package": true, "namespace": true, "using": true,
+	"extends": true, "implements": true, "pass": true, "global": true, "nonlocal": true, "assert": true,
+	"void": true, "readonly": true, "override": true, "virtual": true, "abstract": true,
+}
+
+// genericOperators 通用词法规则的多字符运算符，按长度从长到短匹配
+var genericOperators = []string{
+	">>>=", "<<=", ">>=", ">>>", "===", "!==", "**=", "//=", "...", "?.", "??=",
+	"==", "!=", "<=", ">=", "&&", "||", "++", "--", "+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=",
+	"<<", ">>", "->", "=>", "::", "**", "//", "??", ":=",
+}
+
+// genericTokens 使用通用的类C词法规则切分源码
+func genericTokens(source string, language common.LanguageType) []Token {
+	python := language == common.Python
+	tokens := make([]Token, 0, len(source)/4)
+	line := 1
+
+	for i := 0; i < len(source); {
+		c := source[i]
+		rest := source[i:]
+
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case python && c == '#', !python && strings.HasPrefix(rest, "//"):
+			// 单行注释
+			end := strings.IndexByte(rest, '\n')
+			if end == -1 {
+				end = len(rest)
+			}
+			i += end
+		case !python && strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end == -1 {
+				end = len(rest)
+			} else {
+				end += 4
+			}
+			line += strings.Count(rest[:end], "\n")
+			i += end
+		case c == '"' || c == '\'' || c == '`':
+			end := stringLiteralEnd(rest, python)
+			tokens = append(tokens, Token{Text: rest[:end], Kind: TokenOperand, Line: line})
+			line += strings.Count(rest[:end], "\n")
+			i += end
+		case isIdentStart(c):
+			end := 1
+			for end < len(rest) && isIdentPart(rest[end]) {
+				end++
+			}
+			word := rest[:end]
+			kind := TokenOperand
+			if genericKeywords[word] {
+				kind = TokenOperator
+			}
+			tokens = append(tokens, Token{Text: word, Kind: kind, Line: line})
+			i += end
+		case c >= '0' && c <= '9':
+			end := 1
+			for end < len(rest) && (isIdentPart(rest[end]) || rest[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, Token{Text: rest[:end], Kind: TokenOperand, Line: line})
+			i += end
+		case c == ')' || c == ']' || c == '}':
+			// 成对的括号只计一次
+			i++
+		default:
+			op := rest[:1]
+			for _, candidate := range genericOperators {
+				if strings.HasPrefix(rest, candidate) {
+					op = candidate
+					break
+				}
+			}
+			tokens = append(tokens, Token{Text: op, Kind: TokenOperator, Line: line})
+			i += len(op)
+		}
+	}
+
+	return tokens
+}
+
+// stringLiteralEnd 返回以引号开头的字符串字面量的长度
+// 支持转义字符，反引号和Python三引号字符串可以跨行
+func stringLiteralEnd(s string, python bool) int {
+	quote := s[:1]
+	if python && (strings.HasPrefix(s, `"""`) || strings.HasPrefix(s, "'''")) {
+		if end := strings.Index(s[3:], s[:3]); end != -1 {
+			return end + 6
+		}
+		return len(s)
+	}
+
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+		case s[i:i+1] == quote:
+			return i + 1
+		case s[i] == '\n' && quote != "`":
+			// 未闭合的字符串在行尾结束
+			return i
+		}
+	}
+	return len(s)
+}
+
+// isIdentStart 判断字符能否作为标识符开头
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 || unicode.IsLetter(rune(c))
+}
+
+// isIdentPart 判断字符能否出现在标识符中
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,102 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/analyzer"
+)
+
+// checkstyleReport Checkstyle报告根节点
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+// checkstyleFile Checkstyle文件节点
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+// checkstyleError Checkstyle问题节点
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// 严重问题的关键字
+var severeIssueKeywords = []string{
+	"极", "严重", "必须", "过高", "过多", "过长",
+	"extremely", "very high", "too many", "must", "severe",
+}
+
+// GenerateCheckstyleReport 生成Checkstyle XML格式报告
+// 最差函数以info级别标注在函数开始行
+func (r *Report) GenerateCheckstyleReport(options *ReportOptions) error {
+	if options == nil {
+		options = DefaultReportOptions
+	}
+
+	output := checkstyleReport{Version: "4.3"}
+
+	worstFunctions := make(map[string][]analyzer.FunctionResult)
+	for _, fn := range r.getWorstFunctions(options) {
+		worstFunctions[fn.FilePath] = append(worstFunctions[fn.FilePath], fn)
+	}
+
+	for _, f := range r.getSortedFiles() {
+		file := checkstyleFile{Name: f.FilePath}
+
+		content, err := f.ReadSource()
+		if err != nil {
+			content = nil
+		}
+
+		for _, issue := range f.Issues {
+			line := analyzer.LocateIssue(issue, content)
+			if line == 0 {
+				line = 1
+			}
+
+			file.Errors = append(file.Errors, checkstyleError{
+				Line:     line,
+				Severity: getIssueSeverity(issue),
+				Message:  issue,
+				Source:   "fuck-u-code." + getIssueCategory(issue),
+			})
+		}
+
+		for _, fn := range worstFunctions[f.FilePath] {
+			file.Errors = append(file.Errors, checkstyleError{
+				Line:     max(fn.StartLine, 1),
+				Severity: "info",
+				Message: fmt.Sprintf("%s: %s=%d, %s=%d, %s=%d",
+					fn.QualifiedName(),
+					r.translator.Translate("report.functions.complexity"), fn.Complexity,
+					r.translator.Translate("report.functions.lines"), fn.Lines,
+					r.translator.Translate("report.functions.params"), fn.Parameters),
+				Source: "fuck-u-code.function",
+			})
+		}
+
+		output.Files = append(output.Files, file)
+	}
+
+	return writeXML(output)
+}
+
+// getIssueSeverity 根据问题内容判断严重程度
+func getIssueSeverity(issue string) string {
+	lowerIssue := strings.ToLower(issue)
+	for _, keyword := range severeIssueKeywords {
+		if strings.Contains(lowerIssue, keyword) {
+			return "error"
+		}
+	}
+	return "warning"
+}
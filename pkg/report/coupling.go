@@ -0,0 +1,110 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/Done-0/fuck-u-code/pkg/analyzer"
+)
+
+// couplingTopPackages 报告中列出的包数量
+const couplingTopPackages = 10
+
+// getCoupling 返回报告中列出的包，只保留与其他包有依赖关系的包
+func (r *Report) getCoupling() []analyzer.PackageCoupling {
+	var packages []analyzer.PackageCoupling
+	for _, pkg := range r.result.Coupling {
+		if pkg.Afferent+pkg.Efferent == 0 {
+			continue
+		}
+		packages = append(packages, pkg)
+		if len(packages) == couplingTopPackages {
+			break
+		}
+	}
+	return packages
+}
+
+// couplingHeaders 返回耦合度表格的表头
+func (r *Report) couplingHeaders() []string {
+	return []string{
+		r.translator.Translate("report.coupling.package"),
+		"Ca",
+		"Ce",
+		"I",
+		"A",
+		"D",
+		r.translator.Translate("report.coupling.fan_in"),
+		r.translator.Translate("report.coupling.fan_out"),
+	}
+}
+
+// zoneOfPainMark 返回处于痛苦区的包名后附加的标记
+func (r *Report) zoneOfPainMark(pkg analyzer.PackageCoupling) string {
+	if !pkg.ZoneOfPain {
+		return ""
+	}
+	return " " + r.translator.Translate("report.coupling.zone_of_pain")
+}
+
+// couplingCells 返回包的耦合度表格行
+func (r *Report) couplingCells(pkg analyzer.PackageCoupling) []string {
+	return []string{
+		pkg.Name + r.zoneOfPainMark(pkg),
+		fmt.Sprintf("%d", pkg.Afferent),
+		fmt.Sprintf("%d", pkg.Efferent),
+		fmt.Sprintf("%.2f", pkg.Instability),
+		fmt.Sprintf("%.2f", pkg.Abstractness),
+		fmt.Sprintf("%.2f", pkg.Distance),
+		fmt.Sprintf("%d", pkg.FanIn),
+		fmt.Sprintf("%d", pkg.FanOut),
+	}
+}
+
+// printCoupling 打印包耦合度表格，按与主序列的距离从大到小排列
+func (r *Report) printCoupling() {
+	packages := r.getCoupling()
+	if len(packages) == 0 {
+		return
+	}
+
+	sectionStyle.Printf("\n◆ %s\n\n", r.translator.Translate("report.coupling"))
+	infoStyle.Printf("  %s\n\n", r.translator.Translate("report.coupling.legend"))
+
+	headers := r.couplingHeaders()
+	cells := make([][]string, 0, len(packages))
+	for _, pkg := range packages {
+		cells = append(cells, r.couplingCells(pkg))
+	}
+
+	widths := columnWidths(headers, cells)
+	headerStyle.Printf("  %s\n", joinPadded(headers, widths))
+	for i, row := range cells {
+		if packages[i].ZoneOfPain {
+			dangerStyle.Printf("  %s", padDisplay(row[0], widths[0]))
+		} else {
+			metricStyle.Printf("  %s", padDisplay(row[0], widths[0]))
+		}
+		numberStyle.Printf("  %s\n", joinPadded(row[1:], widths[1:]))
+	}
+}
+
+// printMarkdownCoupling 打印Markdown格式的包耦合度表格
+func (r *Report) printMarkdownCoupling() {
+	packages := r.getCoupling()
+	if len(packages) == 0 {
+		return
+	}
+
+	fmt.Printf("## %s\n\n", r.translator.Translate("report.coupling"))
+	fmt.Printf("%s\n\n", r.translator.Translate("report.coupling.legend"))
+
+	headers := r.couplingHeaders()
+	fmt.Printf("| %s | %s | %s | %s | %s | %s | %s | %s |\n", headers[0], headers[1], headers[2], headers[3], headers[4], headers[5], headers[6], headers[7])
+	fmt.Println("|------|------|------|------|------|------|------|------|")
+	for _, pkg := range packages {
+		row := r.couplingCells(pkg)
+		row[0] = "`" + pkg.Name + "`" + r.zoneOfPainMark(pkg)
+		fmt.Printf("| %s | %s | %s | %s | %s | %s | %s | %s |\n", row[0], row[1], row[2], row[3], row[4], row[5], row[6], row[7])
+	}
+	fmt.Println()
+}
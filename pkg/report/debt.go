@@ -0,0 +1,251 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Done-0/fuck-u-code/pkg/analyzer"
+	"github.com/Done-0/fuck-u-code/pkg/debt"
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+)
+
+// debtTopFiles 报告中列出的文件数量
+const debtTopFiles = 10
+
+// debtDateLayout 标记引入时间的显示格式
+const debtDateLayout = "2006-01-02"
+
+// jsonDebt 技术债标记汇总
+type jsonDebt struct {
+	Total     int              `json:"total"`
+	Untracked int              `json:"untracked"`
+	ByKind    map[string]int   `json:"by_kind"`
+	Files     []jsonDebtFile   `json:"files"`
+	Oldest    []jsonDebtMarker `json:"oldest,omitempty"`
+	Markers   []jsonDebtMarker `json:"markers"`
+}
+
+// jsonDebtFile 文件中的技术债标记数
+type jsonDebtFile struct {
+	Path      string `json:"path"`
+	Markers   int    `json:"markers"`
+	Untracked int    `json:"untracked"`
+}
+
+// jsonDebtMarker 技术债标记
+type jsonDebtMarker struct {
+	Path   string `json:"path"`
+	Line   int    `json:"line"`
+	Kind   string `json:"kind"`
+	Author string `json:"author,omitempty"`
+	Ticket string `json:"ticket,omitempty"`
+	Text   string `json:"text"`
+	Date   string `json:"date,omitempty"`
+}
+
+// buildJSONDebtMarker 转换技术债标记，没有引入时间时不输出日期
+func buildJSONDebtMarker(marker debt.Marker) jsonDebtMarker {
+	return jsonDebtMarker{
+		Path:   marker.File,
+		Line:   marker.Line,
+		Kind:   string(marker.Kind),
+		Author: marker.Author,
+		Ticket: marker.Ticket,
+		Text:   marker.Text,
+		Date:   formatDebtDate(marker.Date),
+	}
+}
+
+// buildJSONDebt 转换技术债标记汇总，没有标记时返回nil
+func buildJSONDebt(summary *analyzer.DebtSummary) *jsonDebt {
+	if summary == nil {
+		return nil
+	}
+
+	output := &jsonDebt{
+		Total:     summary.Total,
+		Untracked: summary.Untracked,
+		ByKind:    make(map[string]int, len(summary.ByKind)),
+		Files:     make([]jsonDebtFile, 0, len(summary.Files)),
+		Markers:   make([]jsonDebtMarker, 0, len(summary.Markers)),
+	}
+	for kind, count := range summary.ByKind {
+		output.ByKind[string(kind)] = count
+	}
+	for _, file := range summary.Files {
+		output.Files = append(output.Files, jsonDebtFile{Path: file.FilePath, Markers: file.Markers, Untracked: file.Untracked})
+	}
+	for _, marker := range summary.Oldest {
+		output.Oldest = append(output.Oldest, buildJSONDebtMarker(marker))
+	}
+	for _, marker := range summary.Markers {
+		output.Markers = append(output.Markers, buildJSONDebtMarker(marker))
+	}
+	return output
+}
+
+// formatDebtDate 格式化标记的引入时间，没有时间时返回空
+func formatDebtDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(debtDateLayout)
+}
+
+// debtKinds 按严重程度列出各类标记的数量，如 "FIXME 2, TODO 5"
+func debtKinds(byKind map[debt.Kind]int) string {
+	var parts []string
+	for _, kind := range debt.Kinds {
+		if count := byKind[kind]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", kind, count))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// debtSummary 返回技术债标记的概要
+func (r *Report) debtSummary() string {
+	summary := r.result.Debt
+	return r.translator.Translate("report.debt.summary", summary.Total, summary.Untracked, debtKinds(summary.ByKind))
+}
+
+// getDebtFiles 返回报告中列出的文件
+func (r *Report) getDebtFiles() []analyzer.FileDebt {
+	files := r.result.Debt.Files
+	if len(files) > debtTopFiles {
+		files = files[:debtTopFiles]
+	}
+	return files
+}
+
+// printDebt 打印技术债标记最多的文件和最老的标记
+func (r *Report) printDebt() {
+	if r.result.Debt == nil {
+		return
+	}
+
+	sectionStyle.Printf("\n◆ %s\n\n", r.translator.Translate("report.debt"))
+	infoStyle.Printf("  %s\n\n", r.debtSummary())
+
+	headers := []string{
+		r.translator.Translate("report.debt.file"),
+		r.translator.Translate("report.debt.markers"),
+		r.translator.Translate("report.debt.untracked"),
+	}
+	files := r.getDebtFiles()
+	cells := make([][]string, 0, len(files))
+	for _, file := range files {
+		cells = append(cells, []string{shortenPath(file.FilePath), strconv.Itoa(file.Markers), strconv.Itoa(file.Untracked)})
+	}
+
+	widths := columnWidths(headers, cells)
+	headerStyle.Printf("  %s\n", joinPadded(headers, widths))
+	for _, row := range cells {
+		fileStyle.Printf("  %s", padDisplay(row[0], widths[0]))
+		numberStyle.Printf("  %s\n", joinPadded(row[1:], widths[1:]))
+	}
+
+	if len(r.result.Debt.Oldest) > 0 {
+		fmt.Println()
+		headerStyle.Printf("  %s\n", r.translator.Translate("report.debt.oldest"))
+		for _, marker := range r.result.Debt.Oldest {
+			numberStyle.Printf("    %s  ", formatDebtDate(marker.Date))
+			warningStyle.Printf("%-12s", marker.Kind)
+			fileStyle.Printf("%s:%d", shortenPath(marker.File), marker.Line)
+			detailStyle.Printf("  %s\n", marker.Text)
+		}
+	}
+}
+
+// printMarkdownDebt 打印Markdown格式的技术债标记
+func (r *Report) printMarkdownDebt() {
+	if r.result.Debt == nil {
+		return
+	}
+
+	fmt.Printf("## %s\n\n", r.translator.Translate("report.debt"))
+	fmt.Printf("%s\n\n", r.debtSummary())
+
+	fmt.Printf("| %s | %s | %s |\n",
+		r.translator.Translate("report.debt.file"),
+		r.translator.Translate("report.debt.markers"),
+		r.translator.Translate("report.debt.untracked"))
+	fmt.Println("|------|------|------|")
+	for _, file := range r.getDebtFiles() {
+		fmt.Printf("| `%s` | %d | %d |\n", file.FilePath, file.Markers, file.Untracked)
+	}
+	fmt.Println()
+
+	if len(r.result.Debt.Oldest) > 0 {
+		fmt.Printf("**%s**\n\n", r.translator.Translate("report.debt.oldest"))
+		for _, marker := range r.result.Debt.Oldest {
+			fmt.Printf("- %s **%s** `%s:%d` %s\n", formatDebtDate(marker.Date), marker.Kind, marker.File, marker.Line, marker.Text)
+		}
+		fmt.Println()
+	}
+}
+
+// WriteDebtMarkers 按格式输出技术债标记列表，format为 text、json 或 csv
+func WriteDebtMarkers(out io.Writer, markers []debt.Marker, format string, translator i18n.Translator) error {
+	switch format {
+	case "json":
+		output := make([]jsonDebtMarker, 0, len(markers))
+		for _, marker := range markers {
+			output = append(output, buildJSONDebtMarker(marker))
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	case "csv":
+		// CSV 的列名固定为英文，便于导入其他工具
+		writer := csv.NewWriter(out)
+		if err := writer.Write([]string{"path", "line", "kind", "author", "ticket", "date", "text"}); err != nil {
+			return err
+		}
+		for _, marker := range markers {
+			if err := writer.Write([]string{marker.File, strconv.Itoa(marker.Line), string(marker.Kind), marker.Author, marker.Ticket, formatDebtDate(marker.Date), marker.Text}); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	case "text":
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			translator.Translate("debt.list.location"),
+			translator.Translate("debt.list.kind"),
+			translator.Translate("debt.list.author"),
+			translator.Translate("debt.list.ticket"),
+			translator.Translate("debt.list.date"),
+			translator.Translate("debt.list.text"))
+		untracked := 0
+		for _, marker := range markers {
+			if !marker.Tracked() {
+				untracked++
+			}
+			fmt.Fprintf(w, "%s:%d\t%s\t%s\t%s\t%s\t%s\n",
+				marker.File, marker.Line, marker.Kind, dashIfEmpty(marker.Author), dashIfEmpty(marker.Ticket), dashIfEmpty(formatDebtDate(marker.Date)), marker.Text)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintf(out, "\n%s\n", translator.Translate("debt.list.total", len(markers), untracked))
+		return err
+	default:
+		return fmt.Errorf(translator.Translate("cmd.unknown_format"), format)
+	}
+}
+
+// dashIfEmpty 空值显示为 -
+func dashIfEmpty(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
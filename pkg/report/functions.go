@@ -0,0 +1,139 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/analyzer"
+)
+
+// functionRanking 返回报告使用的函数排序依据
+func functionRanking(options *ReportOptions) analyzer.FunctionRanking {
+	if options.FunctionRanking == "" {
+		return analyzer.DefaultFunctionRanking
+	}
+	return options.FunctionRanking
+}
+
+// getWorstFunctions 返回按排序依据选出的最差函数
+func (r *Report) getWorstFunctions(options *ReportOptions) []analyzer.FunctionResult {
+	if options.TopFunctions <= 0 {
+		return nil
+	}
+	return analyzer.WorstFunctions(r.result.FilesAnalyzed, functionRanking(options), options.TopFunctions)
+}
+
+// functionLocation 返回函数位置，如 pkg/a.go:12-40
+func functionLocation(fn analyzer.FunctionResult) string {
+	if fn.EndLine > fn.StartLine {
+		return fmt.Sprintf("%s:%d-%d", fn.FilePath, fn.StartLine, fn.EndLine)
+	}
+	return fmt.Sprintf("%s:%d", fn.FilePath, fn.StartLine)
+}
+
+// hasCoverage 判断是否有文件带测试覆盖率数据
+func (r *Report) hasCoverage() bool {
+	for _, f := range r.result.FilesAnalyzed {
+		if f.HasCoverage {
+			return true
+		}
+	}
+	return false
+}
+
+// functionHeaders 返回函数表格的表头，有覆盖率数据时在数值列后追加覆盖率和CRAP
+func (r *Report) functionHeaders() []string {
+	headers := []string{
+		r.translator.Translate("report.functions.complexity"),
+		r.translator.Translate("report.functions.lines"),
+		r.translator.Translate("report.functions.params"),
+		r.translator.Translate("report.functions.mi"),
+	}
+	if r.hasCoverage() {
+		headers = append(headers,
+			r.translator.Translate("report.functions.coverage"),
+			r.translator.Translate("report.functions.crap"))
+	}
+	return append(headers,
+		r.translator.Translate("report.functions.name"),
+		r.translator.Translate("report.functions.location"))
+}
+
+// functionNumbers 返回函数表格中的数值列，与functionHeaders对应
+func (r *Report) functionNumbers(fn analyzer.FunctionResult, withCoverage bool) []string {
+	numbers := []string{
+		fmt.Sprintf("%d", fn.Complexity),
+		fmt.Sprintf("%d", fn.Lines),
+		fmt.Sprintf("%d", fn.Parameters),
+		fmt.Sprintf("%.1f", fn.MaintainabilityIndex),
+	}
+	if !withCoverage {
+		return numbers
+	}
+	if !fn.HasCoverage {
+		return append(numbers, "-", "-")
+	}
+	return append(numbers, fmt.Sprintf("%.0f%%", fn.Coverage), fmt.Sprintf("%.1f", fn.CRAP))
+}
+
+// printWorstFunctions 打印最差函数列表
+func (r *Report) printWorstFunctions(options *ReportOptions) {
+	if options.TopFunctions <= 0 {
+		return
+	}
+
+	ranking := functionRanking(options)
+	sectionStyle.Printf("\n◆ %s (%s)\n\n", r.translator.Translate("report.functions"), r.translator.Translate("report.functions.rank."+string(ranking)))
+
+	functions := r.getWorstFunctions(options)
+	if len(functions) == 0 {
+		infoStyle.Printf("  %s\n", r.translator.Translate("report.functions.none"))
+		return
+	}
+
+	headers := r.functionHeaders()
+	withCoverage := r.hasCoverage()
+	cells := make([][]string, 0, len(functions))
+	for _, fn := range functions {
+		cells = append(cells, append(r.functionNumbers(fn, withCoverage), fn.QualifiedName(), shortenPath(functionLocation(fn))))
+	}
+
+	// 最后两列为函数名和位置，其余为数值
+	numbers := len(headers) - 2
+	widths := columnWidths(headers, cells)
+	headerStyle.Printf("  %s\n", joinPadded(headers, widths))
+	for _, row := range cells {
+		for i := 0; i < numbers; i++ {
+			numberStyle.Printf("  %s", padDisplay(row[i], widths[i]))
+		}
+		metricStyle.Printf("  %s", padDisplay(row[numbers], widths[numbers]))
+		fileStyle.Printf("  %s\n", row[numbers+1])
+	}
+}
+
+// printMarkdownWorstFunctions 打印最差函数表格
+func (r *Report) printMarkdownWorstFunctions(options *ReportOptions) {
+	if options.TopFunctions <= 0 {
+		return
+	}
+
+	ranking := functionRanking(options)
+	fmt.Printf("## %s (%s)\n\n", r.translator.Translate("report.functions"), r.translator.Translate("report.functions.rank."+string(ranking)))
+
+	functions := r.getWorstFunctions(options)
+	if len(functions) == 0 {
+		fmt.Printf("%s\n\n", r.translator.Translate("report.functions.none"))
+		return
+	}
+
+	headers := r.functionHeaders()
+	withCoverage := r.hasCoverage()
+	numbers := len(headers) - 2
+	columns := append([]string{headers[numbers], headers[numbers+1]}, headers[:numbers]...)
+	fmt.Printf("| %s |\n", strings.Join(columns, " | "))
+	fmt.Printf("|%s\n", strings.Repeat("------|", len(columns)))
+	for _, fn := range functions {
+		fmt.Printf("| `%s` | `%s` | %s |\n", fn.QualifiedName(), functionLocation(fn), strings.Join(r.functionNumbers(fn, withCoverage), " | "))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,639 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/analyzer"
+)
+
+// snippetContext 问题代码片段上下文行数
+const snippetContext = 3
+
+// htmlReportData HTML报告模板数据
+type htmlReportData struct {
+	Lang        string
+	Score       float64
+	GaugeOffset float64
+	GaugeColor  template.CSS
+	Level       htmlLevel
+	TotalFiles  int
+	TotalLines  int
+	TotalIssues int
+	Aggregation string
+	SummaryOnly bool
+	Metrics     []htmlMetric
+	Functions   []htmlFunction
+	Ranking     string
+	Files       []htmlFile
+	Treemap     []htmlTreemapCell
+	Coupling    []htmlCoupling
+	Labels      map[string]string
+}
+
+// htmlLevel 质量等级展示数据
+type htmlLevel struct {
+	Emoji       string
+	Name        string
+	Description string
+	Advice      string
+}
+
+// htmlMetric 指标展示数据
+type htmlMetric struct {
+	Name    string
+	Score   float64
+	Weight  float64
+	Status  string
+	Comment string
+	Color   template.CSS
+}
+
+// htmlFile 文件展示数据
+type htmlFile struct {
+	ID        string
+	Path      string
+	Score     float64
+	Lines     int
+	Color     template.CSS
+	Functions []htmlFunction
+	Issues    []htmlIssue
+}
+
+// htmlFunction 函数展示数据
+type htmlFunction struct {
+	Name       string
+	Location   string
+	FileID     string
+	Lines      int
+	Complexity int
+	Parameters int
+	MI         float64
+}
+
+// htmlCoupling 包耦合度展示数据
+type htmlCoupling struct {
+	Name         string
+	ZoneOfPain   bool
+	Afferent     int
+	Efferent     int
+	FanIn        int
+	FanOut       int
+	Instability  float64
+	Abstractness float64
+	Distance     float64
+}
+
+// htmlIssue 问题展示数据
+type htmlIssue struct {
+	Text    string
+	Line    int
+	Snippet []htmlSnippetLine
+}
+
+// htmlSnippetLine 代码片段中的一行
+type htmlSnippetLine struct {
+	Number    int
+	Text      string
+	Highlight bool
+}
+
+// htmlTreemapCell 目录矩形树图中的一个矩形
+type htmlTreemapCell struct {
+	Label  string
+	Title  string
+	FileID string
+	IsDir  bool
+	Style  template.CSS
+}
+
+// treemapNode 矩形树图节点
+type treemapNode struct {
+	name     string
+	path     string
+	fileID   string
+	lines    int
+	score    float64
+	children []*treemapNode
+}
+
+// GenerateHTMLReport 生成自包含的HTML交互式报告
+func (r *Report) GenerateHTMLReport(options *ReportOptions) error {
+	if options == nil {
+		options = DefaultReportOptions
+	}
+
+	tmpl, err := template.New("report").Parse(htmlReportTemplate)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(os.Stdout, r.buildHTMLData(options))
+}
+
+// buildHTMLData 构建HTML模板数据
+func (r *Report) buildHTMLData(options *ReportOptions) *htmlReportData {
+	score := r.result.CodeQualityScore
+	level := r.getQualityLevel(score)
+	displayScore := math.Round(score*10000) / 100
+
+	// 半圆仪表盘的弧长为 π*80
+	gaugeLength := math.Pi * 80
+
+	data := &htmlReportData{
+		Lang:        string(r.translator.GetLanguage()),
+		Score:       displayScore,
+		GaugeOffset: gaugeLength * (1 - math.Min(math.Max(score, 0), 1)),
+		GaugeColor:  scoreToCSSColor(score),
+		Level: htmlLevel{
+			Emoji:       level.Emoji,
+			Name:        r.translator.Translate(level.NameKey),
+			Description: r.translator.Translate(level.Description),
+			Advice:      r.getAdvice(level.MinScore),
+		},
+		TotalFiles:  r.result.TotalFiles,
+		TotalLines:  r.result.TotalLines,
+		Aggregation: r.aggregationLabel(),
+		TotalIssues: r.getTotalIssues(),
+		SummaryOnly: options.SummaryOnly,
+		Labels:      r.htmlLabels(),
+	}
+
+	for _, m := range r.getSortedMetrics() {
+		scorePercentage := math.Round(m.Score*10000) / 100
+		data.Metrics = append(data.Metrics, htmlMetric{
+			Name:    m.Name,
+			Score:   scorePercentage,
+			Weight:  m.Weight,
+			Status:  getStatusEmoji(scorePercentage),
+			Comment: r.getMetricComment(m.Name, scorePercentage),
+			Color:   scoreToCSSColor(m.Score),
+		})
+	}
+
+	if options.SummaryOnly {
+		return data
+	}
+
+	fileIDs := make(map[string]string)
+	for i, f := range r.getSortedFiles() {
+		id := fmt.Sprintf("file-%d", i+1)
+		fileIDs[f.FilePath] = id
+		data.Files = append(data.Files, htmlFile{
+			ID:        id,
+			Path:      f.FilePath,
+			Score:     math.Round(adjustFileScore(f.FileScore)*100) / 100,
+			Lines:     f.TotalLines,
+			Color:     scoreToCSSColor(f.FileScore),
+			Functions: buildHTMLFunctions(f.Functions, fileIDs),
+			Issues:    buildHTMLIssues(f),
+		})
+	}
+
+	if options.TopFunctions > 0 {
+		data.Ranking = r.translator.Translate("report.functions.rank." + string(functionRanking(options)))
+		data.Functions = buildHTMLFunctions(r.getWorstFunctions(options), fileIDs)
+	}
+
+	data.Treemap = buildTreemap(r.result.FilesAnalyzed, fileIDs)
+
+	for _, c := range r.getCoupling() {
+		data.Coupling = append(data.Coupling, htmlCoupling{
+			Name:         c.Name,
+			ZoneOfPain:   c.ZoneOfPain,
+			Afferent:     c.Afferent,
+			Efferent:     c.Efferent,
+			FanIn:        c.FanIn,
+			FanOut:       c.FanOut,
+			Instability:  c.Instability,
+			Abstractness: c.Abstractness,
+			Distance:     c.Distance,
+		})
+	}
+
+	return data
+}
+
+// htmlLabels 返回HTML报告中使用的翻译文本
+func (r *Report) htmlLabels() map[string]string {
+	keys := []string{
+		"report.title", "report.overall_assessment", "report.quality_score",
+		"report.quality_level", "report.analyzed_files", "report.total_lines",
+		"report.quality_metrics", "report.metric", "report.score", "report.weight",
+		"report.status", "report.problem_files", "report.main_issues",
+		"report.conclusion", "report.no_issues", "verbose.total_issues",
+		"verbose.file_good_quality", "report.html.treemap", "report.html.treemap_hint",
+		"report.html.file", "report.html.lines", "report.html.issues",
+		"report.html.comment", "report.html.sort_hint", "report.html.issue_details",
+		"report.aggregation", "report.functions", "report.functions.name",
+		"report.functions.location", "report.functions.complexity",
+		"report.functions.lines", "report.functions.params", "report.functions.mi", "report.functions.none",
+		"report.coupling", "report.coupling.legend", "report.coupling.package",
+		"report.coupling.fan_in", "report.coupling.fan_out", "report.coupling.zone_of_pain",
+	}
+
+	labels := make(map[string]string, len(keys))
+	for _, key := range keys {
+		labels[strings.ReplaceAll(key, ".", "_")] = strings.TrimSuffix(r.translator.Translate(key), ":")
+	}
+	return labels
+}
+
+// getAdvice 根据质量等级返回建议
+func (r *Report) getAdvice(minScore float64) string {
+	switch {
+	case minScore < 30:
+		return r.translator.Translate("advice.good")
+	case minScore < 60:
+		return r.translator.Translate("advice.moderate")
+	default:
+		return r.translator.Translate("advice.bad")
+	}
+}
+
+// buildHTMLFunctions 转换函数展示数据，fileIDs用于链接到文件详情
+func buildHTMLFunctions(functions []analyzer.FunctionResult, fileIDs map[string]string) []htmlFunction {
+	items := make([]htmlFunction, 0, len(functions))
+	for _, fn := range functions {
+		items = append(items, htmlFunction{
+			Name:       fn.QualifiedName(),
+			Location:   functionLocation(fn),
+			FileID:     fileIDs[fn.FilePath],
+			Lines:      fn.Lines,
+			Complexity: fn.Complexity,
+			Parameters: fn.Parameters,
+			MI:         fn.MaintainabilityIndex,
+		})
+	}
+	return items
+}
+
+// buildHTMLIssues 为文件问题附加源码片段
+func buildHTMLIssues(f analyzer.FileAnalysisResult) []htmlIssue {
+	issues := make([]htmlIssue, 0, len(f.Issues))
+
+	content, err := f.ReadSource()
+	if err != nil {
+		content = nil
+	}
+	lines := strings.Split(string(content), "\n")
+
+	for _, issue := range f.Issues {
+		item := htmlIssue{Text: issue}
+		if len(content) > 0 {
+			item.Line = analyzer.LocateIssue(issue, content)
+		}
+
+		if item.Line > 0 {
+			start := max(1, item.Line-snippetContext)
+			end := min(len(lines), item.Line+snippetContext)
+			for n := start; n <= end; n++ {
+				item.Snippet = append(item.Snippet, htmlSnippetLine{
+					Number:    n,
+					Text:      strings.TrimRight(lines[n-1], "\r"),
+					Highlight: n == item.Line,
+				})
+			}
+		}
+
+		issues = append(issues, item)
+	}
+
+	return issues
+}
+
+// buildTreemap 构建按目录分组的矩形树图
+func buildTreemap(files []analyzer.FileAnalysisResult, fileIDs map[string]string) []htmlTreemapCell {
+	if len(files) == 0 {
+		return nil
+	}
+
+	// 计算公共根目录
+	root := filepath.Dir(files[0].FilePath)
+	for _, f := range files[1:] {
+		for root != "." && root != string(filepath.Separator) &&
+			!strings.HasPrefix(f.FilePath, root+string(filepath.Separator)) {
+			root = filepath.Dir(root)
+		}
+	}
+
+	tree := &treemapNode{name: filepath.Base(root), path: root}
+	for _, f := range files {
+		relPath, err := filepath.Rel(root, f.FilePath)
+		if err != nil {
+			relPath = f.FilePath
+		}
+		insertTreemapNode(tree, strings.Split(filepath.ToSlash(relPath), "/"), f, fileIDs[f.FilePath])
+	}
+	aggregateTreemapNode(tree)
+
+	var cells []htmlTreemapCell
+	layoutTreemap(tree, 0, 0, 100, 100, 0, &cells)
+	return cells
+}
+
+// insertTreemapNode 将文件插入矩形树图
+func insertTreemapNode(node *treemapNode, parts []string, f analyzer.FileAnalysisResult, fileID string) {
+	if len(parts) == 1 {
+		node.children = append(node.children, &treemapNode{
+			name:   parts[0],
+			path:   f.FilePath,
+			fileID: fileID,
+			lines:  max(f.TotalLines, 1),
+			score:  f.FileScore,
+		})
+		return
+	}
+
+	for _, child := range node.children {
+		if child.fileID == "" && child.name == parts[0] {
+			insertTreemapNode(child, parts[1:], f, fileID)
+			return
+		}
+	}
+
+	child := &treemapNode{name: parts[0], path: filepath.Join(node.path, parts[0])}
+	node.children = append(node.children, child)
+	insertTreemapNode(child, parts[1:], f, fileID)
+}
+
+// aggregateTreemapNode 汇总目录的行数和按行数加权的得分
+func aggregateTreemapNode(node *treemapNode) {
+	if len(node.children) == 0 {
+		return
+	}
+
+	node.lines = 0
+	weightedScore := 0.0
+	for _, child := range node.children {
+		aggregateTreemapNode(child)
+		node.lines += child.lines
+		weightedScore += child.score * float64(child.lines)
+	}
+	if node.lines > 0 {
+		node.score = weightedScore / float64(node.lines)
+	}
+
+	sort.Slice(node.children, func(i, j int) bool {
+		return node.children[i].lines > node.children[j].lines
+	})
+}
+
+// layoutTreemap 使用切片-切块算法计算矩形位置（单位为百分比）
+func layoutTreemap(node *treemapNode, x, y, w, h float64, depth int, cells *[]htmlTreemapCell) {
+	isDir := node.fileID == ""
+	title := fmt.Sprintf("%s\n%.2f / 100 · %d", node.path, adjustFileScore(node.score), node.lines)
+
+	if depth > 0 {
+		style := fmt.Sprintf("left:%.4f%%;top:%.4f%%;width:%.4f%%;height:%.4f%%;", x, y, w, h)
+		if !isDir {
+			style += fmt.Sprintf("background:%s;", scoreToCSSColor(node.score))
+		}
+		*cells = append(*cells, htmlTreemapCell{
+			Label:  node.name,
+			Title:  title,
+			FileID: node.fileID,
+			IsDir:  isDir,
+			Style:  template.CSS(style),
+		})
+	}
+
+	if !isDir || node.lines == 0 {
+		return
+	}
+
+	offset := 0.0
+	for _, child := range node.children {
+		ratio := float64(child.lines) / float64(node.lines)
+		if w >= h {
+			layoutTreemap(child, x+offset, y, w*ratio, h, depth+1, cells)
+			offset += w * ratio
+		} else {
+			layoutTreemap(child, x, y+offset, w, h*ratio, depth+1, cells)
+			offset += h * ratio
+		}
+	}
+}
+
+// scoreToCSSColor 将0-1得分映射为从绿到红的颜色
+func scoreToCSSColor(score float64) template.CSS {
+	score = math.Min(math.Max(score, 0), 1)
+	hue := int((1 - score) * 120)
+	return template.CSS(fmt.Sprintf("hsl(%d, 65%%, 45%%)", hue))
+}
+
+// getStatusEmoji 根据百分制得分返回状态图标
+func getStatusEmoji(scorePercentage float64) string {
+	switch {
+	case scorePercentage < 20:
+		return "✓✓"
+	case scorePercentage < 35:
+		return "✓"
+	case scorePercentage < 50:
+		return "○"
+	case scorePercentage < 60:
+		return "•"
+	case scorePercentage < 70:
+		return "⚠"
+	case scorePercentage < 80:
+		return "!"
+	case scorePercentage < 90:
+		return "!!"
+	default:
+		return "✗"
+	}
+}
+
+// htmlReportTemplate HTML报告模板，所有样式和脚本均内联
+const htmlReportTemplate = `<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Labels.report_title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", "PingFang SC", "Microsoft YaHei", sans-serif; margin: 0; background: #f6f7f9; color: #222; }
+main { max-width: 1200px; margin: 0 auto; padding: 24px; }
+h1 { text-align: center; }
+section { background: #fff; border-radius: 8px; box-shadow: 0 1px 3px rgba(0,0,0,.1); padding: 16px 24px; margin-bottom: 24px; }
+.overview { display: flex; flex-wrap: wrap; align-items: center; gap: 32px; }
+.gauge text { font-size: 22px; font-weight: bold; }
+.level { font-size: 20px; }
+.level .emoji { font-size: 40px; vertical-align: middle; }
+.stats { list-style: none; padding: 0; }
+table { border-collapse: collapse; width: 100%; }
+th, td { padding: 6px 10px; border-bottom: 1px solid #eee; text-align: left; }
+table.sortable th { cursor: pointer; user-select: none; }
+table.sortable th[data-order="asc"]::after { content: " ▲"; }
+table.sortable th[data-order="desc"]::after { content: " ▼"; }
+.hint { color: #888; font-size: 12px; }
+.badge { display: inline-block; min-width: 48px; padding: 2px 6px; border-radius: 4px; color: #fff; text-align: center; }
+.treemap { position: relative; height: 420px; border: 1px solid #ccc; overflow: hidden; }
+.treemap .cell { position: absolute; box-sizing: border-box; border: 1px solid #fff; overflow: hidden; font-size: 11px; color: #fff; padding: 2px; cursor: pointer; }
+.treemap .dir { border: 2px solid #333; background: transparent; color: #333; cursor: default; pointer-events: none; font-weight: bold; }
+details { border-bottom: 1px solid #eee; padding: 6px 0; }
+summary { cursor: pointer; }
+pre { background: #1e1e1e; color: #ddd; padding: 8px; overflow-x: auto; font-size: 12px; }
+pre .hl { background: #5a3b00; display: block; }
+pre .ln { color: #888; display: inline-block; width: 4em; }
+</style>
+</head>
+<body>
+<main>
+<h1>🌸 {{.Labels.report_title}} 🌸</h1>
+
+<section class="overview">
+  <svg class="gauge" width="200" height="120" viewBox="0 0 200 120">
+    <path d="M20 100 A80 80 0 0 1 180 100" fill="none" stroke="#eee" stroke-width="18"/>
+    <path d="M20 100 A80 80 0 0 1 180 100" fill="none" stroke="{{.GaugeColor}}" stroke-width="18" stroke-dasharray="251.327" stroke-dashoffset="{{printf "%.3f" .GaugeOffset}}"/>
+    <text x="100" y="95" text-anchor="middle">{{printf "%.2f" .Score}}</text>
+  </svg>
+  <div>
+    <div class="level"><span class="emoji">{{.Level.Emoji}}</span> {{.Level.Name}}</div>
+    <p>{{.Level.Description}}</p>
+    <ul class="stats">
+      <li>{{.Labels.report_quality_score}}: {{printf "%.2f" .Score}} / 100</li>
+      <li>{{.Labels.report_analyzed_files}}: {{.TotalFiles}}</li>
+      <li>{{.Labels.report_total_lines}}: {{.TotalLines}}</li>
+      <li>{{.Labels.verbose_total_issues}}: {{.TotalIssues}}</li>
+      {{if .Aggregation}}<li>{{.Labels.report_aggregation}}: {{.Aggregation}}</li>{{end}}
+    </ul>
+  </div>
+</section>
+
+<section>
+  <h2>{{.Labels.report_quality_metrics}}</h2>
+  <p class="hint">{{.Labels.report_html_sort_hint}}</p>
+  <table class="sortable">
+    <thead><tr><th>{{.Labels.report_metric}}</th><th>{{.Labels.report_score}}</th><th>{{.Labels.report_weight}}</th><th>{{.Labels.report_status}}</th><th>{{.Labels.report_html_comment}}</th></tr></thead>
+    <tbody>
+    {{range .Metrics}}<tr><td>{{.Name}}</td><td data-value="{{.Score}}"><span class="badge" style="background: {{.Color}}">{{printf "%.2f" .Score}}</span></td><td data-value="{{.Weight}}">{{printf "%.2f" .Weight}}</td><td>{{.Status}}</td><td>{{.Comment}}</td></tr>
+    {{end}}</tbody>
+  </table>
+</section>
+
+{{if not .SummaryOnly}}
+<section>
+  <h2>{{.Labels.report_html_treemap}}</h2>
+  <p class="hint">{{.Labels.report_html_treemap_hint}}</p>
+  <div class="treemap">
+    {{range .Treemap}}{{if .IsDir}}<div class="cell dir" style="{{.Style}}" title="{{.Title}}">{{.Label}}</div>{{else}}<a class="cell" style="{{.Style}}" title="{{.Title}}" href="#{{.FileID}}">{{.Label}}</a>{{end}}
+    {{end}}
+  </div>
+</section>
+
+{{if .Coupling}}
+<section>
+  <h2>{{.Labels.report_coupling}}</h2>
+  <p class="hint">{{.Labels.report_coupling_legend}}</p>
+  {{$labels := .Labels}}
+  <table class="sortable">
+    <thead><tr><th>{{.Labels.report_coupling_package}}</th><th>Ca</th><th>Ce</th><th>I</th><th>A</th><th>D</th><th>{{.Labels.report_coupling_fan_in}}</th><th>{{.Labels.report_coupling_fan_out}}</th></tr></thead>
+    <tbody>
+    {{range .Coupling}}<tr><td><code>{{.Name}}</code>{{if .ZoneOfPain}} <span class="badge" style="background: hsl(0, 65%, 45%)">{{$labels.report_coupling_zone_of_pain}}</span>{{end}}</td><td data-value="{{.Afferent}}">{{.Afferent}}</td><td data-value="{{.Efferent}}">{{.Efferent}}</td><td data-value="{{.Instability}}">{{printf "%.2f" .Instability}}</td><td data-value="{{.Abstractness}}">{{printf "%.2f" .Abstractness}}</td><td data-value="{{.Distance}}">{{printf "%.2f" .Distance}}</td><td data-value="{{.FanIn}}">{{.FanIn}}</td><td data-value="{{.FanOut}}">{{.FanOut}}</td></tr>
+    {{end}}</tbody>
+  </table>
+</section>
+{{end}}
+
+{{if .Ranking}}
+<section>
+  <h2>{{.Labels.report_functions}} ({{.Ranking}})</h2>
+  <p class="hint">{{.Labels.report_html_sort_hint}}</p>
+  {{if .Functions}}
+  <table class="sortable">
+    <thead><tr><th>{{.Labels.report_functions_name}}</th><th>{{.Labels.report_functions_location}}</th><th>{{.Labels.report_functions_complexity}}</th><th>{{.Labels.report_functions_lines}}</th><th>{{.Labels.report_functions_params}}</th><th>{{.Labels.report_functions_mi}}</th></tr></thead>
+    <tbody>
+    {{range .Functions}}<tr><td><code>{{.Name}}</code></td><td><a href="#{{.FileID}}">{{.Location}}</a></td><td data-value="{{.Complexity}}">{{.Complexity}}</td><td data-value="{{.Lines}}">{{.Lines}}</td><td data-value="{{.Parameters}}">{{.Parameters}}</td><td data-value="{{.MI}}">{{printf "%.1f" .MI}}</td></tr>
+    {{end}}</tbody>
+  </table>
+  {{else}}
+  <p>{{.Labels.report_functions_none}}</p>
+  {{end}}
+</section>
+{{end}}
+
+<section>
+  <h2>{{.Labels.report_problem_files}}</h2>
+  <p class="hint">{{.Labels.report_html_sort_hint}}</p>
+  {{if .Files}}
+  <table class="sortable">
+    <thead><tr><th>{{.Labels.report_html_file}}</th><th>{{.Labels.report_score}}</th><th>{{.Labels.report_html_lines}}</th><th>{{.Labels.report_html_issues}}</th></tr></thead>
+    <tbody>
+    {{range .Files}}<tr><td><a href="#{{.ID}}">{{.Path}}</a></td><td data-value="{{.Score}}"><span class="badge" style="background: {{.Color}}">{{printf "%.2f" .Score}}</span></td><td data-value="{{.Lines}}">{{.Lines}}</td><td data-value="{{len .Issues}}">{{len .Issues}}</td></tr>
+    {{end}}</tbody>
+  </table>
+  {{else}}
+  <p>🎉 {{.Labels.report_no_issues}}</p>
+  {{end}}
+</section>
+
+<section>
+  <h2>{{.Labels.report_html_issue_details}}</h2>
+  {{$labels := .Labels}}
+  {{range .Files}}
+  <details id="{{.ID}}">
+    <summary><span class="badge" style="background: {{.Color}}">{{printf "%.2f" .Score}}</span> {{.Path}} ({{len .Issues}})</summary>
+    {{if .Issues}}<ul>
+      {{range .Issues}}<li>{{.Text}}{{if .Line}} <span class="hint">L{{.Line}}</span>{{end}}
+        {{if .Snippet}}<pre>{{range .Snippet}}<span class="{{if .Highlight}}hl{{end}}"><span class="ln">{{.Number}}</span>{{.Text}}</span>
+{{end}}</pre>{{end}}
+      </li>
+      {{end}}
+    </ul>{{else}}<p>✓ {{$labels.verbose_file_good_quality}}</p>{{end}}
+    {{if .Functions}}<table class="sortable">
+      <thead><tr><th>{{$labels.report_functions_name}}</th><th>{{$labels.report_functions_location}}</th><th>{{$labels.report_functions_complexity}}</th><th>{{$labels.report_functions_lines}}</th><th>{{$labels.report_functions_params}}</th><th>{{$labels.report_functions_mi}}</th></tr></thead>
+      <tbody>
+      {{range .Functions}}<tr><td><code>{{.Name}}</code></td><td>{{.Location}}</td><td data-value="{{.Complexity}}">{{.Complexity}}</td><td data-value="{{.Lines}}">{{.Lines}}</td><td data-value="{{.Parameters}}">{{.Parameters}}</td><td data-value="{{.MI}}">{{printf "%.1f" .MI}}</td></tr>
+      {{end}}</tbody>
+    </table>{{end}}
+  </details>
+  {{end}}
+</section>
+{{end}}
+
+<section>
+  <h2>{{.Labels.report_conclusion}}</h2>
+  <p>{{.Level.Emoji}} {{.Level.Name}} - {{.Level.Description}}</p>
+  <p>{{.Level.Advice}}</p>
+</section>
+</main>
+<script>
+(function () {
+  var tables = document.querySelectorAll("table.sortable");
+  Array.prototype.forEach.call(tables, function (table) {
+    var headers = table.querySelectorAll("th");
+    Array.prototype.forEach.call(headers, function (th, index) {
+      th.addEventListener("click", function () {
+        var asc = th.getAttribute("data-order") !== "asc";
+        Array.prototype.forEach.call(headers, function (h) { h.removeAttribute("data-order"); });
+        th.setAttribute("data-order", asc ? "asc" : "desc");
+        var tbody = table.tBodies[0];
+        var rows = Array.prototype.slice.call(tbody.rows);
+        rows.sort(function (a, b) {
+          var x = a.cells[index].getAttribute("data-value") || a.cells[index].textContent;
+          var y = b.cells[index].getAttribute("data-value") || b.cells[index].textContent;
+          var nx = parseFloat(x), ny = parseFloat(y);
+          var cmp = (!isNaN(nx) && !isNaN(ny)) ? nx - ny : x.localeCompare(y);
+          return asc ? cmp : -cmp;
+        });
+        rows.forEach(function (row) { tbody.appendChild(row); });
+      });
+    });
+  });
+  Array.prototype.forEach.call(document.querySelectorAll(".treemap a.cell"), function (cell) {
+    cell.addEventListener("click", function () {
+      var target = document.getElementById(cell.getAttribute("href").slice(1));
+      if (target) { target.open = true; }
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`
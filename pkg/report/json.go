@@ -0,0 +1,321 @@
+package report
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+
+	"github.com/Done-0/fuck-u-code/pkg/analyzer"
+)
+
+// jsonReport JSON报告根节点，得分均为0-100，越高越差
+type jsonReport struct {
+	Score       float64           `json:"score"`
+	Level       string            `json:"level"`
+	Aggregation string            `json:"aggregation,omitempty"`
+	TotalFiles  int               `json:"total_files"`
+	TotalLines  int               `json:"total_lines"`
+	TotalIssues int               `json:"total_issues"`
+	Metrics     []jsonMetric      `json:"metrics"`
+	Functions   []jsonFunction    `json:"worst_functions,omitempty"`
+	Files       []jsonFile        `json:"files,omitempty"`
+	Directories *jsonDirectory    `json:"directories,omitempty"`
+	Coupling    []jsonCoupling    `json:"coupling,omitempty"`
+	Tests       *jsonTests        `json:"tests,omitempty"`
+	Debt        *jsonDebt         `json:"debt,omitempty"`
+	TestQuality *jsonReport       `json:"test_quality,omitempty"`
+	Suppressed  []jsonSuppressed  `json:"suppressed,omitempty"`
+	Skipped     []jsonSkippedFile `json:"skipped,omitempty"`
+	Failed      []jsonFailedFile  `json:"failed,omitempty"`
+}
+
+// jsonMetric 指标结果
+type jsonMetric struct {
+	Name        string  `json:"name"`
+	Score       float64 `json:"score"`
+	Weight      float64 `json:"weight"`
+	Description string  `json:"description"`
+}
+
+// jsonFile 文件结果
+type jsonFile struct {
+	Path      string         `json:"path"`
+	Score     float64        `json:"score"`
+	Lines     int            `json:"lines"`
+	MI        float64        `json:"maintainability_index"`
+	Coverage  *float64       `json:"coverage,omitempty"`
+	Functions []jsonFunction `json:"functions"`
+	Classes   []jsonClass    `json:"classes,omitempty"`
+	Issues    []string       `json:"issues"`
+}
+
+// jsonFunction 函数结果
+type jsonFunction struct {
+	Name       string `json:"name"`
+	Owner      string `json:"owner,omitempty"`
+	Path       string `json:"path,omitempty"`
+	StartLine  int    `json:"start_line"`
+	EndLine    int    `json:"end_line"`
+	Lines      int    `json:"lines"`
+	Complexity int    `json:"complexity"`
+	Parameters int    `json:"parameters"`
+
+	HalsteadVolume       float64 `json:"halstead_volume"`
+	HalsteadDifficulty   float64 `json:"halstead_difficulty"`
+	HalsteadEffort       float64 `json:"halstead_effort"`
+	HalsteadBugs         float64 `json:"halstead_bugs"`
+	MaintainabilityIndex float64 `json:"maintainability_index"`
+
+	Coverage *float64 `json:"coverage,omitempty"`
+	CRAP     *float64 `json:"crap,omitempty"`
+}
+
+// jsonClass 类的内聚度和规模
+type jsonClass struct {
+	Name       string `json:"name"`
+	StartLine  int    `json:"start_line"`
+	EndLine    int    `json:"end_line"`
+	Lines      int    `json:"lines"`
+	Methods    int    `json:"methods"`
+	Fields     int    `json:"fields"`
+	Complexity int    `json:"complexity"`
+	LCOM4      int    `json:"lcom4"`
+	GodClass   bool   `json:"god_class"`
+	DataClass  bool   `json:"data_class"`
+}
+
+// jsonDirectory 目录汇总结果
+type jsonDirectory struct {
+	Path       string           `json:"path"`
+	Name       string           `json:"name"`
+	Score      float64          `json:"score"`
+	Files      int              `json:"files"`
+	Lines      int              `json:"lines"`
+	Issues     int              `json:"issues"`
+	WorstFiles []jsonWorstFile  `json:"worst_files"`
+	Children   []*jsonDirectory `json:"children,omitempty"`
+}
+
+// jsonWorstFile 目录中的最差文件
+type jsonWorstFile struct {
+	Path   string  `json:"path"`
+	Score  float64 `json:"score"`
+	Lines  int     `json:"lines"`
+	Issues int     `json:"issues"`
+}
+
+// jsonCoupling 包耦合度
+type jsonCoupling struct {
+	Package      string  `json:"package"`
+	Language     string  `json:"language"`
+	Files        int     `json:"files"`
+	Afferent     int     `json:"afferent"`
+	Efferent     int     `json:"efferent"`
+	FanIn        int     `json:"fan_in"`
+	FanOut       int     `json:"fan_out"`
+	Instability  float64 `json:"instability"`
+	Abstractness float64 `json:"abstractness"`
+	Distance     float64 `json:"distance"`
+	ZoneOfPain   bool    `json:"zone_of_pain"`
+}
+
+// jsonSuppressed 被抑制的问题
+type jsonSuppressed struct {
+	Path   string `json:"path"`
+	Metric string `json:"metric"`
+	Issue  string `json:"issue"`
+}
+
+// jsonSkippedFile 跳过的文件
+type jsonSkippedFile struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// jsonFailedFile 分析失败的文件
+type jsonFailedFile struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// GenerateJSONReport 生成JSON格式报告，便于其他工具处理
+func (r *Report) GenerateJSONReport(options *ReportOptions) error {
+	if options == nil {
+		options = DefaultReportOptions
+	}
+
+	return writeJSON(r.buildJSONReport(options))
+}
+
+// buildJSONReport 构建JSON报告数据
+func (r *Report) buildJSONReport(options *ReportOptions) jsonReport {
+	level := r.getQualityLevel(r.result.CodeQualityScore)
+	output := jsonReport{
+		Score:       roundScore(r.result.CodeQualityScore),
+		Level:       r.translator.Translate(level.NameKey),
+		Aggregation: string(r.result.Aggregation),
+		TotalFiles:  r.result.TotalFiles,
+		TotalLines:  r.result.TotalLines,
+		TotalIssues: r.getTotalIssues(),
+		Metrics:     []jsonMetric{},
+		Directories: buildJSONDirectory(r.result.Directories),
+		Tests:       buildJSONTests(r.result.Tests),
+		Debt:        buildJSONDebt(r.result.Debt),
+	}
+	if testReport := r.testQualityReport(); testReport != nil {
+		testQuality := testReport.buildJSONReport(options)
+		output.TestQuality = &testQuality
+	}
+
+	for _, m := range r.getSortedMetrics() {
+		output.Metrics = append(output.Metrics, jsonMetric{
+			Name:        m.Name,
+			Score:       roundScore(m.Score),
+			Weight:      m.Weight,
+			Description: m.Description,
+		})
+	}
+
+	if !options.SummaryOnly {
+		for _, fn := range r.getWorstFunctions(options) {
+			output.Functions = append(output.Functions, buildJSONFunction(fn, true))
+		}
+
+		for _, f := range r.getSortedFiles() {
+			functions := make([]jsonFunction, 0, len(f.Functions))
+			for _, fn := range f.Functions {
+				functions = append(functions, buildJSONFunction(fn, false))
+			}
+			var classes []jsonClass
+			for _, c := range f.Classes {
+				classes = append(classes, jsonClass{
+					Name:       c.Name,
+					StartLine:  c.StartLine,
+					EndLine:    c.EndLine,
+					Lines:      c.Lines,
+					Methods:    c.Methods,
+					Fields:     c.Fields,
+					Complexity: c.Complexity,
+					LCOM4:      c.LCOM4,
+					GodClass:   c.GodClass,
+					DataClass:  c.DataClass,
+				})
+			}
+			file := jsonFile{
+				Path:      f.FilePath,
+				Score:     roundScore(f.FileScore),
+				Lines:     f.TotalLines,
+				MI:        roundFloat(f.MaintainabilityIndex),
+				Functions: functions,
+				Classes:   classes,
+				Issues:    append([]string{}, f.Issues...),
+			}
+			if f.HasCoverage {
+				coverage := roundFloat(f.Coverage)
+				file.Coverage = &coverage
+			}
+			output.Files = append(output.Files, file)
+		}
+	}
+
+	for _, c := range r.result.Coupling {
+		output.Coupling = append(output.Coupling, jsonCoupling{
+			Package:      c.Name,
+			Language:     string(c.Language),
+			Files:        len(c.Files),
+			Afferent:     c.Afferent,
+			Efferent:     c.Efferent,
+			FanIn:        c.FanIn,
+			FanOut:       c.FanOut,
+			Instability:  roundFloat(c.Instability),
+			Abstractness: roundFloat(c.Abstractness),
+			Distance:     roundFloat(c.Distance),
+			ZoneOfPain:   c.ZoneOfPain,
+		})
+	}
+	for _, s := range r.result.Suppressed {
+		output.Suppressed = append(output.Suppressed, jsonSuppressed{Path: s.FilePath, Metric: s.Metric, Issue: s.Issue})
+	}
+	for _, s := range r.result.SkippedFiles {
+		output.Skipped = append(output.Skipped, jsonSkippedFile{Path: s.FilePath, Reason: s.Reason})
+	}
+	for _, f := range r.result.FailedFiles {
+		output.Failed = append(output.Failed, jsonFailedFile{Path: f.FilePath, Error: f.Err.Error()})
+	}
+
+	return output
+}
+
+// buildJSONFunction 转换函数结果，文件内的函数不重复输出路径
+func buildJSONFunction(fn analyzer.FunctionResult, withPath bool) jsonFunction {
+	item := jsonFunction{
+		Name:       fn.Name,
+		Owner:      fn.Owner,
+		StartLine:  fn.StartLine,
+		EndLine:    fn.EndLine,
+		Lines:      fn.Lines,
+		Complexity: fn.Complexity,
+		Parameters: fn.Parameters,
+
+		HalsteadVolume:       roundFloat(fn.HalsteadVolume),
+		HalsteadDifficulty:   roundFloat(fn.HalsteadDifficulty),
+		HalsteadEffort:       roundFloat(fn.HalsteadEffort),
+		HalsteadBugs:         math.Round(fn.HalsteadBugs*1000) / 1000,
+		MaintainabilityIndex: roundFloat(fn.MaintainabilityIndex),
+	}
+	if withPath {
+		item.Path = fn.FilePath
+	}
+	if fn.HasCoverage {
+		coverage, crap := roundFloat(fn.Coverage), roundFloat(fn.CRAP)
+		item.Coverage, item.CRAP = &coverage, &crap
+	}
+	return item
+}
+
+// writeJSON 以缩进格式将JSON写入标准输出
+func writeJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// buildJSONDirectory 递归转换目录汇总结果
+func buildJSONDirectory(dir *analyzer.DirectoryResult) *jsonDirectory {
+	if dir == nil {
+		return nil
+	}
+
+	node := &jsonDirectory{
+		Path:       dir.Path,
+		Name:       dir.Name,
+		Score:      roundScore(dir.Score),
+		Files:      dir.TotalFiles,
+		Lines:      dir.TotalLines,
+		Issues:     dir.TotalIssues,
+		WorstFiles: []jsonWorstFile{},
+	}
+	for _, f := range dir.WorstFiles {
+		node.WorstFiles = append(node.WorstFiles, jsonWorstFile{
+			Path:   f.FilePath,
+			Score:  roundScore(f.FileScore),
+			Lines:  f.TotalLines,
+			Issues: len(f.Issues),
+		})
+	}
+	for _, child := range dir.Children {
+		node.Children = append(node.Children, buildJSONDirectory(child))
+	}
+
+	return node
+}
+
+// roundScore 将0-1的得分转换为保留两位小数的0-100分
+func roundScore(score float64) float64 {
+	return math.Round(score*10000) / 100
+}
+
+// roundFloat 保留两位小数
+func roundFloat(value float64) float64 {
+	return math.Round(value*100) / 100
+}
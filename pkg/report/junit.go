@@ -0,0 +1,122 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// junitTestSuites JUnit报告根节点
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite JUnit测试套件
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase JUnit测试用例
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitFailure JUnit失败信息
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// GenerateJUnitReport 生成JUnit XML格式报告
+// 每个指标和每个文件各对应一个测试用例，得分超过阈值时记为失败
+func (r *Report) GenerateJUnitReport(options *ReportOptions) error {
+	if options == nil {
+		options = DefaultReportOptions
+	}
+
+	metricSuite := junitTestSuite{Name: "fuck-u-code.metrics"}
+	for _, m := range r.getSortedMetrics() {
+		score := math.Round(m.Score*10000) / 100
+		testCase := junitTestCase{Name: m.Name, ClassName: metricSuite.Name}
+		if score > options.FailThreshold {
+			testCase.Failure = &junitFailure{
+				Message: r.translator.Translate("report.ci.threshold_exceeded", score, options.FailThreshold),
+				Type:    "threshold",
+				Text:    m.Description,
+			}
+			metricSuite.Failures++
+		}
+		metricSuite.TestCases = append(metricSuite.TestCases, testCase)
+	}
+	metricSuite.Tests = len(metricSuite.TestCases)
+
+	fileSuite := junitTestSuite{Name: "fuck-u-code.files"}
+	if !options.SummaryOnly {
+		for _, f := range r.getSortedFiles() {
+			score := math.Round(adjustFileScore(f.FileScore)*100) / 100
+			testCase := junitTestCase{Name: f.FilePath, ClassName: fileSuite.Name}
+			if score > options.FailThreshold {
+				testCase.Failure = &junitFailure{
+					Message: r.translator.Translate("report.ci.threshold_exceeded", score, options.FailThreshold),
+					Type:    "threshold",
+					Text:    strings.Join(f.Issues, "\n"),
+				}
+				fileSuite.Failures++
+			}
+			fileSuite.TestCases = append(fileSuite.TestCases, testCase)
+		}
+	}
+	fileSuite.Tests = len(fileSuite.TestCases)
+
+	// 最差函数只作为信息输出，不判定失败
+	functionSuite := junitTestSuite{Name: "fuck-u-code.functions"}
+	if !options.SummaryOnly {
+		for _, fn := range r.getWorstFunctions(options) {
+			functionSuite.TestCases = append(functionSuite.TestCases, junitTestCase{
+				Name:      fn.QualifiedName(),
+				ClassName: functionLocation(fn),
+				SystemOut: fmt.Sprintf("%s=%d %s=%d %s=%d",
+					r.translator.Translate("report.functions.complexity"), fn.Complexity,
+					r.translator.Translate("report.functions.lines"), fn.Lines,
+					r.translator.Translate("report.functions.params"), fn.Parameters),
+			})
+		}
+	}
+	functionSuite.Tests = len(functionSuite.TestCases)
+
+	suites := junitTestSuites{
+		Name:     r.translator.Translate("report.title"),
+		Tests:    metricSuite.Tests + fileSuite.Tests + functionSuite.Tests,
+		Failures: metricSuite.Failures + fileSuite.Failures,
+		Suites:   []junitTestSuite{metricSuite, fileSuite, functionSuite},
+	}
+
+	return writeXML(suites)
+}
+
+// writeXML 将对象以带缩进的XML格式写到标准输出
+func writeXML(v interface{}) error {
+	fmt.Print(xml.Header)
+
+	encoder := xml.NewEncoder(os.Stdout)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	return nil
+}
@@ -3,6 +3,7 @@ package report
 import (
 	"fmt"
 	"math"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -71,20 +72,26 @@ func (r *Report) SetTranslator(translator i18n.Translator) {
 
 // ReportOptions 定义报告生成的选项
 type ReportOptions struct {
-	Verbose        bool // 是否显示详细报告
-	TopFiles       int  // 显示最差文件的数量
-	MaxIssues      int  // 每个文件显示的问题数量
-	SummaryOnly    bool // 是否只显示摘要
-	MarkdownOutput bool // 是否输出Markdown格式
+	Verbose         bool                     // 是否显示详细报告
+	TopFiles        int                      // 显示最差文件的数量
+	MaxIssues       int                      // 每个文件显示的问题数量
+	SummaryOnly     bool                     // 是否只显示摘要
+	MarkdownOutput  bool                     // 是否输出Markdown格式
+	FailThreshold   float64                  // CI报告中判定为失败的得分阈值(0-100)
+	TopFunctions    int                      // 显示最差函数的数量，0表示不显示
+	FunctionRanking analyzer.FunctionRanking // 最差函数的排序依据，为空时按圈复杂度
 }
 
 // DefaultReportOptions 默认报告选项
 var DefaultReportOptions = &ReportOptions{
-	Verbose:        false,
-	TopFiles:       3,
-	MaxIssues:      3,
-	SummaryOnly:    false,
-	MarkdownOutput: false,
+	Verbose:         false,
+	TopFiles:        3,
+	MaxIssues:       3,
+	SummaryOnly:     false,
+	MarkdownOutput:  false,
+	FailThreshold:   60,
+	TopFunctions:    10,
+	FunctionRanking: analyzer.DefaultFunctionRanking,
 }
 
 // GenerateConsoleReport 生成控制台报告
@@ -116,16 +123,27 @@ func (r *Report) GenerateConsoleReport(options *ReportOptions) {
 
 	// 打印质量等级
 	detailStyle.Printf("  %s", r.translator.Translate("report.level", r.translator.Translate(level.NameKey)))
-	detailStyle.Printf(" - %s\n\n", r.translator.Translate(level.Description))
+	detailStyle.Printf(" - %s\n", r.translator.Translate(level.Description))
+	if r.result.Aggregation != "" {
+		infoStyle.Printf("  %s: %s\n", r.translator.Translate("report.aggregation"), r.aggregationLabel())
+	}
+	fmt.Println()
 
 	if !options.SummaryOnly {
 		r.printMetricItems()
+		r.printDirectoryTree(options)
+		r.printCoupling()
+		r.printTests()
+		r.printDebt()
+		r.printWorstFunctions(options)
 
 		if options.Verbose {
 			r.printAllFiles(options)
 		} else {
 			r.printTopIssues(options)
 		}
+
+		r.printSuppressed(options)
 	}
 
 	r.printSummary(level)
@@ -136,6 +154,16 @@ func (r *Report) GenerateConsoleReport(options *ReportOptions) {
 
 	printDivider()
 	fmt.Println()
+
+	r.printTestQuality(options)
+}
+
+// aggregationLabel 返回汇总策略的展示文本，如 "loc（按代码行数加权平均）"
+func (r *Report) aggregationLabel() string {
+	if r.result.Aggregation == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s)", r.result.Aggregation, r.translator.Translate(r.result.Aggregation.DescriptionKey()))
 }
 
 // printDivider 打印分隔线
@@ -255,9 +283,19 @@ func (r *Report) getMetricComment(metricName string, score float64) string {
 
 	var metricType string
 	switch {
+	case strings.Contains(nameKey, "untested") || strings.Contains(nameKey, "未测试"):
+		metricType = "coverage"
+	case strings.Contains(nameKey, "test") || strings.Contains(nameKey, "测试"):
+		metricType = "tests"
+	case strings.Contains(nameKey, "debt") || strings.Contains(nameKey, "技术债"):
+		metricType = "debt"
 	case strings.Contains(nameKey, "complexity") || strings.Contains(nameKey, "复杂度"):
 		metricType = "complexity"
-	case strings.Contains(nameKey, "function") || strings.Contains(nameKey, "length") || strings.Contains(nameKey, "状态") || strings.Contains(nameKey, "长度"):
+	case strings.Contains(nameKey, "state") || strings.Contains(nameKey, "状态"):
+		metricType = "state"
+	case strings.Contains(nameKey, "cohesion") || strings.Contains(nameKey, "内聚"):
+		metricType = "cohesion"
+	case strings.Contains(nameKey, "function") || strings.Contains(nameKey, "length") || strings.Contains(nameKey, "长度"):
 		metricType = "length"
 	case strings.Contains(nameKey, "comment") || strings.Contains(nameKey, "注释"):
 		metricType = "comment"
@@ -373,10 +411,11 @@ func (r *Report) printTopIssues(options *ReportOptions) {
 				"structure":   {color.New(color.FgYellow), "🏗️  "},
 				"duplication": {color.New(color.FgRed), "📋 "},
 				"error":       {color.New(color.FgHiRed), "❌ "},
+				"debt":        {color.New(color.FgGreen), "💸 "},
 				"other":       {color.New(color.FgHiYellow), "⚠️  "},
 			}
 
-			categoryOrder := []string{"complexity", "comment", "naming", "structure", "duplication", "error", "other"}
+			categoryOrder := []string{"complexity", "comment", "naming", "structure", "duplication", "error", "debt", "other"}
 
 			var categories []string
 			for _, category := range categoryOrder {
@@ -462,28 +501,12 @@ func (r *Report) categorizeIssues(issues []string) map[string]int {
 		"structure":   0, // 结构问题
 		"duplication": 0, // 重复问题
 		"error":       0, // 错误处理问题
+		"debt":        0, // 技术债问题
 		"other":       0, // 其他问题
 	}
 
 	for _, issue := range issues {
-		lowerIssue := strings.ToLower(issue)
-
-		switch {
-		case strings.Contains(lowerIssue, "复杂度") || strings.Contains(lowerIssue, "complexity"):
-			categories["complexity"]++
-		case strings.Contains(lowerIssue, "注释") || strings.Contains(lowerIssue, "comment"):
-			categories["comment"]++
-		case strings.Contains(lowerIssue, "命名") || strings.Contains(lowerIssue, "name") || strings.Contains(lowerIssue, "naming"):
-			categories["naming"]++
-		case strings.Contains(lowerIssue, "结构") || strings.Contains(lowerIssue, "嵌套") || strings.Contains(lowerIssue, "structure") || strings.Contains(lowerIssue, "nest"):
-			categories["structure"]++
-		case strings.Contains(lowerIssue, "重复") || strings.Contains(lowerIssue, "duplication"):
-			categories["duplication"]++
-		case strings.Contains(lowerIssue, "错误") || strings.Contains(lowerIssue, "error"):
-			categories["error"]++
-		default:
-			categories["other"]++
-		}
+		categories[getIssueCategory(issue)]++
 	}
 
 	// 删除计数为0的类别
@@ -496,23 +519,61 @@ func (r *Report) categorizeIssues(issues []string) map[string]int {
 	return categories
 }
 
-// getIssueIconAndColor 根据问题内容返回合适的图标和颜色
-func (r *Report) getIssueIconAndColor(issue string) (string, *color.Color) {
+// issueCategoryPrefix 匹配问题开头显式声明的类别
+var issueCategoryPrefix = regexp.MustCompile(`^\[([\w-]+)\]`)
+
+// getIssueCategory 根据问题内容判断问题类别
+func getIssueCategory(issue string) string {
+	// 自定义规则的问题以 [类别] 开头
+	if match := issueCategoryPrefix.FindStringSubmatch(issue); match != nil {
+		switch match[1] {
+		case "complexity", "comment", "naming", "structure", "duplication", "error", "debt":
+			return match[1]
+		default:
+			return "other"
+		}
+	}
+
 	lowerIssue := strings.ToLower(issue)
 
 	switch {
+	// 技术债标记的说明是任意文本，先于其他关键字判断
+	case strings.Contains(lowerIssue, "技术债") || strings.Contains(lowerIssue, "tech debt"):
+		return "debt"
 	case strings.Contains(lowerIssue, "复杂度") || strings.Contains(lowerIssue, "complexity"):
-		return "🔄 ", color.New(color.FgMagenta) // 窄图标，只需一个空格
+		return "complexity"
 	case strings.Contains(lowerIssue, "注释") || strings.Contains(lowerIssue, "comment"):
-		return "📝 ", color.New(color.FgBlue) // 窄图标，只需一个空格
+		return "comment"
 	case strings.Contains(lowerIssue, "命名") || strings.Contains(lowerIssue, "name") || strings.Contains(lowerIssue, "naming"):
-		return "🏷️  ", color.New(color.FgCyan) // 宽图标，需要两个空格
+		return "naming"
 	case strings.Contains(lowerIssue, "结构") || strings.Contains(lowerIssue, "嵌套") || strings.Contains(lowerIssue, "structure") || strings.Contains(lowerIssue, "nest"):
-		return "🏗️  ", color.New(color.FgYellow) // 宽图标，需要两个空格
+		return "structure"
 	case strings.Contains(lowerIssue, "重复") || strings.Contains(lowerIssue, "duplication"):
-		return "📋 ", color.New(color.FgRed) // 窄图标，只需一个空格
+		return "duplication"
 	case strings.Contains(lowerIssue, "错误") || strings.Contains(lowerIssue, "error"):
+		return "error"
+	default:
+		return "other"
+	}
+}
+
+// getIssueIconAndColor 根据问题内容返回合适的图标和颜色
+func (r *Report) getIssueIconAndColor(issue string) (string, *color.Color) {
+	switch getIssueCategory(issue) {
+	case "complexity":
+		return "🔄 ", color.New(color.FgMagenta) // 窄图标，只需一个空格
+	case "comment":
+		return "📝 ", color.New(color.FgBlue) // 窄图标，只需一个空格
+	case "naming":
+		return "🏷️  ", color.New(color.FgCyan) // 宽图标，需要两个空格
+	case "structure":
+		return "🏗️  ", color.New(color.FgYellow) // 宽图标，需要两个空格
+	case "duplication":
+		return "📋 ", color.New(color.FgRed) // 窄图标，只需一个空格
+	case "error":
 		return "❌ ", color.New(color.FgHiRed) // 窄图标，只需一个空格
+	case "debt":
+		return "💸 ", color.New(color.FgGreen) // 窄图标，只需一个空格
 	default:
 		return "⚠️  ", color.New(color.FgHiYellow) // 宽图标，需要两个空格
 	}
@@ -581,8 +642,12 @@ func (r *Report) getSortedMetrics() []analyzer.MetricResult {
 	for _, m := range r.result.Metrics {
 		metrics = append(metrics, m)
 	}
+	// 得分相同时按名称排列，保证多次运行的输出顺序一致
 	sort.Slice(metrics, func(i, j int) bool {
-		return metrics[i].Score < metrics[j].Score
+		if metrics[i].Score != metrics[j].Score {
+			return metrics[i].Score < metrics[j].Score
+		}
+		return metrics[i].Name < metrics[j].Name
 	})
 	return metrics
 }
@@ -591,7 +656,10 @@ func (r *Report) getSortedMetrics() []analyzer.MetricResult {
 func (r *Report) getSortedFiles() []analyzer.FileAnalysisResult {
 	worstFiles := append([]analyzer.FileAnalysisResult{}, r.result.FilesAnalyzed...)
 	sort.Slice(worstFiles, func(i, j int) bool {
-		return worstFiles[i].FileScore > worstFiles[j].FileScore
+		if worstFiles[i].FileScore != worstFiles[j].FileScore {
+			return worstFiles[i].FileScore > worstFiles[j].FileScore
+		}
+		return worstFiles[i].FilePath < worstFiles[j].FilePath
 	})
 	return worstFiles
 }
@@ -636,6 +704,15 @@ func (r *Report) printVerboseInfo() {
 	detailStyle.Printf("    %-15s %d\n", r.translator.Translate("verbose.total_lines"), r.result.TotalLines)
 	detailStyle.Printf("    %-15s %d\n", r.translator.Translate("verbose.total_issues"), r.getTotalIssues())
 
+	// 打印因生成代码而跳过的文件
+	if len(r.result.SkippedFiles) > 0 {
+		headerStyle.Printf("\n  ⏭️  %s (%d)\n", r.translator.Translate("verbose.skipped_files"), len(r.result.SkippedFiles))
+		for _, skipped := range r.result.SkippedFiles {
+			fileStyle.Printf("    %s", shortenPath(skipped.FilePath))
+			infoStyle.Printf(" - %s\n", skipped.Reason)
+		}
+	}
+
 	// 打印各指标详细信息
 	headerStyle.Println("\n  🔍 " + r.translator.Translate("verbose.metric_details"))
 
@@ -763,11 +840,12 @@ func (r *Report) printAllFiles(options *ReportOptions) {
 				"structure":   {color.New(color.FgYellow), "🏗️  "},
 				"duplication": {color.New(color.FgRed), "📋 "},
 				"error":       {color.New(color.FgHiRed), "❌ "},
+				"debt":        {color.New(color.FgGreen), "💸 "},
 				"other":       {color.New(color.FgHiYellow), "⚠️  "},
 			}
 
 			// 定义问题类别的显示顺序
-			categoryOrder := []string{"complexity", "comment", "naming", "structure", "duplication", "error", "other"}
+			categoryOrder := []string{"complexity", "comment", "naming", "structure", "duplication", "error", "debt", "other"}
 
 			var categories []string
 			for _, category := range categoryOrder {
@@ -875,18 +953,30 @@ func (r *Report) GenerateMarkdownReport(options *ReportOptions) {
 		r.translator.Translate(level.NameKey),
 		r.translator.Translate(level.Description))
 	fmt.Printf("- **%s**: %d\n", r.translator.Translate("report.analyzed_files"), r.result.TotalFiles)
-	fmt.Printf("- **%s**: %d\n\n", r.translator.Translate("report.total_lines"), r.result.TotalLines)
+	fmt.Printf("- **%s**: %d\n", r.translator.Translate("report.total_lines"), r.result.TotalLines)
+	if r.result.Aggregation != "" {
+		fmt.Printf("- **%s**: %s\n", r.translator.Translate("report.aggregation"), r.aggregationLabel())
+	}
+	fmt.Println()
 
 	// 质量指标表格
 	r.printMarkdownMetricsTable()
 
 	// 问题文件列表
 	if !options.SummaryOnly {
+		r.printMarkdownDirectoryTree()
+		r.printMarkdownCoupling()
+		r.printMarkdownTests()
+		r.printMarkdownDebt()
+		r.printMarkdownWorstFunctions(options)
 		r.printMarkdownTopFiles(options)
+		r.printMarkdownSuppressed(options)
 	}
 
 	// 改进建议
 	r.printMarkdownAdvice(level)
+
+	r.printMarkdownTestQuality(options)
 }
 
 // printMarkdownMetricsTable 打印质量指标表格
@@ -972,10 +1062,11 @@ func (r *Report) printMarkdownTopFiles(options *ReportOptions) {
 				"structure":   "🏗️ " + r.translator.Translate("issue.category.structure"),
 				"duplication": "📋 " + r.translator.Translate("issue.category.duplication"),
 				"error":       "❌ " + r.translator.Translate("issue.category.error"),
+				"debt":        "💸 " + r.translator.Translate("issue.category.debt"),
 				"other":       "⚠️ " + r.translator.Translate("issue.category.other"),
 			}
 
-			categoryOrder := []string{"complexity", "comment", "naming", "structure", "duplication", "error", "other"}
+			categoryOrder := []string{"complexity", "comment", "naming", "structure", "duplication", "error", "debt", "other"}
 
 			for _, category := range categoryOrder {
 				if count, exists := issuesByCategory[category]; exists {
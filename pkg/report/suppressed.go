@@ -0,0 +1,79 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+)
+
+// suppressedCount 单个指标被抑制的问题数
+type suppressedCount struct {
+	Metric string
+	Count  int
+}
+
+// getSuppressedCounts 按指标统计被抑制的问题数，数量多的在前
+func (r *Report) getSuppressedCounts() []suppressedCount {
+	counts := make(map[string]int)
+	for _, item := range r.result.Suppressed {
+		counts[item.Metric]++
+	}
+
+	result := make([]suppressedCount, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, suppressedCount{Metric: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Metric < result[j].Metric
+	})
+	return result
+}
+
+// printSuppressed 打印被抑制注释忽略的问题
+func (r *Report) printSuppressed(options *ReportOptions) {
+	if len(r.result.Suppressed) == 0 {
+		return
+	}
+
+	sectionStyle.Printf("\n◆ %s\n\n", r.translator.Translate("report.suppressed"))
+	infoStyle.Printf("  %s\n", r.translator.Translate("report.suppressed.total", len(r.result.Suppressed)))
+
+	for _, item := range r.getSuppressedCounts() {
+		metricStyle.Printf("    %s", item.Metric)
+		detailStyle.Printf(": %d\n", item.Count)
+	}
+
+	if options.Verbose {
+		fmt.Println()
+		for _, item := range r.result.Suppressed {
+			fileStyle.Printf("    %s", shortenPath(item.FilePath))
+			detailStyle.Printf(" [%s] %s\n", item.Metric, item.Issue)
+		}
+	}
+}
+
+// printMarkdownSuppressed 打印Markdown格式的被抑制问题
+func (r *Report) printMarkdownSuppressed(options *ReportOptions) {
+	if len(r.result.Suppressed) == 0 {
+		return
+	}
+
+	fmt.Printf("## %s\n\n", r.translator.Translate("report.suppressed"))
+	fmt.Printf("%s\n\n", r.translator.Translate("report.suppressed.total", len(r.result.Suppressed)))
+
+	fmt.Printf("| %s | %s |\n", r.translator.Translate("report.metric"), r.translator.Translate("report.suppressed.count"))
+	fmt.Println("|------|------|")
+	for _, item := range r.getSuppressedCounts() {
+		fmt.Printf("| %s | %d |\n", item.Metric, item.Count)
+	}
+	fmt.Println()
+
+	if options.Verbose {
+		for _, item := range r.result.Suppressed {
+			fmt.Printf("- `%s` [%s] %s\n", item.FilePath, item.Metric, item.Issue)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,201 @@
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Done-0/fuck-u-code/pkg/analyzer"
+)
+
+// testsTopPackages 报告中列出的包数量
+const testsTopPackages = 10
+
+// jsonTests 测试情况
+type jsonTests struct {
+	TestFiles       int               `json:"test_files"`
+	TestLines       int               `json:"test_lines"`
+	ProductionFiles int               `json:"production_files"`
+	ProductionLines int               `json:"production_lines"`
+	Ratio           float64           `json:"ratio"`
+	Untested        int               `json:"untested"`
+	Packages        []jsonPackageTest `json:"packages"`
+	NoAssertions    []string          `json:"without_assertions,omitempty"`
+}
+
+// jsonPackageTest 包的测试情况
+type jsonPackageTest struct {
+	Package         string   `json:"package"`
+	ProductionFiles int      `json:"production_files"`
+	TestFiles       int      `json:"test_files"`
+	ProductionLines int      `json:"production_lines"`
+	TestLines       int      `json:"test_lines"`
+	Ratio           float64  `json:"ratio"`
+	Untested        []string `json:"untested,omitempty"`
+}
+
+// buildJSONTests 转换测试情况，没有匹配结果时返回nil
+func buildJSONTests(tests *analyzer.TestSummary) *jsonTests {
+	if tests == nil {
+		return nil
+	}
+
+	output := &jsonTests{
+		TestFiles:       tests.TestFiles,
+		TestLines:       tests.TestLines,
+		ProductionFiles: tests.ProductionFiles,
+		ProductionLines: tests.ProductionLines,
+		Ratio:           roundFloat(tests.Ratio()),
+		Untested:        tests.Untested,
+		Packages:        make([]jsonPackageTest, 0, len(tests.Packages)),
+		NoAssertions:    tests.NoAssertions,
+	}
+	for _, pkg := range tests.Packages {
+		output.Packages = append(output.Packages, jsonPackageTest{
+			Package:         pkg.Name,
+			ProductionFiles: pkg.ProductionFiles,
+			TestFiles:       pkg.TestFiles,
+			ProductionLines: pkg.ProductionLines,
+			TestLines:       pkg.TestLines,
+			Ratio:           roundFloat(pkg.Ratio()),
+			Untested:        pkg.Untested,
+		})
+	}
+	return output
+}
+
+// getTestPackages 返回报告中列出的包，只保留有生产文件的包
+func (r *Report) getTestPackages() []analyzer.PackageTests {
+	var packages []analyzer.PackageTests
+	for _, pkg := range r.result.Tests.Packages {
+		if pkg.ProductionFiles == 0 {
+			continue
+		}
+		packages = append(packages, pkg)
+		if len(packages) == testsTopPackages {
+			break
+		}
+	}
+	return packages
+}
+
+// testsSummary 返回测试情况的概要
+func (r *Report) testsSummary() string {
+	tests := r.result.Tests
+	return r.translator.Translate("report.tests.summary",
+		tests.TestFiles, tests.TestLines, tests.ProductionLines, tests.Ratio(), tests.Untested)
+}
+
+// testsHeaders 返回测试情况表格的表头
+func (r *Report) testsHeaders() []string {
+	return []string{
+		r.translator.Translate("report.tests.package"),
+		r.translator.Translate("report.tests.ratio"),
+		r.translator.Translate("report.tests.production"),
+		r.translator.Translate("report.tests.files"),
+		r.translator.Translate("report.tests.untested"),
+	}
+}
+
+// testsCells 返回包的测试情况表格行
+func testsCells(pkg analyzer.PackageTests) []string {
+	return []string{
+		pkg.Name,
+		fmt.Sprintf("%.2f", pkg.Ratio()),
+		fmt.Sprintf("%d", pkg.ProductionFiles),
+		fmt.Sprintf("%d", pkg.TestFiles),
+		fmt.Sprintf("%d", len(pkg.Untested)),
+	}
+}
+
+// printTests 打印测试情况，按未测试文件数从多到少列出包
+func (r *Report) printTests() {
+	if r.result.Tests == nil || r.result.Tests.ProductionFiles == 0 {
+		return
+	}
+
+	sectionStyle.Printf("\n◆ %s\n\n", r.translator.Translate("report.tests"))
+	infoStyle.Printf("  %s\n\n", r.testsSummary())
+
+	packages := r.getTestPackages()
+	headers := r.testsHeaders()
+	cells := make([][]string, 0, len(packages))
+	for _, pkg := range packages {
+		cells = append(cells, testsCells(pkg))
+	}
+
+	widths := columnWidths(headers, cells)
+	headerStyle.Printf("  %s\n", joinPadded(headers, widths))
+	for i, row := range cells {
+		if len(packages[i].Untested) > 0 {
+			dangerStyle.Printf("  %s", padDisplay(row[0], widths[0]))
+		} else {
+			metricStyle.Printf("  %s", padDisplay(row[0], widths[0]))
+		}
+		numberStyle.Printf("  %s\n", joinPadded(row[1:], widths[1:]))
+	}
+
+	if len(r.result.Tests.NoAssertions) > 0 {
+		fmt.Println()
+		headerStyle.Printf("  %s\n", r.translator.Translate("report.tests.no_assertions"))
+		for _, path := range r.result.Tests.NoAssertions {
+			fileStyle.Printf("    %s\n", shortenPath(path))
+		}
+	}
+}
+
+// printMarkdownTests 打印Markdown格式的测试情况
+func (r *Report) printMarkdownTests() {
+	if r.result.Tests == nil || r.result.Tests.ProductionFiles == 0 {
+		return
+	}
+
+	fmt.Printf("## %s\n\n", r.translator.Translate("report.tests"))
+	fmt.Printf("%s\n\n", r.testsSummary())
+
+	headers := r.testsHeaders()
+	fmt.Printf("| %s | %s | %s | %s | %s |\n", headers[0], headers[1], headers[2], headers[3], headers[4])
+	fmt.Println("|------|------|------|------|------|")
+	for _, pkg := range r.getTestPackages() {
+		row := testsCells(pkg)
+		fmt.Printf("| `%s` | %s | %s | %s | %s |\n", row[0], row[1], row[2], row[3], row[4])
+	}
+	fmt.Println()
+
+	if len(r.result.Tests.NoAssertions) > 0 {
+		fmt.Printf("**%s**\n\n", r.translator.Translate("report.tests.no_assertions"))
+		for _, path := range r.result.Tests.NoAssertions {
+			fmt.Printf("- `%s`\n", filepath.ToSlash(path))
+		}
+		fmt.Println()
+	}
+}
+
+// testQualityReport 返回测试代码的报告，没有单独评估测试代码时返回nil
+func (r *Report) testQualityReport() *Report {
+	if r.result.TestQuality == nil {
+		return nil
+	}
+	testReport := NewReport(r.result.TestQuality)
+	testReport.SetTranslator(r.translator)
+	return testReport
+}
+
+// printTestQuality 在主报告之后打印测试代码的完整报告
+func (r *Report) printTestQuality(options *ReportOptions) {
+	testReport := r.testQualityReport()
+	if testReport == nil {
+		return
+	}
+	sectionStyle.Printf("\n◆ %s\n\n", r.translator.Translate("report.tests.quality"))
+	testReport.GenerateConsoleReport(options)
+}
+
+// printMarkdownTestQuality 在主报告之后打印Markdown格式的测试代码报告
+func (r *Report) printMarkdownTestQuality(options *ReportOptions) {
+	testReport := r.testQualityReport()
+	if testReport == nil {
+		return
+	}
+	fmt.Printf("---\n\n## %s\n\n", r.translator.Translate("report.tests.quality"))
+	testReport.GenerateMarkdownReport(options)
+}
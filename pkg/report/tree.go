@@ -0,0 +1,122 @@
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/analyzer"
+)
+
+// defaultTreeDepth 非详细模式下目录树展示的层数
+const defaultTreeDepth = 2
+
+// collapseDirectory 合并只有一个子目录且没有直接文件的目录链，如 src/main/java
+func collapseDirectory(dir *analyzer.DirectoryResult) (*analyzer.DirectoryResult, string) {
+	name := dir.Name
+	for len(dir.Children) == 1 && dir.Children[0].TotalFiles == dir.TotalFiles {
+		dir = dir.Children[0]
+		name = name + "/" + dir.Name
+	}
+	return dir, name
+}
+
+// hasSubdirectories 判断目录树是否值得展示
+func hasSubdirectories(tree *analyzer.DirectoryResult) bool {
+	return tree != nil && len(tree.Children) > 0
+}
+
+// printDirectoryTree 打印按目录汇总的得分树
+func (r *Report) printDirectoryTree(options *ReportOptions) {
+	tree := r.result.Directories
+	if !hasSubdirectories(tree) {
+		return
+	}
+
+	sectionStyle.Printf("\n◆ %s\n\n", r.translator.Translate("report.directories"))
+
+	maxDepth := defaultTreeDepth
+	if options.Verbose {
+		maxDepth = -1
+	}
+
+	r.printDirectoryLine("  ", tree, tree.Path, options)
+	r.printDirectoryChildren("  ", tree, 1, maxDepth, options)
+}
+
+// printDirectoryChildren 递归打印子目录，maxDepth为负数时不限制层数
+func (r *Report) printDirectoryChildren(prefix string, dir *analyzer.DirectoryResult, depth, maxDepth int, options *ReportOptions) {
+	if maxDepth >= 0 && depth > maxDepth {
+		return
+	}
+
+	for i, child := range dir.Children {
+		child, name := collapseDirectory(child)
+
+		branch, indent := "├── ", "│   "
+		if i == len(dir.Children)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		r.printDirectoryLine(prefix+branch, child, name+"/", options)
+		r.printDirectoryChildren(prefix+indent, child, depth+1, maxDepth, options)
+	}
+}
+
+// printDirectoryLine 打印一个目录的汇总信息，详细模式下附带最差文件
+func (r *Report) printDirectoryLine(prefix string, dir *analyzer.DirectoryResult, name string, options *ReportOptions) {
+	fmt.Print(prefix)
+	fileStyle.Printf("%s ", name)
+	getScoreColor(dir.Score).Printf("%.2f", adjustFileScore(dir.Score))
+	detailStyle.Printf("  (%s)\n", r.translator.Translate("report.directory.summary", dir.TotalFiles, dir.TotalLines, dir.TotalIssues))
+
+	if options.Verbose && len(dir.WorstFiles) > 0 {
+		worst := dir.WorstFiles[0]
+		padding := strings.Repeat(" ", len([]rune(prefix)))
+		infoStyle.Printf("%s  %s: %s (%.2f)\n", padding, r.translator.Translate("report.directory.worst"), filepath.Base(worst.FilePath), adjustFileScore(worst.FileScore))
+	}
+}
+
+// printMarkdownDirectoryTree 打印可折叠的Markdown目录树
+func (r *Report) printMarkdownDirectoryTree() {
+	tree := r.result.Directories
+	if !hasSubdirectories(tree) {
+		return
+	}
+
+	fmt.Printf("## %s\n\n", r.translator.Translate("report.directories"))
+	for _, child := range tree.Children {
+		r.printMarkdownDirectory(child)
+	}
+	fmt.Println()
+}
+
+// printMarkdownDirectory 递归打印目录，子目录放在折叠块中
+func (r *Report) printMarkdownDirectory(dir *analyzer.DirectoryResult) {
+	dir, name := collapseDirectory(dir)
+
+	fmt.Printf("<details>\n<summary><b>%s/</b> · %.2f · %s</summary>\n\n",
+		name,
+		adjustFileScore(dir.Score),
+		r.translator.Translate("report.directory.summary", dir.TotalFiles, dir.TotalLines, dir.TotalIssues))
+
+	if len(dir.WorstFiles) > 0 {
+		fmt.Printf("%s:\n\n", r.translator.Translate("report.directory.worst"))
+		for _, f := range dir.WorstFiles {
+			fmt.Printf("- `%s` (%.2f)\n", f.FilePath, adjustFileScore(f.FileScore))
+		}
+		fmt.Println()
+	}
+
+	if len(dir.Children) > 0 {
+		fmt.Println("<blockquote>")
+		fmt.Println()
+		for _, child := range dir.Children {
+			r.printMarkdownDirectory(child)
+		}
+		fmt.Println("</blockquote>")
+		fmt.Println()
+	}
+
+	fmt.Printf("</details>\n\n")
+}
@@ -0,0 +1,325 @@
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Done-0/fuck-u-code/pkg/analyzer"
+	"github.com/Done-0/fuck-u-code/pkg/i18n"
+)
+
+// WorkspaceReport 工作区报告，每个子项目单独评分并汇总成概览表
+type WorkspaceReport struct {
+	result     *analyzer.WorkspaceResult
+	translator i18n.Translator
+}
+
+// NewWorkspaceReport 创建工作区报告
+func NewWorkspaceReport(result *analyzer.WorkspaceResult) *WorkspaceReport {
+	return &WorkspaceReport{
+		result:     result,
+		translator: i18n.NewTranslator(i18n.ZhCN),
+	}
+}
+
+// SetTranslator 设置翻译器
+func (w *WorkspaceReport) SetTranslator(translator i18n.Translator) {
+	w.translator = translator
+}
+
+// workspaceRow 概览表中的一行
+type workspaceRow struct {
+	project *analyzer.ProjectResult
+	report  *Report
+	score   float64
+	emoji   string
+	level   string
+	kind    string
+	path    string
+}
+
+// rows 按得分从差到好排列子项目
+func (w *WorkspaceReport) rows() []workspaceRow {
+	rows := make([]workspaceRow, 0, len(w.result.Projects))
+	for i := range w.result.Projects {
+		project := &w.result.Projects[i]
+		projectReport := w.projectReport(project)
+		level := projectReport.getQualityLevel(project.Result.CodeQualityScore)
+
+		kind := project.Kind
+		if kind == "" {
+			kind = "-"
+		}
+
+		rows = append(rows, workspaceRow{
+			project: project,
+			report:  projectReport,
+			score:   project.Result.CodeQualityScore,
+			emoji:   level.Emoji,
+			level:   w.translator.Translate(level.NameKey),
+			kind:    kind,
+			path:    w.relativePath(project.Path),
+		})
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].score > rows[j].score
+	})
+	return rows
+}
+
+// projectReport 创建子项目的报告
+func (w *WorkspaceReport) projectReport(project *analyzer.ProjectResult) *Report {
+	projectReport := NewReport(project.Result)
+	projectReport.SetTranslator(w.translator)
+	return projectReport
+}
+
+// relativePath 返回相对于工作区根目录的路径
+func (w *WorkspaceReport) relativePath(path string) string {
+	root, err := filepath.Abs(w.result.Root)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// overall 返回合并结果的报告
+func (w *WorkspaceReport) overall() *Report {
+	overall := NewReport(w.result.Overall)
+	overall.SetTranslator(w.translator)
+	return overall
+}
+
+// GenerateConsoleReport 生成控制台工作区报告，详细模式下附带每个子项目的完整报告
+func (w *WorkspaceReport) GenerateConsoleReport(options *ReportOptions) {
+	if options == nil {
+		options = DefaultReportOptions
+	}
+	if options.MarkdownOutput {
+		w.GenerateMarkdownReport(options)
+		return
+	}
+
+	overall := w.overall()
+	score := w.result.Overall.CodeQualityScore
+	level := overall.getQualityLevel(score)
+
+	printDivider()
+	titleStyle.Printf("\n  🌸 %s 🌸\n", w.translator.Translate("report.title"))
+	printDivider()
+
+	fmt.Printf("\n")
+	scoreStyle.Printf("  %s", w.translator.Translate("report.overall_score", score*100))
+	fmt.Printf(" - ")
+	overall.printScoreComment(score)
+	fmt.Printf("\n")
+	detailStyle.Printf("  %s\n", w.translator.Translate("report.level", w.translator.Translate(level.NameKey)))
+	infoStyle.Printf("  %s: %s\n", w.translator.Translate("report.aggregation"), overall.aggregationLabel())
+
+	sectionStyle.Printf("\n◆ %s\n\n", w.translator.Translate("report.workspace.overview"))
+	infoStyle.Printf("  %s\n\n", w.translator.Translate("report.workspace.count", len(w.result.Projects)))
+
+	rows := w.rows()
+	headers := []string{
+		w.translator.Translate("report.score"),
+		w.translator.Translate("report.quality_level"),
+		w.translator.Translate("report.workspace.project"),
+		w.translator.Translate("report.workspace.kind"),
+		w.translator.Translate("report.workspace.files"),
+		w.translator.Translate("report.workspace.lines"),
+		w.translator.Translate("report.workspace.issues"),
+		w.translator.Translate("report.workspace.path"),
+	}
+	cells := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		cells = append(cells, []string{
+			fmt.Sprintf("%.2f", adjustFileScore(row.score)),
+			row.emoji + " " + row.level,
+			row.project.Name,
+			row.kind,
+			fmt.Sprintf("%d", row.project.Result.TotalFiles),
+			fmt.Sprintf("%d", row.project.Result.TotalLines),
+			fmt.Sprintf("%d", row.report.getTotalIssues()),
+			row.path,
+		})
+	}
+
+	widths := columnWidths(headers, cells)
+	headerStyle.Printf("  %s\n", joinPadded(headers, widths))
+	for i, row := range rows {
+		// 得分列单独着色，其余列按显示宽度补齐
+		getScoreColor(row.score).Print("  " + padDisplay(cells[i][0], widths[0]))
+		fmt.Printf("  %s\n", joinPadded(cells[i][1:], widths[1:]))
+	}
+	fmt.Println()
+
+	if options.Verbose {
+		for _, row := range rows {
+			sectionStyle.Printf("\n◆ %s: %s (%s)\n\n", w.translator.Translate("report.workspace.project"), row.project.Name, row.path)
+			row.report.GenerateConsoleReport(options)
+		}
+	}
+
+	printDivider()
+	fmt.Println()
+}
+
+// GenerateMarkdownReport 生成Markdown工作区报告，详细模式下附带每个子项目的完整报告
+func (w *WorkspaceReport) GenerateMarkdownReport(options *ReportOptions) {
+	if options == nil {
+		options = DefaultReportOptions
+	}
+
+	overall := w.overall()
+	score := w.result.Overall.CodeQualityScore
+	level := overall.getQualityLevel(score)
+
+	fmt.Printf("# 🌸 %s 🌸\n\n", w.translator.Translate("report.title"))
+	fmt.Printf("## %s\n\n", w.translator.Translate("report.overall_assessment"))
+	fmt.Printf("- **%s**: %.2f/100\n", w.translator.Translate("report.quality_score"), roundScore(score))
+	fmt.Printf("- **%s**: %s %s\n", w.translator.Translate("report.quality_level"), level.Emoji, w.translator.Translate(level.NameKey))
+	fmt.Printf("- **%s**: %d\n", w.translator.Translate("report.analyzed_files"), w.result.Overall.TotalFiles)
+	fmt.Printf("- **%s**: %d\n", w.translator.Translate("report.total_lines"), w.result.Overall.TotalLines)
+	fmt.Printf("- **%s**: %s\n\n", w.translator.Translate("report.aggregation"), overall.aggregationLabel())
+
+	fmt.Printf("## %s\n\n", w.translator.Translate("report.workspace.overview"))
+	fmt.Printf("| %s | %s | %s | %s | %s | %s | %s | %s |\n",
+		w.translator.Translate("report.workspace.project"),
+		w.translator.Translate("report.workspace.kind"),
+		w.translator.Translate("report.workspace.path"),
+		w.translator.Translate("report.workspace.files"),
+		w.translator.Translate("report.workspace.lines"),
+		w.translator.Translate("report.workspace.issues"),
+		w.translator.Translate("report.score"),
+		w.translator.Translate("report.quality_level"))
+	fmt.Println("|------|------|------|------|------|------|------|------|")
+
+	rows := w.rows()
+	for _, row := range rows {
+		fmt.Printf("| %s | %s | `%s` | %d | %d | %d | %.2f | %s %s |\n",
+			row.project.Name,
+			row.kind,
+			row.path,
+			row.project.Result.TotalFiles,
+			row.project.Result.TotalLines,
+			row.report.getTotalIssues(),
+			roundScore(row.score),
+			row.emoji,
+			row.level)
+	}
+	fmt.Println()
+
+	if options.Verbose {
+		for _, row := range rows {
+			fmt.Printf("---\n\n")
+			row.report.GenerateMarkdownReport(options)
+		}
+	}
+}
+
+// jsonWorkspaceReport 工作区JSON报告
+type jsonWorkspaceReport struct {
+	Score       float64       `json:"score"`
+	Level       string        `json:"level"`
+	Aggregation string        `json:"aggregation"`
+	TotalFiles  int           `json:"total_files"`
+	TotalLines  int           `json:"total_lines"`
+	TotalIssues int           `json:"total_issues"`
+	Projects    []jsonProject `json:"projects"`
+}
+
+// jsonProject 子项目结果
+type jsonProject struct {
+	Name   string     `json:"name"`
+	Kind   string     `json:"kind"`
+	Path   string     `json:"path"`
+	Score  float64    `json:"score"`
+	Level  string     `json:"level"`
+	Report jsonReport `json:"report"`
+}
+
+// GenerateJSONReport 生成工作区JSON报告
+func (w *WorkspaceReport) GenerateJSONReport(options *ReportOptions) error {
+	if options == nil {
+		options = DefaultReportOptions
+	}
+
+	overall := w.overall()
+	level := overall.getQualityLevel(w.result.Overall.CodeQualityScore)
+	output := jsonWorkspaceReport{
+		Score:       roundScore(w.result.Overall.CodeQualityScore),
+		Level:       w.translator.Translate(level.NameKey),
+		Aggregation: string(w.result.Overall.Aggregation),
+		TotalFiles:  w.result.Overall.TotalFiles,
+		TotalLines:  w.result.Overall.TotalLines,
+		TotalIssues: overall.getTotalIssues(),
+		Projects:    []jsonProject{},
+	}
+
+	for _, row := range w.rows() {
+		output.Projects = append(output.Projects, jsonProject{
+			Name:   row.project.Name,
+			Kind:   row.project.Kind,
+			Path:   row.path,
+			Score:  roundScore(row.score),
+			Level:  row.level,
+			Report: row.report.buildJSONReport(options),
+		})
+	}
+
+	return writeJSON(output)
+}
+
+// displayWidth 估算字符串在终端中的显示宽度，中日韩字符和表情按两列计算
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// 变体选择符和组合字符不占宽度
+		case r >= 0x1100 && (unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hangul, r) ||
+			unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) ||
+			(r >= 0xFF00 && r <= 0xFF60) || r >= 0x1F000 || (r >= 0x2600 && r <= 0x27BF)):
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+// padDisplay 按显示宽度在右侧补空格
+func padDisplay(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-displayWidth(s)))
+}
+
+// columnWidths 计算各列的最大显示宽度
+func columnWidths(headers []string, rows [][]string) []int {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = displayWidth(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], displayWidth(cell))
+		}
+	}
+	return widths
+}
+
+// joinPadded 将补齐后的各列用两个空格连接
+func joinPadded(cells []string, widths []int) string {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		padded[i] = padDisplay(cell, widths[i])
+	}
+	return strings.TrimRight(strings.Join(padded, "  "), " ")
+}
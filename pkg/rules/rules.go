@@ -0,0 +1,258 @@
+// Package rules 提供项目配置中声明的自定义规则
+// 创建者：Done-0
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+)
+
+// 规则严重程度
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// DefaultCategory 未指定类别时使用的类别
+const DefaultCategory = "custom"
+
+// Rule 声明式自定义规则
+// Pattern/Patterns 按行匹配原始代码，Structure 在去除注释和字符串后按代码结构匹配
+type Rule struct {
+	ID           string            `json:"id"`            // 规则ID
+	Message      string            `json:"message"`       // 命中时的提示信息
+	Severity     string            `json:"severity"`      // 严重程度：error、warning、info，默认warning
+	Category     string            `json:"category"`      // 问题类别，默认custom
+	Languages    []string          `json:"languages"`     // 生效的语言，为空表示所有语言
+	Pattern      string            `json:"pattern"`       // 所有语言通用的正则
+	Patterns     map[string]string `json:"patterns"`      // 按语言指定的正则
+	NotPattern   string            `json:"not_pattern"`   // 同一行匹配该正则时不算命中
+	Structure    string            `json:"structure"`     // 结构化模式，如 "fmt.Println(...)"
+	Paths        []string          `json:"paths"`         // 只在匹配的路径中生效，如 "pkg/**"
+	ExcludePaths []string          `json:"exclude_paths"` // 不在匹配的路径中生效
+}
+
+// Violation 规则命中记录
+type Violation struct {
+	RuleID   string // 规则ID
+	Message  string // 提示信息
+	Severity string // 严重程度
+	Category string // 问题类别
+	Line     int    // 命中行号，从1开始
+}
+
+// Set 编译后的规则集合
+type Set struct {
+	root  string
+	rules []*compiledRule
+}
+
+// compiledRule 编译后的规则
+type compiledRule struct {
+	Rule
+	languages  map[common.LanguageType]bool
+	pattern    *regexp.Regexp
+	patterns   map[common.LanguageType]*regexp.Regexp
+	notPattern *regexp.Regexp
+	structure  *regexp.Regexp
+}
+
+// knownLanguages 规则中可以使用的语言名称
+var knownLanguages = map[string]common.LanguageType{
+	string(common.Go):         common.Go,
+	string(common.JavaScript): common.JavaScript,
+	string(common.TypeScript): common.TypeScript,
+	string(common.Python):     common.Python,
+	string(common.Java):       common.Java,
+	string(common.CPlusPlus):  common.CPlusPlus,
+	string(common.C):          common.C,
+	string(common.CSharp):     common.CSharp,
+}
+
+// Compile 编译规则，root为路径模式的基准目录（通常是配置文件所在目录）
+func Compile(root string, rules []Rule) (*Set, error) {
+	if absRoot, err := filepath.Abs(root); err == nil {
+		root = absRoot
+	}
+
+	set := &Set{root: root}
+	for i, rule := range rules {
+		compiled, err := compileRule(rule)
+		if err != nil {
+			name := rule.ID
+			if name == "" {
+				name = fmt.Sprintf("#%d", i+1)
+			}
+			return nil, fmt.Errorf("规则 %s 无效: %w", name, err)
+		}
+		set.rules = append(set.rules, compiled)
+	}
+
+	return set, nil
+}
+
+// compileRule 校验并编译单条规则
+func compileRule(rule Rule) (*compiledRule, error) {
+	if rule.ID == "" {
+		return nil, fmt.Errorf("缺少id")
+	}
+	if rule.Message == "" {
+		return nil, fmt.Errorf("缺少message")
+	}
+	if rule.Pattern == "" && len(rule.Patterns) == 0 && rule.Structure == "" {
+		return nil, fmt.Errorf("pattern、patterns、structure至少需要一个")
+	}
+
+	switch rule.Severity {
+	case "":
+		rule.Severity = SeverityWarning
+	case SeverityError, SeverityWarning, SeverityInfo:
+	default:
+		return nil, fmt.Errorf("未知的severity %q", rule.Severity)
+	}
+	if rule.Category == "" {
+		rule.Category = DefaultCategory
+	}
+
+	compiled := &compiledRule{Rule: rule}
+
+	if len(rule.Languages) > 0 {
+		compiled.languages = make(map[common.LanguageType]bool)
+		for _, name := range rule.Languages {
+			lang, ok := knownLanguages[name]
+			if !ok {
+				return nil, fmt.Errorf("未知的语言 %q", name)
+			}
+			compiled.languages[lang] = true
+		}
+	}
+
+	var err error
+	if rule.Pattern != "" {
+		if compiled.pattern, err = regexp.Compile(rule.Pattern); err != nil {
+			return nil, err
+		}
+	}
+	if rule.NotPattern != "" {
+		if compiled.notPattern, err = regexp.Compile(rule.NotPattern); err != nil {
+			return nil, err
+		}
+	}
+	if len(rule.Patterns) > 0 {
+		compiled.patterns = make(map[common.LanguageType]*regexp.Regexp)
+		for name, pattern := range rule.Patterns {
+			lang, ok := knownLanguages[name]
+			if !ok {
+				return nil, fmt.Errorf("未知的语言 %q", name)
+			}
+			if compiled.patterns[lang], err = regexp.Compile(pattern); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if rule.Structure != "" {
+		if compiled.structure, err = compileStructure(rule.Structure); err != nil {
+			return nil, err
+		}
+	}
+
+	return compiled, nil
+}
+
+// Len 返回规则数量
+func (s *Set) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.rules)
+}
+
+// Check 检查文件内容，返回按行号排序的命中记录
+func (s *Set) Check(path string, language common.LanguageType, content []byte) []Violation {
+	if s.Len() == 0 {
+		return nil
+	}
+
+	absPath := path
+	if p, err := filepath.Abs(path); err == nil {
+		absPath = p
+	}
+
+	lines := strings.Split(string(content), "\n")
+	var stripped string
+
+	var violations []Violation
+	for _, rule := range s.rules {
+		if !rule.appliesTo(absPath, s.root, language) {
+			continue
+		}
+
+		// 按行匹配正则
+		pattern := rule.pattern
+		if langPattern, ok := rule.patterns[language]; ok {
+			pattern = langPattern
+		}
+		if pattern != nil {
+			for i, line := range lines {
+				if pattern.MatchString(line) && (rule.notPattern == nil || !rule.notPattern.MatchString(line)) {
+					violations = append(violations, rule.violation(i+1))
+				}
+			}
+		}
+
+		// 结构化匹配，忽略注释和字符串中的内容
+		if rule.structure != nil {
+			if stripped == "" {
+				stripped = stripCommentsAndStrings(string(content), language)
+			}
+			for _, loc := range rule.structure.FindAllStringIndex(stripped, -1) {
+				violations = append(violations, rule.violation(strings.Count(stripped[:loc[0]], "\n")+1))
+			}
+		}
+	}
+
+	sortViolations(violations)
+	return violations
+}
+
+// appliesTo 判断规则是否适用于指定文件
+func (r *compiledRule) appliesTo(path, root string, language common.LanguageType) bool {
+	if r.languages != nil && !r.languages[language] {
+		return false
+	}
+
+	// 只有按语言指定的正则且当前语言没有对应正则时，规则不适用
+	if r.pattern == nil && r.structure == nil && r.patterns[language] == nil {
+		return false
+	}
+
+	if len(r.Paths) > 0 && !common.MatchesAnyPattern(path, root, r.Paths) {
+		return false
+	}
+
+	return !common.MatchesAnyPattern(path, root, r.ExcludePaths)
+}
+
+// violation 创建命中记录
+func (r *compiledRule) violation(line int) Violation {
+	return Violation{
+		RuleID:   r.ID,
+		Message:  r.Message,
+		Severity: r.Severity,
+		Category: r.Category,
+		Line:     line,
+	}
+}
+
+// sortViolations 按行号排序，行号相同时保持规则顺序
+func sortViolations(violations []Violation) {
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Line < violations[j].Line
+	})
+}
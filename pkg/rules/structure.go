@@ -0,0 +1,144 @@
+// Package rules 提供项目配置中声明的自定义规则
+// 创建者：Done-0
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+)
+
+// compileStructure 将结构化模式编译为正则
+// 模式语法：
+//   - ... 匹配任意内容（可跨行）
+//   - $NAME 匹配任意标识符
+//   - 空白匹配任意数量的空白，符号两侧允许任意空白
+func compileStructure(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	runes := []rune(strings.TrimSpace(pattern))
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '.' && i+2 < len(runes) && runes[i+1] == '.' && runes[i+2] == '.':
+			sb.WriteString(`(?s:.*?)`)
+			i += 3
+		case r == '$' && i+1 < len(runes) && isIdentStart(runes[i+1]):
+			j := i + 1
+			for j < len(runes) && isIdentPart(runes[j]) {
+				j++
+			}
+			sb.WriteString(`[A-Za-z_$][\w$]*`)
+			i = j
+		case unicode.IsSpace(r):
+			for i < len(runes) && unicode.IsSpace(runes[i]) {
+				i++
+			}
+			sb.WriteString(`\s*`)
+		case isIdentStart(r):
+			j := i
+			for j < len(runes) && isIdentPart(runes[j]) {
+				j++
+			}
+			word := string(runes[i:j])
+			// 标识符前后加单词边界，避免匹配到更长标识符的一部分
+			sb.WriteString(`\b` + regexp.QuoteMeta(word))
+			if j == len(runes) || !isIdentPart(runes[j]) {
+				sb.WriteString(`\b`)
+			}
+			i = j
+		default:
+			sb.WriteString(`\s*` + regexp.QuoteMeta(string(r)) + `\s*`)
+			i++
+		}
+	}
+
+	if sb.Len() == 0 {
+		return nil, fmt.Errorf("structure不能为空")
+	}
+	return regexp.Compile(sb.String())
+}
+
+// isIdentStart 判断字符能否作为标识符开头
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+// isIdentPart 判断字符能否作为标识符的一部分
+func isIdentPart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// stripCommentsAndStrings 将注释和字符串字面量替换为空格，保留换行以维持行号
+func stripCommentsAndStrings(content string, language common.LanguageType) string {
+	hashComments := language == common.Python
+	runes := []rune(content)
+	out := make([]rune, len(runes))
+	copy(out, runes)
+
+	blank := func(from, to int) {
+		for k := from; k < to && k < len(out); k++ {
+			if out[k] != '\n' {
+				out[k] = ' '
+			}
+		}
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		switch {
+		case hashComments && r == '#', !hashComments && r == '/' && next == '/':
+			end := i
+			for end < len(runes) && runes[end] != '\n' {
+				end++
+			}
+			blank(i, end)
+			i = end
+		case !hashComments && r == '/' && next == '*':
+			end := i + 2
+			for end+1 < len(runes) && !(runes[end] == '*' && runes[end+1] == '/') {
+				end++
+			}
+			end = min(end+2, len(runes))
+			blank(i, end)
+			i = end
+		case hashComments && (r == '"' || r == '\'') && i+2 < len(runes) && next == r && runes[i+2] == r:
+			// Python三引号字符串
+			end := i + 3
+			for end+2 < len(runes) && !(runes[end] == r && runes[end+1] == r && runes[end+2] == r) {
+				end++
+			}
+			end = min(end+3, len(runes))
+			blank(i, end)
+			i = end
+		case r == '"' || r == '\'' || r == '`':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				// 只有反引号字符串可以跨行，其余字符串遇到换行即结束
+				if runes[end] == '\n' && r != '`' {
+					break
+				}
+				if runes[end] == '\\' && r != '`' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(runes))
+			// 保留引号本身，只清空内容
+			blank(i+1, end-1)
+			i = end
+		default:
+			i++
+		}
+	}
+
+	return string(out)
+}
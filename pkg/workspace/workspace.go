@@ -0,0 +1,283 @@
+// Package workspace 识别仓库中的子项目，用于按项目分别评分
+// 创建者：Done-0
+package workspace
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Done-0/fuck-u-code/pkg/common"
+)
+
+// Kind 子项目类型
+type Kind string
+
+// 子项目类型
+const (
+	KindNone   Kind = ""       // 不属于任何子项目
+	KindGo     Kind = "go"     // go.mod
+	KindNode   Kind = "node"   // package.json，包括 workspaces 中的各个包
+	KindPython Kind = "python" // pyproject.toml 或 setup.py
+	KindMaven  Kind = "maven"  // pom.xml
+	KindGradle Kind = "gradle" // build.gradle 或 build.gradle.kts
+	KindDotNet Kind = "dotnet" // .csproj 或 .sln
+)
+
+// Project 子项目及其包含的源码文件
+type Project struct {
+	Name  string   // 项目名称，取自清单文件，取不到时使用目录名
+	Path  string   // 项目目录
+	Kind  Kind     // 项目类型
+	Files []string // 属于该项目的源码文件
+}
+
+// marker 项目清单文件
+type marker struct {
+	kind      Kind
+	fileName  string                                // 精确文件名，为空时按扩展名匹配
+	extension string                                // 文件扩展名
+	name      func(path string, data []byte) string // 从清单中读取项目名称
+}
+
+// markers 按优先级排列的清单文件，同一目录有多个清单时使用第一个
+var markers = []marker{
+	{kind: KindGo, fileName: "go.mod", name: goModuleName},
+	{kind: KindNode, fileName: "package.json", name: packageJSONName},
+	{kind: KindPython, fileName: "pyproject.toml", name: regexName(regexp.MustCompile(`(?m)^name\s*=\s*["']([^"']+)["']`))},
+	{kind: KindPython, fileName: "setup.py", name: regexName(regexp.MustCompile(`\bname\s*=\s*["']([^"']+)["']`))},
+	{kind: KindMaven, fileName: "pom.xml", name: pomArtifactID},
+	{kind: KindGradle, fileName: "build.gradle"},
+	{kind: KindGradle, fileName: "build.gradle.kts"},
+	{kind: KindDotNet, extension: ".csproj", name: fileBaseName},
+	{kind: KindDotNet, extension: ".sln", name: fileBaseName},
+}
+
+var (
+	goModulePattern = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+	pomParent       = regexp.MustCompile(`(?s)<parent>.*?</parent>`)
+	pomArtifact     = regexp.MustCompile(`<artifactId>\s*([^<\s]+)\s*</artifactId>`)
+)
+
+// Group 将源码文件按所属的最近子项目分组，结果按项目路径排序
+// 不属于任何子项目的文件归入以root为路径、类型为KindNone的项目
+func Group(root string, files []string) []Project {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		absRoot = root
+	}
+
+	detected := make(map[string]*Project) // 目录 -> 子项目，nil表示不是子项目
+	projects := make(map[string]*Project)
+	members := workspaceMembers(absRoot, files)
+
+	for _, file := range files {
+		project := findProject(absRoot, file, detected, members)
+		if project == nil {
+			// 未归属的文件统一放入根目录项目
+			if project = projects[""]; project == nil {
+				project = &Project{Name: filepath.Base(absRoot), Path: absRoot}
+			}
+			projects[""] = project
+		} else {
+			projects[project.Path] = project
+		}
+		project.Files = append(project.Files, file)
+	}
+
+	result := make([]Project, 0, len(projects))
+	for _, p := range projects {
+		result = append(result, *p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result
+}
+
+// findProject 从文件所在目录向上查找最近的子项目，不超过root
+func findProject(root, file string, detected map[string]*Project, members map[string]bool) *Project {
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return nil
+	}
+
+	for dir := filepath.Dir(absFile); ; dir = filepath.Dir(dir) {
+		project, ok := detected[dir]
+		if !ok {
+			project = detectProject(dir, members)
+			detected[dir] = project
+		}
+		if project != nil {
+			return project
+		}
+		if dir == root || dir == filepath.Dir(dir) || !strings.HasPrefix(dir, root) {
+			return nil
+		}
+	}
+}
+
+// detectProject 判断目录是否为子项目，不是时返回nil
+// 没有清单文件但在package.json的workspaces中声明的目录也是子项目
+func detectProject(dir string, members map[string]bool) *Project {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	for _, m := range markers {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			if (m.fileName != "" && entry.Name() != m.fileName) ||
+				(m.fileName == "" && filepath.Ext(entry.Name()) != m.extension) {
+				continue
+			}
+
+			project := &Project{Name: filepath.Base(dir), Path: dir, Kind: m.kind}
+			if m.name != nil {
+				path := filepath.Join(dir, entry.Name())
+				if data, err := os.ReadFile(path); err == nil {
+					if name := m.name(path, data); name != "" {
+						project.Name = name
+					}
+				}
+			}
+			return project
+		}
+	}
+
+	if members[dir] {
+		return &Project{Name: filepath.Base(dir), Path: dir, Kind: KindNode}
+	}
+	return nil
+}
+
+// workspaceMembers 找出root下各package.json的workspaces声明的成员目录
+// 只检查源码文件所在的各级目录，模式相对于package.json所在目录，以!开头的模式排除成员
+func workspaceMembers(root string, files []string) map[string]bool {
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, file := range files {
+		absFile, err := filepath.Abs(file)
+		if err != nil {
+			continue
+		}
+		for dir := filepath.Dir(absFile); !seen[dir] && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+			if dir == root || dir == filepath.Dir(dir) {
+				break
+			}
+		}
+	}
+
+	members := make(map[string]bool)
+	for _, dir := range dirs {
+		data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+		if err != nil {
+			continue
+		}
+		include, exclude := splitWorkspacePatterns(workspacePatterns(data))
+		if len(include) == 0 {
+			continue
+		}
+		for _, candidate := range dirs {
+			if candidate == dir || !strings.HasPrefix(candidate, dir+string(filepath.Separator)) {
+				continue
+			}
+			if common.MatchesAnyPattern(candidate, dir, include) && !common.MatchesAnyPattern(candidate, dir, exclude) {
+				members[candidate] = true
+			}
+		}
+	}
+	return members
+}
+
+// workspacePatterns 读取package.json中workspaces声明的目录模式
+// 支持数组形式和Yarn的 {"packages": [...]} 形式
+func workspacePatterns(data []byte) []string {
+	var pkg struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil || len(pkg.Workspaces) == 0 {
+		return nil
+	}
+
+	var patterns []string
+	if err := json.Unmarshal(pkg.Workspaces, &patterns); err == nil {
+		return patterns
+	}
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(pkg.Workspaces, &object); err == nil {
+		return object.Packages
+	}
+	return nil
+}
+
+// splitWorkspacePatterns 将workspaces模式分为包含和排除两组，并转换为相对于package.json所在目录的glob
+func splitWorkspacePatterns(patterns []string) (include, exclude []string) {
+	for _, pattern := range patterns {
+		negate := strings.HasPrefix(pattern, "!")
+		pattern = strings.Trim(strings.TrimPrefix(strings.TrimPrefix(pattern, "!"), "./"), "/")
+		if pattern == "" || pattern == "." {
+			continue
+		}
+		// 加上/前缀，使不含/的模式（如 "app"）只匹配package.json所在目录下的同名目录
+		if negate {
+			exclude = append(exclude, "/"+pattern)
+		} else {
+			include = append(include, "/"+pattern)
+		}
+	}
+	return include, exclude
+}
+
+// goModuleName 读取go.mod中的模块路径
+func goModuleName(_ string, data []byte) string {
+	if match := goModulePattern.FindSubmatch(data); match != nil {
+		return string(match[1])
+	}
+	return ""
+}
+
+// packageJSONName 读取package.json中的name
+func packageJSONName(_ string, data []byte) string {
+	var pkg struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return ""
+	}
+	return pkg.Name
+}
+
+// pomArtifactID 读取pom.xml中项目自身的artifactId，跳过parent中的
+func pomArtifactID(_ string, data []byte) string {
+	if match := pomArtifact.FindSubmatch(pomParent.ReplaceAll(data, nil)); match != nil {
+		return string(match[1])
+	}
+	return ""
+}
+
+// regexName 返回用正则第一个分组作为名称的读取函数
+func regexName(pattern *regexp.Regexp) func(string, []byte) string {
+	return func(_ string, data []byte) string {
+		if match := pattern.FindSubmatch(data); match != nil {
+			return string(match[1])
+		}
+		return ""
+	}
+}
+
+// fileBaseName 使用不带扩展名的清单文件名作为名称
+func fileBaseName(path string, _ []byte) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}